@@ -109,6 +109,26 @@ func (c *Client) EditStreamEndpoint(ctx context.Context, apiKey string, endpoint
 	return res, nil
 }
 
+// RotateStreamEndpointKey replaces an endpoint's key, the new key is only returned here
+func (c *Client) RotateStreamEndpointKey(ctx context.Context, apiKey string, endpointID int) (stream.RotatedKey, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/v1/internal/streams/%d/rotate-key", c.BaseURL, endpointID))
+	if err != nil {
+		return stream.RotatedKey{}, fmt.Errorf("invalid base URL: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), nil)
+	if err != nil {
+		return stream.RotatedKey{}, err
+	}
+
+	var res stream.RotatedKey
+	if err = c.sendRequest(req, apiKey, &res); err != nil {
+		return stream.RotatedKey{}, err
+	}
+
+	return res, nil
+}
+
 func (c *Client) DeleteStreamEndpoint(ctx context.Context, apiKey string, endpointID int) error {
 	u, err := url.Parse(fmt.Sprintf("%s/v1/internal/streams/%d", c.BaseURL, endpointID))
 	if err != nil {
@@ -13,7 +13,9 @@ import (
 	"github.com/ystv/web-api/services/creator/encode"
 	"github.com/ystv/web-api/services/creator/playlist"
 	"github.com/ystv/web-api/services/creator/playout"
+	"github.com/ystv/web-api/services/creator/preferences"
 	"github.com/ystv/web-api/services/creator/series"
+	"github.com/ystv/web-api/services/creator/storage"
 	"github.com/ystv/web-api/services/creator/video"
 	"github.com/ystv/web-api/services/encoder"
 	"github.com/ystv/web-api/utils"
@@ -22,11 +24,13 @@ import (
 // Repos represents all our data repositories
 type (
 	Repos interface {
-		Stats(c echo.Context) error
 		EncodeRepo
 		PlaylistRepo
 		PlayoutRepo
+		PreferencesRepo
 		SeriesRepo
+		StatsRepo
+		StorageRepo
 		VideoRepo
 	}
 
@@ -35,10 +39,13 @@ type (
 		NewEncodeFormat(c echo.Context) error
 		UpdateEncodeFormat(c echo.Context) error
 		DeleteEncodeFormat(c echo.Context) error
+		PreviewEncodeFormat(c echo.Context) error
 		ListEncodePresets(c echo.Context) error
 		NewEncodePreset(c echo.Context) error
 		UpdateEncodePreset(c echo.Context) error
 		DeleteEncodePreset(c echo.Context) error
+		ExportEncodePresets(c echo.Context) error
+		ImportEncodePresets(c echo.Context) error
 	}
 
 	PlaylistRepo interface {
@@ -54,6 +61,15 @@ type (
 		NewChannel(c echo.Context) error
 		UpdateChannel(c echo.Context) error
 		DeleteChannel(c echo.Context) error
+		ListChannelSchedule(c echo.Context) error
+		NewScheduleItem(c echo.Context) error
+		UpdateScheduleItem(c echo.Context) error
+		DeleteScheduleItem(c echo.Context) error
+	}
+
+	PreferencesRepo interface {
+		GetPreferences(c echo.Context) error
+		UpdatePreferences(c echo.Context) error
 	}
 
 	SeriesRepo interface {
@@ -63,8 +79,22 @@ type (
 		DeleteSeries(c echo.Context) error
 	}
 
+	StatsRepo interface {
+		Stats(c echo.Context) error
+		VideoStats(c echo.Context) error
+		SeriesStats(c echo.Context) error
+	}
+
+	StorageRepo interface {
+		GetStorageGCReport(c echo.Context) error
+		CollectStorageGarbage(c echo.Context) error
+		StorageUsage(c echo.Context) error
+		StorageUsageCSV(c echo.Context) error
+	}
+
 	VideoRepo interface {
 		GetVideo(c echo.Context) error
+		GetVideoFileURL(c echo.Context) error
 		NewVideo(c echo.Context) error
 		UpdateVideoMeta(c echo.Context) error
 		DeleteVideo(c echo.Context) error
@@ -75,28 +105,33 @@ type (
 	}
 
 	Store struct {
-		access     utils.Repo
-		video      creator.VideoRepo
-		series     creator.SeriesRepo
-		playlist   creator.PlaylistRepo
-		channel    creator.ChannelRepo
-		breadcrumb creator.BreadcrumbRepo
-		encode     creator.EncodeRepo
-		creator    creator.StatRepo
+		access      utils.Repo
+		video       creator.VideoRepo
+		series      creator.SeriesRepo
+		playlist    creator.PlaylistRepo
+		channel     creator.ChannelRepo
+		breadcrumb  creator.BreadcrumbRepo
+		encode      creator.EncodeRepo
+		storage     creator.StorageRepo
+		encoder     encoder.Repo
+		preferences creator.PreferencesRepo
+		creator     creator.StatRepo
 	}
 
 	Config struct {
-		IngestBucket string
-		ServeBucket  string
+		IngestBucket    string
+		ServeBucket     string
+		RecordingBucket string
 	}
 )
 
 // NewRepos creates our data repositories
 func NewRepos(db *sqlx.DB, cdn *s3.S3, enc encoder.Repo, access utils.Repo, conf *Config, cdnEndpoint string) Repos {
 	config := &creator.Config{
-		IngestBucket: conf.IngestBucket,
-		ServeBucket:  conf.ServeBucket,
-		Endpoint:     cdnEndpoint,
+		IngestBucket:    conf.IngestBucket,
+		ServeBucket:     conf.ServeBucket,
+		RecordingBucket: conf.RecordingBucket,
+		Endpoint:        cdnEndpoint,
 	}
 	return &Store{
 		access,
@@ -106,6 +141,9 @@ func NewRepos(db *sqlx.DB, cdn *s3.S3, enc encoder.Repo, access utils.Repo, conf
 		playout.NewStore(db, cdn, config),
 		breadcrumb.NewController(db, cdn, enc, config),
 		encode.NewStore(db),
+		storage.NewStore(db, cdn, config),
+		enc,
+		preferences.NewStore(db),
 		creator.NewStore(db),
 	}
 }
@@ -6,10 +6,14 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/labstack/echo/v4"
+	"gopkg.in/yaml.v3"
 
+	encodeService "github.com/ystv/web-api/services/creator/encode"
 	"github.com/ystv/web-api/services/creator/types/encode"
+	"github.com/ystv/web-api/services/encoder"
 	"github.com/ystv/web-api/utils"
 )
 
@@ -33,7 +37,8 @@ func (s *Store) ListEncodeFormats(c echo.Context) error {
 
 // NewEncodeFormat handles creating a new encode format
 // @Summary New encode format
-// @Description creates a new encode format.
+// @Description creates a new encode format. The arguments are checked for options that touch
+// @Description other files or shell syntax, and against the width, height and mime type.
 // @ID new-creator-encode-format
 // @Tags creator-encodes
 // @Accept json
@@ -51,6 +56,9 @@ func (s *Store) NewEncodeFormat(c echo.Context) error {
 
 	formatID, err := s.encode.NewFormat(c.Request().Context(), format)
 	if err != nil {
+		if errors.Is(err, encodeService.ErrInvalidFormat) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
 		err = fmt.Errorf("NewFormat failed: %w", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, err)
 	}
@@ -81,6 +89,9 @@ func (s *Store) UpdateEncodeFormat(c echo.Context) error {
 		if errors.Is(err, sql.ErrNoRows) {
 			return echo.NewHTTPError(http.StatusBadRequest, "no preset found")
 		}
+		if errors.Is(err, encodeService.ErrInvalidFormat) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
 		err = fmt.Errorf("PresetUpdate failed: %w", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, err)
 	}
@@ -88,6 +99,46 @@ func (s *Store) UpdateEncodeFormat(c echo.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
+// PreviewEncodeFormat handles previewing an encode
+// @Summary Preview an encode
+// @Description Renders the task and ffmpeg command that encoding a video's source file in the
+// @Description format would send to VT, including the key the encode would be written to.
+// @Description Nothing is encoded.
+// @ID get-creator-encode-format-preview
+// @Tags creator-encodes
+// @Produce json
+// @Param formatid path int true "Format ID"
+// @Param videoID query int true "Video ID"
+// @Success 200 {object} encoder.EncodePreview
+// @Router /v1/internal/creator/encode/format/{formatid}/preview [get]
+func (s *Store) PreviewEncodeFormat(c echo.Context) error {
+	formatID, err := strconv.Atoi(c.Param("formatid"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid format ID")
+	}
+
+	videoID, err := strconv.Atoi(c.QueryParam("videoID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid video ID")
+	}
+
+	p, err := s.encoder.PreviewEncode(c.Request().Context(), videoID, formatID)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows), errors.Is(err, encoder.ErrFormatNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		case errors.Is(err, encodeService.ErrInvalidFormat), errors.Is(err, encoder.ErrNoArgs),
+			errors.Is(err, encoder.ErrNoVideoFiles), errors.Is(err, encoder.ErrNoSourceFile),
+			errors.Is(err, encoder.ErrTooManySourceFiles):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		err = fmt.Errorf("PreviewEncode failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, p)
+}
+
 // DeleteEncodeFormat handles deleting quotes
 // @Summary Delete an encode format
 // @Description Delete a video encode format
@@ -208,3 +259,79 @@ func (s *Store) DeleteEncodePreset(c echo.Context) error {
 
 	return c.NoContent(http.StatusOK)
 }
+
+// ExportEncodePresets handles exporting presets and formats
+// @Summary Export encode presets
+// @Description Exports every encode preset and format as a versioned document, formats are
+// @Description referenced by name so it can be imported into another environment.
+// @ID get-creator-encode-presets-export
+// @Tags creator-encodes
+// @Produce json
+// @Produce application/yaml
+// @Param format query string false "json or yaml, defaults to json"
+// @Success 200 {object} encode.PresetDocument
+// @Router /v1/internal/creator/encode/export [get]
+func (s *Store) ExportEncodePresets(c echo.Context) error {
+	doc, err := s.encode.ExportPresets(c.Request().Context())
+	if err != nil {
+		err = fmt.Errorf("ExportPresets failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	switch c.QueryParam("format") {
+	case "", "json":
+		c.Response().Header().Set(echo.HeaderContentDisposition, "attachment; filename=\"encode-presets.json\"")
+		return c.JSONPretty(http.StatusOK, doc, "  ")
+	case "yaml":
+		b, err := yaml.Marshal(doc)
+		if err != nil {
+			err = fmt.Errorf("ExportPresets failed to marshal yaml: %w", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, err)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentDisposition, "attachment; filename=\"encode-presets.yaml\"")
+		return c.Blob(http.StatusOK, "application/yaml", b)
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid format, must be json or yaml")
+	}
+}
+
+// ImportEncodePresets handles importing presets and formats
+// @Summary Import encode presets
+// @Description Imports a document made by export, matching formats and presets by name. Without apply
+// @Description it only reports what would be created and updated. Nothing is deleted, so importing
+// @Description the same document again changes nothing. YAML is read when the content type is yaml.
+// @ID import-creator-encode-presets
+// @Tags creator-encodes
+// @Accept json
+// @Accept application/yaml
+// @Produce json
+// @Param document body encode.PresetDocument true "Preset document"
+// @Param apply query bool false "Apply the changes"
+// @Success 200 {object} encode.ImportReport
+// @Router /v1/internal/creator/encode/import [post]
+func (s *Store) ImportEncodePresets(c echo.Context) error {
+	var doc encode.PresetDocument
+
+	var err error
+	if strings.Contains(c.Request().Header.Get(echo.HeaderContentType), "yaml") {
+		err = yaml.NewDecoder(c.Request().Body).Decode(&doc)
+	} else {
+		err = c.Bind(&doc)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to decode preset document: %w", err)
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	report, err := s.encode.ImportPresets(c.Request().Context(), doc, c.QueryParam("apply") == "true")
+	if err != nil {
+		if errors.Is(err, encodeService.ErrInvalidDocument) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		err = fmt.Errorf("ImportPresets failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
@@ -32,7 +34,8 @@ func (s *Store) ListChannels(c echo.Context) error {
 
 // NewChannel handles creating a new channel
 // @Summary New channel
-// @Description creates a new channel.
+// @Description creates a new channel. Linking it to a stream endpoint moves it between scheduled,
+// @Description live and finished as the endpoint publishes and its scheduled times pass.
 // @ID new-creator-playout-channel
 // @Tags creator-playout-channels
 // @Accept json
@@ -50,6 +53,9 @@ func (s *Store) NewChannel(c echo.Context) error {
 
 	err = s.channel.NewChannel(c.Request().Context(), ch)
 	if err != nil {
+		if errors.Is(err, playout.ErrInvalidChannel) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
 		err = fmt.Errorf("NewChannel failed: %w", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, err)
 	}
@@ -80,6 +86,9 @@ func (s *Store) UpdateChannel(c echo.Context) error {
 		if errors.Is(err, sql.ErrNoRows) {
 			return echo.NewHTTPError(http.StatusBadRequest, "No channel found")
 		}
+		if errors.Is(err, playout.ErrInvalidChannel) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
 		err = fmt.Errorf("PresetChannel failed: %w", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, err)
 	}
@@ -104,3 +113,160 @@ func (s *Store) DeleteChannel(c echo.Context) error {
 
 	return c.NoContent(http.StatusOK)
 }
+
+// ListChannelSchedule handles listing a channel's schedule
+// @Summary List a channel's schedule
+// @Description Lists the items on a channel's schedule between from and to, defaulting to the
+// @Description day before through the next two weeks.
+// @ID get-creator-playout-channel-schedule
+// @Tags creator-playout-channels
+// @Produce json
+// @Param channelid path string true "Channel URL Name"
+// @Param from query string false "Start of the range, RFC 3339 or YYYY-MM-DD"
+// @Param to query string false "End of the range, RFC 3339 or YYYY-MM-DD"
+// @Success 200 {array} playout.ScheduleItem
+// @Router /v1/internal/creator/playout/channel/{channelid}/schedule [get]
+func (s *Store) ListChannelSchedule(c echo.Context) error {
+	now := time.Now()
+	from, to := now.Add(-24*time.Hour), now.Add(14*24*time.Hour)
+
+	var err error
+
+	if q := c.QueryParam("from"); q != "" {
+		from, err = parseQueryTime(q)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid from")
+		}
+	}
+
+	if q := c.QueryParam("to"); q != "" {
+		to, err = parseQueryTime(q)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid to")
+		}
+	}
+
+	items, err := s.channel.ListSchedule(c.Request().Context(), c.Param("channelid"), from, to)
+	if err != nil {
+		if errors.Is(err, playout.ErrChannelNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		err = fmt.Errorf("ListChannelSchedule failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, utils.NonNil(items))
+}
+
+// NewScheduleItem handles adding an item to a channel's schedule
+// @Summary New schedule item
+// @Description Adds a video, event or text item to a channel's schedule. Items on a channel
+// @Description can't overlap, an item that would is rejected with a conflict.
+// @ID new-creator-playout-channel-schedule-item
+// @Tags creator-playout-channels
+// @Accept json
+// @Param channelid path string true "Channel URL Name"
+// @Param item body playout.ScheduleItem true "Schedule item object"
+// @Success 201 body int "Schedule item ID"
+// @Router /v1/internal/creator/playout/channel/{channelid}/schedule [post]
+func (s *Store) NewScheduleItem(c echo.Context) error {
+	var item playout.ScheduleItem
+
+	err := c.Bind(&item)
+	if err != nil {
+		err = fmt.Errorf("failed to bind to request json: %w", err)
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	claims, status, err := s.access.GetToken(c.Request())
+	if err != nil {
+		err = fmt.Errorf("NewScheduleItem failed to get token: %w", err)
+		return echo.NewHTTPError(status, err)
+	}
+
+	item.ScheduleItemID = 0
+
+	itemID, err := s.channel.NewScheduleItem(c.Request().Context(), c.Param("channelid"), item, claims.UserID)
+	if err != nil {
+		return scheduleItemError("NewScheduleItem", err)
+	}
+
+	return c.JSON(http.StatusCreated, itemID)
+}
+
+// UpdateScheduleItem handles updating an item on a channel's schedule
+// @Summary Update schedule item
+// @Description Updates an item on a channel's schedule. It can't be moved to overlap another item.
+// @ID update-creator-playout-channel-schedule-item
+// @Tags creator-playout-channels
+// @Accept json
+// @Param channelid path string true "Channel URL Name"
+// @Param itemid path int true "Schedule item ID"
+// @Param item body playout.ScheduleItem true "Schedule item object"
+// @Success 200
+// @Router /v1/internal/creator/playout/channel/{channelid}/schedule/{itemid} [put]
+func (s *Store) UpdateScheduleItem(c echo.Context) error {
+	itemID, err := strconv.Atoi(c.Param("itemid"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid schedule item ID")
+	}
+
+	var item playout.ScheduleItem
+
+	err = c.Bind(&item)
+	if err != nil {
+		err = fmt.Errorf("failed to bind to request json: %w", err)
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	claims, status, err := s.access.GetToken(c.Request())
+	if err != nil {
+		err = fmt.Errorf("UpdateScheduleItem failed to get token: %w", err)
+		return echo.NewHTTPError(status, err)
+	}
+
+	item.ScheduleItemID = itemID
+
+	err = s.channel.UpdateScheduleItem(c.Request().Context(), c.Param("channelid"), item, claims.UserID)
+	if err != nil {
+		return scheduleItemError("UpdateScheduleItem", err)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// DeleteScheduleItem handles removing an item from a channel's schedule
+// @Summary Delete schedule item
+// @Description Removes an item from a channel's schedule.
+// @ID delete-creator-playout-channel-schedule-item
+// @Tags creator-playout-channels
+// @Param channelid path string true "Channel URL Name"
+// @Param itemid path int true "Schedule item ID"
+// @Success 200
+// @Router /v1/internal/creator/playout/channel/{channelid}/schedule/{itemid} [delete]
+func (s *Store) DeleteScheduleItem(c echo.Context) error {
+	itemID, err := strconv.Atoi(c.Param("itemid"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid schedule item ID")
+	}
+
+	err = s.channel.DeleteScheduleItem(c.Request().Context(), c.Param("channelid"), itemID)
+	if err != nil {
+		return scheduleItemError("DeleteScheduleItem", err)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// scheduleItemError maps schedule errors to their HTTP status
+func scheduleItemError(handler string, err error) error {
+	switch {
+	case errors.Is(err, playout.ErrChannelNotFound), errors.Is(err, playout.ErrScheduleItemNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, playout.ErrInvalidScheduleItem):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, playout.ErrScheduleOverlap):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("%s failed: %w", handler, err))
+}
@@ -0,0 +1,72 @@
+package creator
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/ystv/web-api/services/creator/preferences"
+)
+
+// GetPreferences handles getting the user's creator preferences
+// @Summary Get creator preferences
+// @Description Gets the user's default preset, series and publish status, list view options
+// @Description and notification choices. Anything they haven't set is the default.
+// @ID get-creator-preferences
+// @Tags creator-preferences
+// @Produce json
+// @Success 200 {object} preferences.Preferences
+// @Router /v1/internal/creator/preferences [get]
+func (s *Store) GetPreferences(c echo.Context) error {
+	claims, status, err := s.access.GetToken(c.Request())
+	if err != nil {
+		err = fmt.Errorf("GetPreferences failed to get token: %w", err)
+		return echo.NewHTTPError(status, err)
+	}
+
+	p, err := s.preferences.GetPreferences(c.Request().Context(), claims.UserID)
+	if err != nil {
+		err = fmt.Errorf("GetPreferences failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, p)
+}
+
+// UpdatePreferences handles replacing the user's creator preferences
+// @Summary Update creator preferences
+// @Description Replaces the user's creator preferences, fields that are left out go back to
+// @Description the default. Unknown fields, values outside of the allowed options and presets
+// @Description or series that don't exist are rejected.
+// @ID update-creator-preferences
+// @Tags creator-preferences
+// @Accept json
+// @Produce json
+// @Param preferences body preferences.Preferences true "Preferences object"
+// @Success 200 {object} preferences.Preferences
+// @Router /v1/internal/creator/preferences [put]
+func (s *Store) UpdatePreferences(c echo.Context) error {
+	p, err := preferences.DecodePreferences(c.Request().Body)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	claims, status, err := s.access.GetToken(c.Request())
+	if err != nil {
+		err = fmt.Errorf("UpdatePreferences failed to get token: %w", err)
+		return echo.NewHTTPError(status, err)
+	}
+
+	err = s.preferences.UpdatePreferences(c.Request().Context(), claims.UserID, p)
+	if err != nil {
+		if errors.Is(err, preferences.ErrInvalidPreferences) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		err = fmt.Errorf("UpdatePreferences failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, p)
+}
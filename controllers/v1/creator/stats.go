@@ -0,0 +1,140 @@
+package creator
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/ystv/web-api/services/creator/types/series"
+	"github.com/ystv/web-api/services/creator/types/stats"
+	"github.com/ystv/web-api/services/creator/types/video"
+)
+
+// defaultStatsRange is how far back stats go when from isn't set
+const defaultStatsRange = 30 * 24 * time.Hour
+
+// VideoStats handles sending how a video has been watched
+// @Summary Get video stats
+// @Description Gets a video's views and unique viewers over time, its mode breakdown,
+// @Description average percent watched and an audience retention histogram.
+// @Description Defaults to the last 30 days by day. Requires VideoStats.
+// @ID get-creator-video-stats
+// @Tags creator-videos
+// @Produce json
+// @Param id path int true "Video ID"
+// @Param from query string false "Start of the range, RFC 3339 or YYYY-MM-DD"
+// @Param to query string false "End of the range, RFC 3339 or YYYY-MM-DD"
+// @Param interval query string false "hour, day, week or month"
+// @Success 200 {object} stats.VideoStats
+// @Router /v1/internal/creator/video/{id}/stats [get]
+func (s *Store) VideoStats(c echo.Context) error {
+	videoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid video ID")
+	}
+
+	r, err := parseStatsRange(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	vs, err := s.creator.VideoStats(c.Request().Context(), videoID, r)
+	if err != nil {
+		switch {
+		case errors.Is(err, video.ErrNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		case errors.Is(err, stats.ErrInvalidRange):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		err = fmt.Errorf("VideoStats failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, vs)
+}
+
+// SeriesStats handles sending how a series has been watched
+// @Summary Get series stats
+// @Description Gets the stats of every video in a series and the series below it rolled up,
+// @Description along with each video's views. Defaults to the last 30 days by day.
+// @Description Requires VideoStats.
+// @ID get-creator-series-stats
+// @Tags creator-series
+// @Produce json
+// @Param seriesid path int true "Series ID"
+// @Param from query string false "Start of the range, RFC 3339 or YYYY-MM-DD"
+// @Param to query string false "End of the range, RFC 3339 or YYYY-MM-DD"
+// @Param interval query string false "hour, day, week or month"
+// @Success 200 {object} stats.SeriesStats
+// @Router /v1/internal/creator/series/{seriesid}/stats [get]
+func (s *Store) SeriesStats(c echo.Context) error {
+	seriesID, err := strconv.Atoi(c.Param("seriesid"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid series ID")
+	}
+
+	r, err := parseStatsRange(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	ss, err := s.creator.SeriesStats(c.Request().Context(), seriesID, r)
+	if err != nil {
+		switch {
+		case errors.Is(err, series.ErrNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		case errors.Is(err, stats.ErrInvalidRange):
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		err = fmt.Errorf("SeriesStats failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, ss)
+}
+
+// parseStatsRange reads the range from the query, defaulting to the last 30 days by day
+func parseStatsRange(c echo.Context) (stats.Range, error) {
+	r := stats.Range{
+		To:       time.Now(),
+		Interval: "day",
+	}
+
+	var err error
+
+	if to := c.QueryParam("to"); to != "" {
+		r.To, err = parseQueryTime(to)
+		if err != nil {
+			return stats.Range{}, fmt.Errorf("invalid to: %w", err)
+		}
+	}
+
+	r.From = r.To.Add(-defaultStatsRange)
+
+	if from := c.QueryParam("from"); from != "" {
+		r.From, err = parseQueryTime(from)
+		if err != nil {
+			return stats.Range{}, fmt.Errorf("invalid from: %w", err)
+		}
+	}
+
+	if interval := c.QueryParam("interval"); interval != "" {
+		r.Interval = interval
+	}
+
+	return r, nil
+}
+
+// parseQueryTime parses a time from the query as either RFC 3339 or a date
+func parseQueryTime(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return t, nil
+	}
+
+	return time.Parse(time.DateOnly, value)
+}
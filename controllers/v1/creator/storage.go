@@ -0,0 +1,193 @@
+package creator
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/ystv/web-api/services/creator/types/storage"
+)
+
+// defaultGCGraceDays is how long an orphan is left before the garbage collector deletes it
+const defaultGCGraceDays = 7
+
+// GetStorageGCReport handles a dry run of the storage garbage collector
+// @Summary Storage garbage collection report
+// @Description Lists the ingest and serve buckets and compares them against video files,
+// @Description thumbnails and avatars. Reports orphaned objects and video file rows whose
+// @Description object is missing, marking those older than the grace period as collectable.
+// @Description Nothing is deleted.
+// @ID get-creator-storage-gc
+// @Tags creator-storage
+// @Produce json
+// @Param graceDays query int false "Grace period in days, defaults to 7"
+// @Success 200 {object} storage.GCReport
+// @Router /v1/internal/creator/storage/gc [get]
+func (s *Store) GetStorageGCReport(c echo.Context) error {
+	cutoff, err := gcCutoff(c)
+	if err != nil {
+		return err
+	}
+
+	r, err := s.storage.CollectGarbage(c.Request().Context(), cutoff, true)
+	if err != nil {
+		err = fmt.Errorf("GetStorageGCReport failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, r)
+}
+
+// CollectStorageGarbage handles running the storage garbage collector
+// @Summary Collect storage garbage
+// @Description Deletes the orphaned objects and dangling video file rows that are older than
+// @Description the grace period. Failed deletions are listed in the report's errors and are
+// @Description retried on the next run. Check the dry run report first.
+// @ID collect-creator-storage-gc
+// @Tags creator-storage
+// @Produce json
+// @Param graceDays query int false "Grace period in days, defaults to 7"
+// @Success 200 {object} storage.GCReport
+// @Router /v1/internal/creator/storage/gc [post]
+func (s *Store) CollectStorageGarbage(c echo.Context) error {
+	cutoff, err := gcCutoff(c)
+	if err != nil {
+		return err
+	}
+
+	r, err := s.storage.CollectGarbage(c.Request().Context(), cutoff, false)
+	if err != nil {
+		err = fmt.Errorf("CollectStorageGarbage failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, r)
+}
+
+// gcCutoff gets the end of the grace period from the graceDays query parameter,
+// it has to be at least a day so uploads in progress aren't collected
+func gcCutoff(c echo.Context) (time.Time, error) {
+	days := defaultGCGraceDays
+
+	if raw := c.QueryParam("graceDays"); raw != "" {
+		var err error
+
+		days, err = strconv.Atoi(raw)
+		if err != nil || days < 1 {
+			return time.Time{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid graceDays, must be at least 1")
+		}
+	}
+
+	return time.Now().AddDate(0, 0, -days), nil
+}
+
+// StorageUsage handles the storage usage breakdown
+// @Summary Storage usage breakdown
+// @Description Breaks down the storage used by the video library by series subtree, creating user,
+// @Description encode format and broadcast year, with what dropping each format would save.
+// @Description Sizes are in bytes. Setting reconcile checks every file against the object store,
+// @Description filling in the actual sizes and listing files whose recorded size is wrong.
+// @ID get-creator-storage-usage
+// @Tags creator-storage
+// @Produce json
+// @Param reconcile query bool false "Reconcile against the object store"
+// @Success 200 {object} storage.Usage
+// @Router /v1/internal/creator/stats/storage [get]
+func (s *Store) StorageUsage(c echo.Context) error {
+	u, err := s.storage.StorageUsage(c.Request().Context(), c.QueryParam("reconcile") == "true")
+	if err != nil {
+		err = fmt.Errorf("StorageUsage failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, u)
+}
+
+// StorageUsageCSV handles exporting the storage usage breakdown
+// @Summary Export storage usage breakdown
+// @Description The storage usage breakdown as a CSV, one row per series, user, format and year.
+// @ID get-creator-storage-usage-csv
+// @Tags creator-storage
+// @Produce text/csv
+// @Param reconcile query bool false "Reconcile against the object store"
+// @Success 200 {string} string "CSV"
+// @Router /v1/internal/creator/stats/storage.csv [get]
+func (s *Store) StorageUsageCSV(c echo.Context) error {
+	u, err := s.storage.StorageUsage(c.Request().Context(), c.QueryParam("reconcile") == "true")
+	if err != nil {
+		err = fmt.Errorf("StorageUsageCSV failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	var b bytes.Buffer
+
+	err = writeUsageCSV(&b, u)
+	if err != nil {
+		err = fmt.Errorf("StorageUsageCSV failed to write csv: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=\"storage-usage-%s.csv\"", time.Now().Format(time.DateOnly)))
+
+	return c.Blob(http.StatusOK, "text/csv; charset=utf-8", b.Bytes())
+}
+
+// writeUsageCSV writes a storage usage breakdown with a row per group, the
+// drop savings columns are only filled in for formats
+func writeUsageCSV(w io.Writer, u storage.Usage) error {
+	cw := csv.NewWriter(w)
+
+	records := [][]string{{"breakdown", "id", "name", "files", "size_bytes", "actual_size_bytes",
+		"missing_files", "drop_savings_bytes", "actual_drop_savings_bytes"}}
+
+	records = append(records, usageRecord(u, "total", "", "", u.Total))
+
+	for _, ser := range u.Series {
+		records = append(records, usageRecord(u, "series", strconv.Itoa(ser.SeriesID),
+			strings.Repeat("  ", ser.Depth)+ser.Name, ser.UsageFigure))
+	}
+
+	for _, user := range u.Users {
+		id := ""
+		if user.UserID.Valid {
+			id = strconv.FormatInt(user.UserID.Int64, 10)
+		}
+		records = append(records, usageRecord(u, "user", id, user.Name, user.UsageFigure))
+	}
+
+	for _, format := range u.Formats {
+		record := usageRecord(u, "format", strconv.Itoa(format.FormatID), format.Name, format.UsageFigure)
+		record[7] = strconv.FormatInt(format.DropSavings, 10)
+		if u.Reconciled {
+			record[8] = strconv.FormatInt(format.DropSavingsActual, 10)
+		}
+		records = append(records, record)
+	}
+
+	for _, year := range u.Years {
+		records = append(records, usageRecord(u, "year", strconv.Itoa(year.Year), "", year.UsageFigure))
+	}
+
+	return cw.WriteAll(records)
+}
+
+// usageRecord is a CSV row of a usage figure, the actual sizes are left empty
+// when the usage wasn't reconciled
+func usageRecord(u storage.Usage, breakdown, id, name string, f storage.UsageFigure) []string {
+	record := []string{breakdown, id, name, strconv.Itoa(f.Files), strconv.FormatInt(f.Size, 10), "", "", "", ""}
+
+	if u.Reconciled {
+		record[5] = strconv.FormatInt(f.ActualSize, 10)
+		record[6] = strconv.Itoa(f.Missing)
+	}
+
+	return record
+}
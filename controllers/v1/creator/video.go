@@ -1,8 +1,10 @@
 package creator
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"time"
 
@@ -10,7 +12,9 @@ import (
 
 	"github.com/ystv/web-api/services/creator/types/video"
 	video2 "github.com/ystv/web-api/services/creator/video"
+	"github.com/ystv/web-api/services/search"
 	"github.com/ystv/web-api/utils"
+	"github.com/ystv/web-api/utils/permissions/users"
 )
 
 // GetVideo finds a video by ID
@@ -38,19 +42,80 @@ func (s *Store) GetVideo(c echo.Context) error {
 	return c.JSON(http.StatusOK, video2.ItemDBToItem(v))
 }
 
+// GetVideoFileURL gets a URL for one of a video's files
+//
+// @Summary Get video file URL
+// @Description Gets a URL to fetch a video file from. Files that aren't public get a signed URL
+// @Description that expires after 15 minutes. Private files are only available to the video's
+// @Description creator and watch admins. Setting download logs a download of the video.
+// @ID get-creator-video-file-url
+// @Tags creator-videos
+// @Produce json
+// @Param videoid path int true "Video ID"
+// @Param fileid path int true "File ID"
+// @Param download query bool false "Download the file"
+// @Success 200 {object} video.FileURL
+// @Router /v1/internal/creator/video/{videoid}/file/{fileid}/url [get]
+func (s *Store) GetVideoFileURL(c echo.Context) error {
+	videoID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid video ID")
+	}
+
+	fileID, err := strconv.Atoi(c.Param("fileid"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid file ID")
+	}
+
+	claims, status, err := s.access.GetToken(c.Request())
+	if err != nil {
+		err = fmt.Errorf("GetVideoFileURL failed to get token: %w", err)
+		return echo.NewHTTPError(status, err)
+	}
+
+	u, err := s.video.GetFileURL(c.Request().Context(), video.FileRequest{
+		VideoID:    videoID,
+		FileID:     fileID,
+		UserID:     claims.UserID,
+		Privileged: slices.Contains(claims.Permissions, users.SuperUser) || slices.Contains(claims.Permissions, users.WatchAdmin),
+		Download:   c.QueryParam("download") == "true",
+		IPAddress:  c.RealIP(),
+		ClientInfo: c.Request().UserAgent(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, video.ErrFileNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		case errors.Is(err, video.ErrFileForbidden):
+			return echo.NewHTTPError(http.StatusForbidden, err.Error())
+		case errors.Is(err, video.ErrFileProcessing):
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+
+		err = fmt.Errorf("GetVideoFileURL failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, u)
+}
+
 type NewVideoOutput struct {
 	VideoID int `json:"id"`
+	// EncodeError is set when the video was created but its preset couldn't be applied
+	EncodeError string `json:"encodeError,omitempty"`
 }
 
 // NewVideo Handles creation of a video
 //
 // @Summary New video
 // @Description creates a new video, requires the file ID/name to find it in CDN.
+// @Description The preset, series and publish type default to the user's creator preferences.
+// @Description If the video is created but its preset can't be applied, encodeError says why.
 // @ID new-creator-video
 // @Tags creator-videos
 // @Accept json
 // @Param event body video.New true "NewVideo object"
-// @Success 201 body int "Video ID"
+// @Success 201 {object} NewVideoOutput
 // @Router /v1/internal/creator/videos [post]
 func (s *Store) NewVideo(c echo.Context) error {
 	var v video.New
@@ -69,8 +134,32 @@ func (s *Store) NewVideo(c echo.Context) error {
 
 	v.CreatedBy = claims.UserID
 
+	// Filling in what was left out from the user's preferences
+	p, err := s.preferences.GetPreferences(c.Request().Context(), claims.UserID)
+	if err != nil {
+		err = fmt.Errorf("VideoNew failed to get preferences: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	if v.PresetID == 0 && p.DefaultPresetID != nil {
+		v.PresetID = *p.DefaultPresetID
+	}
+
+	if v.SeriesID == 0 && p.DefaultSeriesID != nil {
+		v.SeriesID = *p.DefaultSeriesID
+	}
+
+	if v.PublishType == "" {
+		v.PublishType = p.DefaultPublishStatus
+	}
+
 	videoID, err := s.video.NewItem(c.Request().Context(), v)
 	if err != nil {
+		// The video exists once it has an ID, so retrying would make another
+		if videoID != 0 {
+			return c.JSON(http.StatusCreated, NewVideoOutput{VideoID: videoID, EncodeError: err.Error()})
+		}
+
 		err = fmt.Errorf("failed to create new video item: %w", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, err)
 	}
@@ -129,26 +218,38 @@ func (s *Store) DeleteVideo(c echo.Context) error {
 // ListVideos Handles listing all creations
 //
 // @Summary List all videos
-// @Description Lists all videos, doesn't include files inside.
+// @Description Lists all videos by cursor pagination, newest first, doesn't include files inside.
+// @Description Follow the next and prev links to page through the list.
 // @ID get-creator-videos-all
 // @Tags creator-videos
 // @Produce json
-// @Success 200 {array} video.Meta
-// @Router /v1/internal/creator/video [get]
+// @Param cursor query string false "Opaque page cursor"
+// @Param size query int false "Page size"
+// @Success 200 {object} utils.CursorPage[video.Meta]
+// @Router /v1/internal/creator/videos [get]
 func (s *Store) ListVideos(c echo.Context) error {
-	v, err := s.video.ListMeta(c.Request().Context())
+	cursor, size, err := utils.CursorParams(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	v, err := s.video.ListMeta(c.Request().Context(), cursor, size)
 	if err != nil {
 		err = fmt.Errorf("failed to list videos: %w", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, err)
 	}
 
-	meta := make([]video.Meta, 0)
+	page := utils.CursorPage[video.Meta]{
+		Items: make([]video.Meta, 0, len(v.Items)),
+		Next:  v.Next,
+		Prev:  v.Prev,
+	}
 
-	for _, item := range v {
-		meta = append(meta, video2.MetaDBToMeta(item))
+	for _, item := range v.Items {
+		page.Items = append(page.Items, video2.MetaDBToMeta(item))
 	}
 
-	return c.JSON(http.StatusOK, utils.NonNil(meta))
+	return c.JSON(http.StatusOK, page.WithLinks(c.Request().URL))
 }
 
 // ListVideosByUser Handles retrieving a user's videos using their userid in their token.
@@ -214,39 +315,33 @@ func (s *Store) ListVideosByMonth(c echo.Context) error {
 	return c.JSON(http.StatusOK, utils.NonNil(v))
 }
 
-type searchInput struct {
-	Query string `json:"query"`
-}
-
 // SearchVideo Handles listing appropriate videos from the relevant search
 //
 // @Summary Search videos
-// @Description Search videos.
+// @Description Search all videos, including non-public ones, ranked by relevance to the query.
+// @Description Results can be filtered to a series (and its child series), a broadcast year and a duration range.
 // @ID search-creator-videos
 // @Tags creator-videos
 // @Produce json
-// @Param searchInput body searchInput true "Search Input object"
-// @Success 200 {array} video.Meta
+// @Param searchInput body search.Params true "Search parameters object"
+// @Success 200 {object} search.Results
 // @Router /v1/internal/creator/video/search [post]
 func (s *Store) SearchVideo(c echo.Context) error {
-	var input searchInput
+	var params search.Params
 
-	err := c.Bind(&input)
+	err := c.Bind(&params)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err)
 	}
 
-	v, err := s.video.Search(c.Request().Context(), input.Query)
+	res, err := s.video.Search(c.Request().Context(), params)
 	if err != nil {
-		err = fmt.Errorf("public Search failed : %w", err)
+		if errors.Is(err, search.ErrEmptyQuery) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		err = fmt.Errorf("creator Search failed : %w", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, err)
 	}
 
-	metas := make([]video.Meta, 0)
-
-	for _, item := range v {
-		metas = append(metas, video2.MetaDBToMeta(item))
-	}
-
-	return c.JSON(http.StatusOK, utils.NonNil(metas))
+	return c.JSON(http.StatusOK, res)
 }
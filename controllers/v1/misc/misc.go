@@ -14,6 +14,8 @@ type (
 		ListRepo
 		QuoteRepo
 		WebcamRepo
+		RedirectRepo
+		ShortLinkRepo
 	}
 
 	ListRepo interface {
@@ -40,6 +42,23 @@ type (
 		GetWebcam(c echo.Context) error
 	}
 
+	RedirectRepo interface {
+		ListRedirects(c echo.Context) error
+		GetRedirect(c echo.Context) error
+		NewRedirect(c echo.Context) error
+		UpdateRedirect(c echo.Context) error
+		DeleteRedirect(c echo.Context) error
+	}
+
+	ShortLinkRepo interface {
+		ListShortLinks(c echo.Context) error
+		NewShortLink(c echo.Context) error
+		GetShortLink(c echo.Context) error
+		GetShortLinkAnalytics(c echo.Context) error
+		DeleteShortLink(c echo.Context) error
+		ResolveShortLink(c echo.Context) error
+	}
+
 	Store struct {
 		misc   misc.Repos
 		access utils.Repo
@@ -47,9 +66,9 @@ type (
 )
 
 // NewRepos creates our data store
-func NewRepos(db *sqlx.DB, access utils.Repo) Repos {
+func NewRepos(db *sqlx.DB, access utils.Repo, siteURL string) Repos {
 	return &Store{
-		misc:   misc.NewStore(db),
+		misc:   misc.NewStore(db, siteURL),
 		access: access,
 	}
 }
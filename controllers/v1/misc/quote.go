@@ -13,32 +13,28 @@ import (
 
 // ListQuotes handles listing quotes by pagination
 // @Summary List quotes
-// @Description Lists quotes by pagination.
+// @Description Lists quotes by cursor pagination, newest first.
+// @Description Follow the next and prev links to page through the list.
 // @ID get-quotes
 // @Tags misc-quotes
 // @Produce json
-// @Param amount path int true "Amount"
-// @Param page path int true "Page"
-// @Success 200 {array} misc.QuotePage
-// @Router /v1/internal/misc/quotes/{amount}/{page} [get]
+// @Param cursor query string false "Opaque page cursor"
+// @Param size query int false "Page size"
+// @Success 200 {object} utils.CursorPage[misc.Quote]
+// @Router /v1/internal/misc/quotes [get]
 func (s *Store) ListQuotes(c echo.Context) error {
-	amount, err := strconv.Atoi(c.Param("amount"))
+	cursor, size, err := utils.CursorParams(c)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Bad offset")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	page, err := strconv.Atoi(c.Param("page"))
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Bad page")
-	}
-
-	q, err := s.misc.ListQuotes(c.Request().Context(), amount, page)
+	q, err := s.misc.ListQuotes(c.Request().Context(), cursor, size)
 	if err != nil {
 		err = fmt.Errorf("ListQuotes failed: %w", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, err)
 	}
 
-	return c.JSON(http.StatusOK, utils.NonNil(q))
+	return c.JSON(http.StatusOK, q.WithLinks(c.Request().URL))
 }
 
 // NewQuote handles creating a quote
@@ -0,0 +1,168 @@
+package misc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/ystv/web-api/services/misc"
+)
+
+// ListRedirects handles listing redirects
+// @Summary List redirects
+// @Description Lists all redirects in order of source.
+// @Description Setting unusedDays lists only the redirects that haven't been used in that
+// @Description many days, least recently used first, so they can be pruned.
+// @ID get-redirects
+// @Tags misc-redirects
+// @Produce json
+// @Param unusedDays query int false "Only redirects unused for this many days"
+// @Success 200 {array} misc.Redirect
+// @Router /v1/internal/misc/redirects [get]
+func (s *Store) ListRedirects(c echo.Context) error {
+	var unusedSince *time.Time
+
+	if raw := c.QueryParam("unusedDays"); raw != "" {
+		days, err := strconv.Atoi(raw)
+		if err != nil || days < 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid unusedDays")
+		}
+
+		since := time.Now().AddDate(0, 0, -days)
+		unusedSince = &since
+	}
+
+	r, err := s.misc.ListRedirects(c.Request().Context(), unusedSince)
+	if err != nil {
+		err = fmt.Errorf("ListRedirects failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, r)
+}
+
+// GetRedirect handles getting a redirect
+// @Summary Get redirect
+// @Description Gets a redirect by ID, including its usage.
+// @ID get-redirect
+// @Tags misc-redirects
+// @Produce json
+// @Param redirectid path int true "Redirect ID"
+// @Success 200 {object} misc.Redirect
+// @Router /v1/internal/misc/redirects/{redirectid} [get]
+func (s *Store) GetRedirect(c echo.Context) error {
+	redirectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+
+	r, err := s.misc.GetRedirect(c.Request().Context(), redirectID)
+	if err != nil {
+		return redirectError("GetRedirect", err)
+	}
+
+	return c.JSON(http.StatusOK, r)
+}
+
+// NewRedirect handles creating a redirect
+// @Summary New redirect
+// @Description Creates a redirect. Match type is exact, prefix or wildcard, defaulting to exact.
+// @Description web-api will overwrite created by User ID with the token's user ID.
+// @ID new-redirect
+// @Tags misc-redirects
+// @Accept json
+// @Produce json
+// @Param redirect body misc.Redirect true "Redirect object"
+// @Success 201 {object} int "Redirect ID"
+// @Router /v1/internal/misc/redirects [post]
+func (s *Store) NewRedirect(c echo.Context) error {
+	var r misc.Redirect
+
+	err := c.Bind(&r)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	claims, status, err := s.access.GetToken(c.Request())
+	if err != nil {
+		err = fmt.Errorf("NewRedirect failed to get user ID: %w", err)
+		return echo.NewHTTPError(status, err)
+	}
+
+	r.CreatedBy = null.IntFrom(int64(claims.UserID))
+
+	redirectID, err := s.misc.NewRedirect(c.Request().Context(), r)
+	if err != nil {
+		return redirectError("NewRedirect", err)
+	}
+
+	return c.JSON(http.StatusCreated, redirectID)
+}
+
+// UpdateRedirect handles updating a redirect
+// @Summary Update redirect
+// @Description Updates the source, destination and match type of a redirect,
+// @Description its usage is kept.
+// @ID update-redirect
+// @Tags misc-redirects
+// @Accept json
+// @Param redirect body misc.Redirect true "Redirect object"
+// @Success 200
+// @Router /v1/internal/misc/redirects [put]
+func (s *Store) UpdateRedirect(c echo.Context) error {
+	var r misc.Redirect
+
+	err := c.Bind(&r)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	err = s.misc.UpdateRedirect(c.Request().Context(), r)
+	if err != nil {
+		return redirectError("UpdateRedirect", err)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// DeleteRedirect handles deleting a redirect
+// @Summary Delete redirect
+// @Description Deletes a redirect by ID.
+// @ID delete-redirect
+// @Tags misc-redirects
+// @Param redirectid path int true "Redirect ID"
+// @Success 200
+// @Router /v1/internal/misc/redirects/{redirectid} [delete]
+func (s *Store) DeleteRedirect(c echo.Context) error {
+	redirectID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+
+	err = s.misc.DeleteRedirect(c.Request().Context(), redirectID)
+	if err != nil {
+		err = fmt.Errorf("DeleteRedirect failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func redirectError(name string, err error) error {
+	switch {
+	case errors.Is(err, misc.ErrRedirectNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, misc.ErrRedirectInvalid):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, misc.ErrRedirectConflict):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	}
+
+	err = fmt.Errorf("%s failed: %w", name, err)
+	return echo.NewHTTPError(http.StatusInternalServerError, err)
+}
@@ -0,0 +1,231 @@
+package misc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/ystv/web-api/services/misc"
+	"github.com/ystv/web-api/utils"
+	"github.com/ystv/web-api/utils/permissions/users"
+)
+
+// defaultAnalyticsDays is how far back short link analytics go by default
+const defaultAnalyticsDays = 90
+
+// ListShortLinks handles listing short links
+// @Summary List short links
+// @Description Lists the user's short links, newest first, with their total clicks.
+// @Description Permalink admins can set all to list everyone's links.
+// @ID get-short-links
+// @Tags misc-short-links
+// @Produce json
+// @Param all query bool false "List everyone's links"
+// @Success 200 {array} misc.ShortLink
+// @Router /v1/internal/misc/shortlinks [get]
+func (s *Store) ListShortLinks(c echo.Context) error {
+	claims, status, err := s.access.GetToken(c.Request())
+	if err != nil {
+		err = fmt.Errorf("ListShortLinks failed to get token: %w", err)
+		return echo.NewHTTPError(status, err)
+	}
+
+	createdBy := &claims.UserID
+	if c.QueryParam("all") == "true" && isPermalinkAdmin(claims) {
+		createdBy = nil
+	}
+
+	l, err := s.misc.ListShortLinks(c.Request().Context(), createdBy)
+	if err != nil {
+		err = fmt.Errorf("ListShortLinks failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, l)
+}
+
+// NewShortLink handles creating a short link
+// @Summary New short link
+// @Description Creates a short link to a video, series, playlist, channel or URL.
+// @Description A code is generated unless a vanity code is given.
+// @Description web-api will overwrite created by User ID with the token's user ID.
+// @ID new-short-link
+// @Tags misc-short-links
+// @Accept json
+// @Produce json
+// @Param shortlink body misc.ShortLink true "Short link object"
+// @Success 201 {object} misc.ShortLink
+// @Router /v1/internal/misc/shortlinks [post]
+func (s *Store) NewShortLink(c echo.Context) error {
+	var l misc.ShortLink
+
+	err := c.Bind(&l)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	claims, status, err := s.access.GetToken(c.Request())
+	if err != nil {
+		err = fmt.Errorf("NewShortLink failed to get user ID: %w", err)
+		return echo.NewHTTPError(status, err)
+	}
+
+	l.CreatedBy = null.IntFrom(int64(claims.UserID))
+
+	l, err = s.misc.NewShortLink(c.Request().Context(), l)
+	if err != nil {
+		return shortLinkError("NewShortLink", err)
+	}
+
+	return c.JSON(http.StatusCreated, l)
+}
+
+// GetShortLink handles getting a short link
+// @Summary Get short link
+// @Description Gets a short link by ID, only its creator or a permalink admin can.
+// @ID get-short-link
+// @Tags misc-short-links
+// @Produce json
+// @Param linkid path int true "Link ID"
+// @Success 200 {object} misc.ShortLink
+// @Router /v1/internal/misc/shortlinks/{linkid} [get]
+func (s *Store) GetShortLink(c echo.Context) error {
+	l, err := s.ownedShortLink(c)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, l)
+}
+
+// GetShortLinkAnalytics handles the click analytics of a short link
+// @Summary Short link analytics
+// @Description Clicks on a short link by day and by referring site, only its creator
+// @Description or a permalink admin can see them. Defaults to the last 90 days.
+// @ID get-short-link-analytics
+// @Tags misc-short-links
+// @Produce json
+// @Param linkid path int true "Link ID"
+// @Param days query int false "Number of days"
+// @Success 200 {object} misc.ShortLinkAnalytics
+// @Router /v1/internal/misc/shortlinks/{linkid}/analytics [get]
+func (s *Store) GetShortLinkAnalytics(c echo.Context) error {
+	l, err := s.ownedShortLink(c)
+	if err != nil {
+		return err
+	}
+
+	days := defaultAnalyticsDays
+
+	if raw := c.QueryParam("days"); raw != "" {
+		days, err = strconv.Atoi(raw)
+		if err != nil || days <= 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid days")
+		}
+	}
+
+	now := time.Now()
+	since := time.Date(now.Year(), now.Month(), now.Day()-days+1, 0, 0, 0, 0, now.Location())
+
+	a, err := s.misc.GetShortLinkAnalytics(c.Request().Context(), l.LinkID, since)
+	if err != nil {
+		return shortLinkError("GetShortLinkAnalytics", err)
+	}
+
+	return c.JSON(http.StatusOK, a)
+}
+
+// DeleteShortLink handles deleting a short link
+// @Summary Delete short link
+// @Description Deletes a short link and its analytics, only its creator or a permalink admin can.
+// @ID delete-short-link
+// @Tags misc-short-links
+// @Param linkid path int true "Link ID"
+// @Success 200
+// @Router /v1/internal/misc/shortlinks/{linkid} [delete]
+func (s *Store) DeleteShortLink(c echo.Context) error {
+	l, err := s.ownedShortLink(c)
+	if err != nil {
+		return err
+	}
+
+	err = s.misc.DeleteShortLink(c.Request().Context(), l.LinkID)
+	if err != nil {
+		return shortLinkError("DeleteShortLink", err)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// ResolveShortLink handles following a short link
+// @Summary Follow short link
+// @Description Redirects to the target of a short link, recording the click.
+// @ID get-public-short-link
+// @Tags misc-short-links
+// @Param code path string true "Short link code"
+// @Success 302
+// @Failure 404
+// @Failure 410
+// @Router /v1/public/s/{code} [get]
+func (s *Store) ResolveShortLink(c echo.Context) error {
+	target, err := s.misc.ResolveShortLink(c.Request().Context(), c.Param("code"), c.Request().Referer())
+	if err != nil {
+		return shortLinkError("ResolveShortLink", err)
+	}
+
+	// Temporary, since the link can expire or be deleted
+	return c.Redirect(http.StatusFound, target)
+}
+
+// ownedShortLink gets the short link in the path, as long as the user
+// created it or is a permalink admin
+func (s *Store) ownedShortLink(c echo.Context) (misc.ShortLink, error) {
+	linkID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return misc.ShortLink{}, echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
+	}
+
+	claims, status, err := s.access.GetToken(c.Request())
+	if err != nil {
+		err = fmt.Errorf("failed to get token: %w", err)
+		return misc.ShortLink{}, echo.NewHTTPError(status, err)
+	}
+
+	l, err := s.misc.GetShortLink(c.Request().Context(), linkID)
+	if err != nil {
+		return misc.ShortLink{}, shortLinkError("GetShortLink", err)
+	}
+
+	if l.CreatedBy.Int64 != int64(claims.UserID) && !isPermalinkAdmin(claims) {
+		return misc.ShortLink{}, echo.NewHTTPError(http.StatusForbidden, "You don't own this short link")
+	}
+
+	return l, nil
+}
+
+func isPermalinkAdmin(claims *utils.AccessClaims) bool {
+	return slices.Contains(claims.Permissions, users.SuperUser) ||
+		slices.Contains(claims.Permissions, users.CMSPermalinkAdmin)
+}
+
+func shortLinkError(name string, err error) error {
+	switch {
+	case errors.Is(err, misc.ErrShortLinkNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, misc.ErrShortLinkExpired):
+		return echo.NewHTTPError(http.StatusGone, err.Error())
+	case errors.Is(err, misc.ErrShortLinkInvalid):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, misc.ErrShortLinkConflict):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	}
+
+	err = fmt.Errorf("%s failed: %w", name, err)
+	return echo.NewHTTPError(http.StatusInternalServerError, err)
+}
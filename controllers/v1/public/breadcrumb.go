@@ -17,12 +17,14 @@ import (
 // Find handles converting an url path to either a video or series
 //
 // @Summary Converts a VOD url to either a series or video
-// @Description Allows us to remain backwards compatible with the existing URLs
+// @Description Allows us to remain backwards compatible with the existing URLs.
+// @Description Paths matching a redirect return 301 with the target in Location and the body.
 // @ID get-public-breadcrumb-find
 // @Tags public-breadcrumb
 // @Param url path string true "URL Path"
 // @Produce json
 // @Success 200 {object} public.BreadcrumbItem
+// @Success 301 {object} public.BreadcrumbItem
 // @Router /v1/public/find/{url} [get]
 func (s *Store) Find(c echo.Context) error {
 	raw := c.Request().URL
@@ -49,6 +51,11 @@ func (s *Store) Find(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
+	if b.Redirect != nil {
+		c.Response().Header().Set(echo.HeaderLocation, b.Redirect.Target)
+		return c.JSON(http.StatusMovedPermanently, b)
+	}
+
 	return c.JSON(http.StatusOK, b)
 }
 
@@ -0,0 +1,153 @@
+package public
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/ystv/web-api/services/public"
+)
+
+// GetOEmbed handles the oEmbed provider endpoint
+//
+// @Summary oEmbed provider
+// @Description Returns the oEmbed of a public video or series page, so links unfurl
+// @Description with a player. Only the JSON format is supported.
+// @ID get-public-oembed
+// @Tags public-embed
+// @Param url query string true "Public site URL"
+// @Param maxwidth query int false "Maximum player width"
+// @Param maxheight query int false "Maximum player height"
+// @Param format query string false "Response format, must be json"
+// @Produce json
+// @Success 200 {object} public.OEmbed
+// @Router /v1/public/oembed [get]
+func (s *Store) GetOEmbed(c echo.Context) error {
+	if format := c.QueryParam("format"); format != "" && format != "json" {
+		return echo.NewHTTPError(http.StatusNotImplemented, "Only the json format is supported")
+	}
+
+	rawURL := c.QueryParam("url")
+	if rawURL == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "url is required")
+	}
+
+	maxWidth, err := optionalIntParam(c, "maxwidth")
+	if err != nil {
+		return err
+	}
+
+	maxHeight, err := optionalIntParam(c, "maxheight")
+	if err != nil {
+		return err
+	}
+
+	o, err := s.public.GetOEmbed(c.Request().Context(), rawURL, maxWidth, maxHeight)
+	if err != nil {
+		return embedError("GetOEmbed", err)
+	}
+
+	return c.JSON(http.StatusOK, o)
+}
+
+// GetVideoPageMeta handles the OpenGraph and Twitter card tags of a video
+//
+// @Summary Video page metadata
+// @Description Returns the OpenGraph and Twitter card tags of a public video.
+// @ID get-public-meta-video
+// @Tags public-embed
+// @Param videoid path int true "Video ID"
+// @Produce json
+// @Success 200 {object} public.PageMeta
+// @Router /v1/public/meta/video/{videoid} [get]
+func (s *Store) GetVideoPageMeta(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Bad video ID")
+	}
+
+	m, err := s.public.GetVideoPageMeta(c.Request().Context(), id)
+	if err != nil {
+		return embedError("GetVideoPageMeta", err)
+	}
+
+	return c.JSON(http.StatusOK, m)
+}
+
+// GetSeriesPageMeta handles the OpenGraph and Twitter card tags of a series
+//
+// @Summary Series page metadata
+// @Description Returns the OpenGraph and Twitter card tags of a public series.
+// @ID get-public-meta-series
+// @Tags public-embed
+// @Param seriesid path int true "Series ID"
+// @Produce json
+// @Success 200 {object} public.PageMeta
+// @Router /v1/public/meta/series/{seriesid} [get]
+func (s *Store) GetSeriesPageMeta(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Bad series ID")
+	}
+
+	m, err := s.public.GetSeriesPageMeta(c.Request().Context(), id)
+	if err != nil {
+		return embedError("GetSeriesPageMeta", err)
+	}
+
+	return c.JSON(http.StatusOK, m)
+}
+
+// GetPlaylistPageMeta handles the OpenGraph and Twitter card tags of a playlist
+//
+// @Summary Playlist page metadata
+// @Description Returns the OpenGraph and Twitter card tags of a public playlist.
+// @ID get-public-meta-playlist
+// @Tags public-embed
+// @Param playlistid path int true "Playlist ID"
+// @Produce json
+// @Success 200 {object} public.PageMeta
+// @Router /v1/public/meta/playlist/{playlistid} [get]
+func (s *Store) GetPlaylistPageMeta(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("playlistid"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Bad playlist ID")
+	}
+
+	m, err := s.public.GetPlaylistPageMeta(c.Request().Context(), id)
+	if err != nil {
+		return embedError("GetPlaylistPageMeta", err)
+	}
+
+	return c.JSON(http.StatusOK, m)
+}
+
+// optionalIntParam reads a positive integer query parameter, 0 when unset
+func optionalIntParam(c echo.Context, name string) (int, error) {
+	raw := c.QueryParam(name)
+	if raw == "" {
+		return 0, nil
+	}
+
+	i, err := strconv.Atoi(raw)
+	if err != nil || i < 0 {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "Bad "+name)
+	}
+
+	return i, nil
+}
+
+func embedError(name string, err error) error {
+	if errors.Is(err, public.ErrVideoNotFound) ||
+		errors.Is(err, public.ErrSeriesNotFound) ||
+		errors.Is(err, public.ErrPlaylistNotFound) ||
+		errors.Is(err, public.ErrUnsupportedURL) {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	err = fmt.Errorf("public %s failed: %w", name, err)
+	return echo.NewHTTPError(http.StatusInternalServerError, err)
+}
@@ -0,0 +1,153 @@
+package public
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/ystv/web-api/services/public"
+)
+
+const (
+	mimeRSS  = "application/rss+xml; charset=UTF-8"
+	mimeAtom = "application/atom+xml; charset=UTF-8"
+)
+
+// GetSeriesRSS handles the RSS feed of a series
+//
+// @Summary Series RSS feed
+// @Description RSS feed of the most recent public videos in a series and its child series.
+// @Description Setting format turns it into a podcast with enclosures of that encode format.
+// @ID get-public-series-feed-rss
+// @Tags public-series
+// @Param seriesid path int true "Series ID"
+// @Param format query int false "Encode format ID for podcast enclosures"
+// @Produce xml
+// @Success 200 {string} string
+// @Router /v1/public/series/{seriesid}/feed.rss [get]
+func (s *Store) GetSeriesRSS(c echo.Context) error {
+	f, err := s.seriesFeed(c)
+	if err != nil {
+		return err
+	}
+
+	b, err := f.RSS(feedSelfURL(c))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.Blob(http.StatusOK, mimeRSS, b)
+}
+
+// GetSeriesAtom handles the Atom feed of a series
+//
+// @Summary Series Atom feed
+// @Description Atom feed of the most recent public videos in a series and its child series.
+// @ID get-public-series-feed-atom
+// @Tags public-series
+// @Param seriesid path int true "Series ID"
+// @Produce xml
+// @Success 200 {string} string
+// @Router /v1/public/series/{seriesid}/feed.atom [get]
+func (s *Store) GetSeriesAtom(c echo.Context) error {
+	f, err := s.seriesFeed(c)
+	if err != nil {
+		return err
+	}
+
+	b, err := f.Atom(feedSelfURL(c))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.Blob(http.StatusOK, mimeAtom, b)
+}
+
+// GetPlaylistRSS handles the RSS feed of a playlist
+//
+// @Summary Playlist RSS feed
+// @Description RSS feed of the public videos in a playlist, in playlist order.
+// @Description Setting format turns it into a podcast with enclosures of that encode format.
+// @ID get-public-playlist-feed-rss
+// @Tags public-playlist
+// @Param playlistid path int true "Playlist ID"
+// @Param format query int false "Encode format ID for podcast enclosures"
+// @Produce xml
+// @Success 200 {string} string
+// @Router /v1/public/playlist/{playlistid}/feed.rss [get]
+func (s *Store) GetPlaylistRSS(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("playlistid"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Bad playlist ID")
+	}
+
+	formatID, err := feedFormatParam(c)
+	if err != nil {
+		return err
+	}
+
+	f, err := s.public.GetPlaylistFeed(c.Request().Context(), id, formatID)
+	if err != nil {
+		return feedError("GetPlaylistRSS", err)
+	}
+
+	b, err := f.RSS(feedSelfURL(c))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.Blob(http.StatusOK, mimeRSS, b)
+}
+
+func (s *Store) seriesFeed(c echo.Context) (public.Feed, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return public.Feed{}, echo.NewHTTPError(http.StatusBadRequest, "Bad series ID")
+	}
+
+	formatID, err := feedFormatParam(c)
+	if err != nil {
+		return public.Feed{}, err
+	}
+
+	f, err := s.public.GetSeriesFeed(c.Request().Context(), id, formatID)
+	if err != nil {
+		return public.Feed{}, feedError("GetSeriesFeed", err)
+	}
+
+	return f, nil
+}
+
+// feedFormatParam reads the optional encode format of a podcast feed
+func feedFormatParam(c echo.Context) (*int, error) {
+	raw := c.QueryParam("format")
+	if raw == "" {
+		return nil, nil
+	}
+
+	formatID, err := strconv.Atoi(raw)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Bad format ID")
+	}
+
+	return &formatID, nil
+}
+
+func feedError(name string, err error) error {
+	if errors.Is(err, public.ErrSeriesNotFound) ||
+		errors.Is(err, public.ErrPlaylistNotFound) ||
+		errors.Is(err, public.ErrFormatNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, err)
+	}
+
+	err = fmt.Errorf("public %s failed: %w", name, err)
+	return echo.NewHTTPError(http.StatusInternalServerError, err)
+}
+
+// feedSelfURL is the absolute URL of the feed being requested
+func feedSelfURL(c echo.Context) string {
+	return c.Scheme() + "://" + c.Request().Host + c.Request().URL.RequestURI()
+}
@@ -6,6 +6,8 @@ import (
 	"strconv"
 
 	"github.com/labstack/echo/v4"
+
+	"github.com/ystv/web-api/utils"
 )
 
 // GetPlaylist handles returning a playlist with a list of videos and metadata
@@ -33,6 +35,39 @@ func (s *Store) GetPlaylist(c echo.Context) error {
 	return c.JSON(http.StatusOK, p)
 }
 
+// ListPlaylistVideos returns a page of the videos of a playlist
+//
+// @Summary Provides a list of a playlist's videos
+// @Description List of public video meta's in the playlist, in playlist order.
+// @Description Follow the next and prev links to page through the list.
+// @ID get-public-playlist-videos
+// @Tags public-playlist
+// @Param playlistid path int true "Playlist ID"
+// @Param cursor query string false "Opaque page cursor"
+// @Param size query int false "Page size"
+// @Produce json
+// @Success 200 {object} utils.CursorPage[public.VideoMeta]
+// @Router /v1/public/playlist/{playlistid}/videos [get]
+func (s *Store) ListPlaylistVideos(c echo.Context) error {
+	playlistID, err := strconv.Atoi(c.Param("playlistid"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Bad playlist ID")
+	}
+
+	cursor, size, err := utils.CursorParams(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	v, err := s.public.ListPlaylistVideos(c.Request().Context(), playlistID, cursor, size)
+	if err != nil {
+		err = fmt.Errorf("public ListPlaylistVideos failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, v.WithLinks(c.Request().URL))
+}
+
 // GetPlaylistPopularByAllTime returns a fake playlist with a list of popular videos of all time
 //
 // @Summary Provides a playlist of popular videos of all time
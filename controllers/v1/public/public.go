@@ -17,6 +17,9 @@ type (
 		TeamRepo
 		VideoRepo
 		CustomSettingRepo
+		FeedRepo
+		EmbedRepo
+		SitemapRepo
 	}
 
 	BreadcrumbRepo interface {
@@ -27,6 +30,7 @@ type (
 
 	PlaylistRepo interface {
 		GetPlaylist(c echo.Context) error
+		ListPlaylistVideos(c echo.Context) error
 		GetPlaylistPopularByAllTime(c echo.Context) error
 		GetPlaylistPopularByPastYear(c echo.Context) error
 		GetPlaylistPopularByPastMonth(c echo.Context) error
@@ -35,6 +39,7 @@ type (
 
 	SeriesRepo interface {
 		GetSeriesByID(c echo.Context) error
+		ListSeriesVideos(c echo.Context) error
 		GetSeriesByYear(c echo.Context) error
 		Search(c echo.Context) error
 	}
@@ -42,6 +47,9 @@ type (
 	StreamRepo interface {
 		ListChannels(c echo.Context) error
 		GetChannel(c echo.Context) error
+		GetNowNext(c echo.Context) error
+		GetGuide(c echo.Context) error
+		GetChannelGuide(c echo.Context) error
 	}
 
 	TeamRepo interface {
@@ -58,6 +66,26 @@ type (
 	VideoRepo interface {
 		GetVideo(c echo.Context) error
 		ListVideos(c echo.Context) error
+		ListRelatedVideos(c echo.Context) error
+	}
+
+	FeedRepo interface {
+		GetSeriesRSS(c echo.Context) error
+		GetSeriesAtom(c echo.Context) error
+		GetPlaylistRSS(c echo.Context) error
+	}
+
+	EmbedRepo interface {
+		GetOEmbed(c echo.Context) error
+		GetVideoPageMeta(c echo.Context) error
+		GetSeriesPageMeta(c echo.Context) error
+		GetPlaylistPageMeta(c echo.Context) error
+	}
+
+	SitemapRepo interface {
+		GetSitemapIndex(c echo.Context) error
+		GetSeriesSitemap(c echo.Context) error
+		GetVideoSitemap(c echo.Context) error
 	}
 
 	CustomSettingRepo interface {
@@ -70,6 +98,6 @@ type (
 )
 
 // NewRepos creates our data store
-func NewRepos(db *sqlx.DB, cdnEndpoint string) Repos {
-	return &Store{public.NewStore(db, cdnEndpoint)}
+func NewRepos(db *sqlx.DB, cdnEndpoint, siteURL string) Repos {
+	return &Store{public.NewStore(db, cdnEndpoint, siteURL)}
 }
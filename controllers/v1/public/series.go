@@ -1,11 +1,15 @@
 package public
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 
 	"github.com/labstack/echo/v4"
+
+	"github.com/ystv/web-api/services/search"
+	"github.com/ystv/web-api/utils"
 )
 
 // GetSeriesByID returns a series with its immediate children with a SeriesID
@@ -31,6 +35,39 @@ func (s *Store) GetSeriesByID(c echo.Context) error {
 	return c.JSON(http.StatusOK, series)
 }
 
+// ListSeriesVideos returns a page of the videos of a series
+//
+// @Summary Provides a list of a series' videos
+// @Description List of video meta's belonging to the series, in series order.
+// @Description Follow the next and prev links to page through the list.
+// @ID get-public-series-videos
+// @Tags public-series
+// @Param id path int true "Series ID"
+// @Param cursor query string false "Opaque page cursor"
+// @Param size query int false "Page size"
+// @Produce json
+// @Success 200 {object} utils.CursorPage[public.VideoMeta]
+// @Router /v1/public/series/{id}/videos [get]
+func (s *Store) ListSeriesVideos(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Bad series ID")
+	}
+
+	cursor, size, err := utils.CursorParams(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	v, err := s.public.ListVideosOfSeries(c.Request().Context(), id, cursor, size)
+	if err != nil {
+		err = fmt.Errorf("public ListSeriesVideos failed : %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, v.WithLinks(c.Request().URL))
+}
+
 // GetSeriesByYear returns a virtual series containing series / videos made in that year
 //
 // @Summary Series of a year
@@ -57,33 +94,33 @@ func (s *Store) GetSeriesByYear(c echo.Context) error {
 	return c.JSON(http.StatusOK, series)
 }
 
-type SearchInput struct {
-	Query string `json:"query"`
-}
-
-// Search returns a virtual series that contains relevant videos and series
+// Search returns a page of ranked videos relevant to the query
 //
 // @Summary Search the VOD library
-// @Description Returns a virtual series that contains relevant videos and series
+// @Description Returns a page of public videos ranked by relevance to the query, with highlighted snippets.
+// @Description Results can be filtered to a series (and its child series), a broadcast year and a duration range.
 // @ID search-vod
 // @Tags public-series
-// @Param searchInput body SearchInput true "Search Input object"
+// @Param searchInput body search.Params true "Search parameters object"
 // @Produce json
-// @Success 200 {array} public.Series
+// @Success 200 {object} search.Results
 // @Router /v1/public/search [post]
 func (s *Store) Search(c echo.Context) error {
-	var searchInput SearchInput
+	var params search.Params
 
-	err := c.Bind(&searchInput)
+	err := c.Bind(&params)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err)
 	}
 
-	series, err := s.public.Search(c.Request().Context(), searchInput.Query)
+	res, err := s.public.Search(c.Request().Context(), params)
 	if err != nil {
+		if errors.Is(err, search.ErrEmptyQuery) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
 		err = fmt.Errorf("public Search failed : %w", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, err)
 	}
 
-	return c.JSON(http.StatusOK, series)
+	return c.JSON(http.StatusOK, res)
 }
@@ -0,0 +1,84 @@
+package public
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/ystv/web-api/services/public"
+)
+
+const mimeSitemap = "application/xml; charset=UTF-8"
+
+// GetSitemapIndex handles the sitemap index
+//
+// @Summary Sitemap index
+// @Description Sitemap index of the public video library, linking to the series
+// @Description sitemap and each chunk of the video sitemap.
+// @ID get-public-sitemap
+// @Tags public-sitemap
+// @Produce xml
+// @Success 200 {string} string
+// @Router /v1/public/sitemap.xml [get]
+func (s *Store) GetSitemapIndex(c echo.Context) error {
+	base := c.Scheme() + "://" + c.Request().Host + "/v1/public/sitemap"
+
+	b, err := s.public.GetSitemapIndex(c.Request().Context(), base)
+	if err != nil {
+		err = fmt.Errorf("public GetSitemapIndex failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.Blob(http.StatusOK, mimeSitemap, b)
+}
+
+// GetSeriesSitemap handles the sitemap of series
+//
+// @Summary Series sitemap
+// @Description Sitemap of every public series.
+// @ID get-public-sitemap-series
+// @Tags public-sitemap
+// @Produce xml
+// @Success 200 {string} string
+// @Router /v1/public/sitemap/series.xml [get]
+func (s *Store) GetSeriesSitemap(c echo.Context) error {
+	b, err := s.public.GetSeriesSitemap(c.Request().Context())
+	if err != nil {
+		err = fmt.Errorf("public GetSeriesSitemap failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.Blob(http.StatusOK, mimeSitemap, b)
+}
+
+// GetVideoSitemap handles a chunk of the video sitemap
+//
+// @Summary Video sitemap
+// @Description A chunk of the video sitemap, with video extensions.
+// @ID get-public-sitemap-videos
+// @Tags public-sitemap
+// @Param chunk path string true "Chunk, i.e. 0.xml"
+// @Produce xml
+// @Success 200 {string} string
+// @Router /v1/public/sitemap/videos/{chunk} [get]
+func (s *Store) GetVideoSitemap(c echo.Context) error {
+	chunk, err := strconv.Atoi(strings.TrimSuffix(c.Param("chunk"), ".xml"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Bad sitemap chunk")
+	}
+
+	b, err := s.public.GetVideoSitemap(c.Request().Context(), chunk)
+	if err != nil {
+		if errors.Is(err, public.ErrSitemapNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		err = fmt.Errorf("public GetVideoSitemap failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.Blob(http.StatusOK, mimeSitemap, b)
+}
@@ -1,14 +1,18 @@
 package public
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 
+	"github.com/ystv/web-api/services/public"
 	"github.com/ystv/web-api/utils"
 )
 
+const mimeXMLTV = "application/xml; charset=UTF-8"
+
 // ListChannels handles listing all channels
 //
 // @Summary Provides the visible channels
@@ -46,3 +50,69 @@ func (s *Store) GetChannel(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, chs)
 }
+
+// GetNowNext handles what is on a channel now and next
+//
+// @Summary Provides what is on a channel now and next
+// @Description What is on a public or unlisted channel's schedule now and what is on next,
+// @Description either is null when nothing is scheduled.
+// @ID get-public-stream-channel-now-next
+// @Tags public-playout-channels
+// @Param channelShortName path string true "Channel short name"
+// @Produce json
+// @Success 200 {object} public.NowNext
+// @Router /v1/public/playout/channel/{channelShortName}/now [get]
+func (s *Store) GetNowNext(c echo.Context) error {
+	nn, err := s.public.GetNowNext(c.Request().Context(), c.Param("channelShortName"))
+	if err != nil {
+		if errors.Is(err, public.ErrChannelNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		err = fmt.Errorf("public getnownext failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.JSON(http.StatusOK, nn)
+}
+
+// GetGuide handles the XMLTV guide of every public channel
+//
+// @Summary XMLTV guide
+// @Description XMLTV guide of every public channel, from the day before through the next week.
+// @ID get-public-stream-guide
+// @Tags public-playout-channels
+// @Produce xml
+// @Success 200 {string} string
+// @Router /v1/public/playout/guide.xml [get]
+func (s *Store) GetGuide(c echo.Context) error {
+	b, err := s.public.GetGuide(c.Request().Context())
+	if err != nil {
+		err = fmt.Errorf("public getguide failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.Blob(http.StatusOK, mimeXMLTV, b)
+}
+
+// GetChannelGuide handles the XMLTV guide of a channel
+//
+// @Summary Channel XMLTV guide
+// @Description XMLTV guide of a public or unlisted channel, from the day before through the next week.
+// @ID get-public-stream-channel-guide
+// @Tags public-playout-channels
+// @Param channelShortName path string true "Channel short name"
+// @Produce xml
+// @Success 200 {string} string
+// @Router /v1/public/playout/channel/{channelShortName}/guide.xml [get]
+func (s *Store) GetChannelGuide(c echo.Context) error {
+	b, err := s.public.GetChannelGuide(c.Request().Context(), c.Param("channelShortName"))
+	if err != nil {
+		if errors.Is(err, public.ErrChannelNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		err = fmt.Errorf("public getchannelguide failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
+	}
+
+	return c.Blob(http.StatusOK, mimeXMLTV, b)
+}
@@ -1,12 +1,14 @@
 package public
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 
 	"github.com/labstack/echo/v4"
 
+	"github.com/ystv/web-api/services/public"
 	"github.com/ystv/web-api/utils"
 )
 
@@ -35,33 +37,59 @@ func (s *Store) GetVideo(c echo.Context) error {
 	return c.JSON(http.StatusOK, v)
 }
 
-// ListVideos handles listing videos using an offset and page
+// ListVideos handles listing videos using a cursor
 //
 // @Summary Provides a list of videos
-// @Description List of video meta's in order of broadcast date.
+// @Description List of video meta's in order of broadcast date, newest first.
+// @Description Follow the next and prev links to page through the list.
 // @ID get-public-videos
 // @Tags public-video
-// @Param offset path int true "Offset"
-// @Param page path int true "Page"
+// @Param cursor query string false "Opaque page cursor"
+// @Param size query int false "Page size"
 // @Produce json
-// @Success 200 {array} public.VideoMeta
-// @Router /v1/public/videos/{offset}/{page} [get]
+// @Success 200 {object} utils.CursorPage[public.VideoMeta]
+// @Router /v1/public/videos [get]
 func (s *Store) ListVideos(c echo.Context) error {
-	offset, err := strconv.Atoi(c.Param("offset"))
+	cursor, size, err := utils.CursorParams(c)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Bad offset")
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	page, err := strconv.Atoi(c.Param("page"))
+	v, err := s.public.ListVideos(c.Request().Context(), cursor, size)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Bad page")
+		err = fmt.Errorf("public ListVideos failed: %w", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, err)
 	}
 
-	v, err := s.public.ListVideos(c.Request().Context(), offset, page)
+	return c.JSON(http.StatusOK, v.WithLinks(c.Request().URL))
+}
+
+// ListRelatedVideos handles recommending videos to watch after another
+//
+// @Summary Provides related videos
+// @Description Lists public videos related to a video, scored on being in the same series,
+// @Description sharing tags, having a similar name and being watched together.
+// @Description The weighting is set by the "related_videos" custom setting.
+// @ID get-public-video-related
+// @Tags public-video
+// @Param videoid path int true "Video ID"
+// @Produce json
+// @Success 200 {array} public.RelatedVideo
+// @Router /v1/public/video/{videoid}/related [get]
+func (s *Store) ListRelatedVideos(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		err = fmt.Errorf("public ListVideos failed: %w", err)
+		return echo.NewHTTPError(http.StatusBadRequest, "Bad video ID")
+	}
+
+	v, err := s.public.ListRelatedVideos(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, public.ErrVideoNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, err)
+		}
+		err = fmt.Errorf("public ListRelatedVideos failed: %w", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, err)
 	}
 
-	return c.JSON(http.StatusOK, utils.NonNil(v))
+	return c.JSON(http.StatusOK, v)
 }
@@ -0,0 +1,109 @@
+package stream
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/ystv/web-api/services/stream"
+	"github.com/ystv/web-api/utils"
+)
+
+var guestEmail = template.Must(template.New("guestEmail").Parse(`<p>Hi{{if .Guest}} {{.Guest}}{{end}},</p>
+<p>Here are the details to stream to YSTV.</p>
+<pre>{{.Instructions}}</pre>
+<p>Thanks,<br>YSTV</p>
+`))
+
+// NewGuestStream creates a temporary stream endpoint for an external collaborator
+//
+// @Summary New guest stream endpoint
+// @Description Creates an endpoint with a generated key that is removed once it stops being valid,
+// @Description returning the key with instructions to stream to it. The key is only returned here.
+// @Description When an email is given the instructions are also sent there.
+// @ID new-stream-guest
+// @Tags stream-endpoints
+// @Accept json
+// @Param guest body stream.GuestEndpointNew true "Guest endpoint object"
+// @Produce json
+// @Success 201 {object} stream.GuestKey
+// @Error 400
+// @Router /v1/internal/streams/guest [post]
+func (s *Store) NewGuestStream(c echo.Context) error {
+	var guest stream.GuestEndpointNew
+
+	err := c.Bind(&guest)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest,
+			fmt.Errorf("NewGuestStream: failed to bind to request json: %w", err))
+	}
+
+	key, err := s.stream.AddGuestEndpoint(c.Request().Context(), guest, s.conf.Ingest)
+	if err != nil {
+		if errors.Is(err, stream.ErrInvalidGuest) {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("NewGuestStream: %w", err))
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("NewGuestStream: failed to add guest endpoint: %w", err))
+	}
+
+	// The key has been made, so a failed email is left to be passed on by hand
+	if guest.Email != nil && *guest.Email != "" {
+		err = s.mailGuestKey(key, *guest.Email)
+		if err != nil {
+			c.Logger().Warnf("NewGuestStream: failed to email instructions for %d: %+v", key.EndpointID, err)
+		} else {
+			key.Emailed = true
+		}
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+
+	return c.JSON(http.StatusCreated, key)
+}
+
+// RevokeStream stops a stream endpoint working straight away
+//
+// @Summary Revoke stream endpoint
+// @Description Blocks and expires an endpoint, taking anything it feeds off air.
+// @Description Endpoints set to auto remove, like guest endpoints, are deleted.
+// @ID revoke-stream
+// @Tags stream-endpoints
+// @Param endpointid path int true "Endpoint ID"
+// @Success 204
+// @Error 404
+// @Router /v1/internal/streams/{endpointid}/revoke [post]
+func (s *Store) RevokeStream(c echo.Context) error {
+	endpointID, err := strconv.Atoi(c.Param("endpointid"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "RevokeStream: invalid endpoint ID")
+	}
+
+	err = s.stream.RevokeEndpoint(c.Request().Context(), endpointID)
+	if err != nil {
+		if errors.Is(err, stream.ErrEndpointNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "RevokeStream: endpoint not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("RevokeStream: failed to revoke endpoint: %w", err))
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// mailGuestKey sends a guest their instructions, connecting for each email as
+// guest keys are only occasionally made
+func (s *Store) mailGuestKey(key stream.GuestKey, to string) error {
+	if s.conf.Mail.Host == "" {
+		return errors.New("mail isn't configured")
+	}
+
+	mailer, err := utils.NewMailer(s.conf.Mail)
+	if err != nil {
+		return fmt.Errorf("failed to connect to mail server: %w", err)
+	}
+
+	return mailer.SendMail(utils.NewMail("Your YSTV stream key", to, s.conf.MailFrom, guestEmail, key))
+}
@@ -0,0 +1,157 @@
+package stream
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/ystv/web-api/services/stream"
+)
+
+// PlayStream handles a stream play request
+//
+// @Summary Play a stream
+// @Description Checks a viewer can play a stream; this is for SRS on_play and Nginx RTMP on_play.
+// @Description Streams feeding only internal channels need a playback token for one of those channels,
+// @Description or a service token for the stream, passed as the token query parameter. Anything else,
+// @Description including streams that feed a public or unlisted channel or no channel at all, can be played by anyone.
+// @ID play-stream
+// @Tags stream-endpoints
+// @Accept json
+// @Success 200 body int "Playback allowed"
+// @Error 401
+// @Router /v1/internal/stream/play [post]
+func (s *Store) PlayStream(c echo.Context) error {
+	var application, name, token string
+
+	if c.Request().Header.Get("Content-Type") == "application/json" {
+		// SRS play handler
+		var play _srsPublish
+
+		defer func(Body io.ReadCloser) {
+			_ = Body.Close()
+		}(c.Request().Body)
+
+		err := json.NewDecoder(c.Request().Body).Decode(&play)
+		if err == nil && play.Action != "on_play" {
+			err = fmt.Errorf("invalid action %s", play.Action)
+		}
+		if err != nil {
+			c.Logger().Warnf("PlayStream: failed to parse play data: %+v", err)
+			return c.String(http.StatusUnauthorized, "401 Unauthorized")
+		}
+
+		val, err := url.ParseQuery(strings.TrimPrefix(play.Param, "?"))
+		if err != nil {
+			c.Logger().Warnf("PlayStream: failed to parse play params: %+v", err)
+			return c.String(http.StatusUnauthorized, "401 Unauthorized")
+		}
+
+		application, name, token = play.Application, play.Stream, val.Get("token")
+	} else {
+		// Form DATA from nginx-rtmp, the play URL's query is passed along
+		if c.FormValue("call") != "play" {
+			return nil
+		}
+
+		application, name, token = c.FormValue("app"), c.FormValue("name"), c.FormValue("token")
+	}
+
+	err := s.stream.AuthorisePlay(c.Request().Context(), s.conf.PlaybackKey, application, name, token)
+	if err != nil {
+		if errors.Is(err, stream.ErrPlaybackDenied) {
+			c.Logger().Infof("PlayStream: %+v", err)
+		} else {
+			c.Logger().Errorf("PlayStream: failed to authorise play: %+v", err)
+		}
+		return c.String(http.StatusUnauthorized, "401 Unauthorized")
+	}
+
+	// SRS needs zero response
+	return c.String(http.StatusOK, "0")
+}
+
+// NewPlaybackToken issues a token to play a channel's stream
+//
+// @Summary New playback token
+// @Description Issues a short-lived token to play the stream feeding a channel, for channels that
+// @Description aren't public. It's bound to the channel and only checked when playback starts.
+// @ID new-stream-playback-token
+// @Tags stream-endpoints
+// @Param channelShortName path string true "Channel short name"
+// @Produce json
+// @Success 201 {object} stream.PlaybackToken
+// @Error 404
+// @Router /v1/internal/stream/playback/{channelShortName} [post]
+func (s *Store) NewPlaybackToken(c echo.Context) error {
+	claims, status, err := s.access.GetToken(c.Request())
+	if err != nil {
+		return echo.NewHTTPError(status, err)
+	}
+
+	token, err := s.stream.IssuePlaybackToken(c.Request().Context(), s.conf.PlaybackKey, c.Param("channelShortName"), claims.UserID)
+	if err != nil {
+		if errors.Is(err, stream.ErrChannelNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "NewPlaybackToken: channel not found or not fed by a stream endpoint")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("NewPlaybackToken: failed to issue token: %w", err))
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+
+	return c.JSON(http.StatusCreated, token)
+}
+
+// NewServicePlaybackToken issues a token to play a stream endpoint's stream
+//
+// @Summary New service playback token
+// @Description Issues a long-lived token to play an endpoint's stream whatever channels it feeds, for
+// @Description relays, recorders and restreams that pull it. It's bound to the stream and only checked
+// @Description when playback starts.
+// @ID new-stream-service-playback-token
+// @Tags stream-endpoints
+// @Param endpointid path int true "Endpoint ID"
+// @Param validity query string false "How long the token works for, defaults to 720h"
+// @Produce json
+// @Success 201 {object} stream.PlaybackToken
+// @Error 400
+// @Error 404
+// @Router /v1/internal/streams/{endpointid}/playback-token [post]
+func (s *Store) NewServicePlaybackToken(c echo.Context) error {
+	endpointID, err := strconv.Atoi(c.Param("endpointid"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "NewServicePlaybackToken: invalid endpoint ID")
+	}
+
+	var validity time.Duration
+
+	if q := c.QueryParam("validity"); q != "" {
+		validity, err = time.ParseDuration(q)
+		if err != nil || validity <= 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "NewServicePlaybackToken: invalid validity")
+		}
+	}
+
+	token, err := s.stream.IssueServicePlaybackToken(c.Request().Context(), s.conf.PlaybackKey, endpointID, validity)
+	if err != nil {
+		switch {
+		case errors.Is(err, stream.ErrInvalidValidity):
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("NewServicePlaybackToken: %w", err))
+		case errors.Is(err, stream.ErrEndpointNotFound):
+			return echo.NewHTTPError(http.StatusNotFound, "NewServicePlaybackToken: endpoint not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("NewServicePlaybackToken: failed to issue token: %w", err))
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+
+	return c.JSON(http.StatusCreated, token)
+}
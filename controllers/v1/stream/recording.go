@@ -0,0 +1,121 @@
+package stream
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/ystv/web-api/services/creator/types/video"
+)
+
+// _srsDVR is what SRS sends to on_dvr, file is relative to cwd unless absolute
+type _srsDVR struct {
+	Action      string `json:"action"`
+	Application string `json:"app"`
+	Stream      string `json:"stream"`
+	CWD         string `json:"cwd"`
+	File        string `json:"file"`
+}
+
+// RecordingDone handles a stream recording finishing
+//
+// @Summary Register a stream recording
+// @Description Makes a finished recording into a private video in the recordings series, with the
+// @Description recording as its source file, and applies the recordings preset so it's encoded.
+// @Description This is for SRS on_dvr and Nginx RTMP record_done. The recording's path has to be
+// @Description below where the recordings bucket is mounted. Nothing happens when recordings aren't set up.
+// @Description The media servers have to pass the recording secret in the hook URL.
+// @ID recording-done
+// @Tags stream-endpoints
+// @Accept json
+// @Param secret query string true "Recording hook secret"
+// @Success 200 body int "Recording registered"
+// @Error 400
+// @Error 401
+// @Error 500
+// @Router /v1/internal/stream/recording [post]
+func (s *Store) RecordingDone(c echo.Context) error {
+	var application, name, path string
+
+	if c.Request().Header.Get("Content-Type") == "application/json" {
+		// SRS DVR handler
+		var dvr _srsDVR
+
+		defer func(Body io.ReadCloser) {
+			_ = Body.Close()
+		}(c.Request().Body)
+
+		err := json.NewDecoder(c.Request().Body).Decode(&dvr)
+		if err == nil && dvr.Action != "on_dvr" {
+			err = fmt.Errorf("invalid action %s", dvr.Action)
+		}
+		if err != nil {
+			c.Logger().Warnf("RecordingDone: failed to parse recording data: %+v", err)
+			return c.String(http.StatusBadRequest, "400 Bad Request")
+		}
+
+		application, name, path = dvr.Application, dvr.Stream, dvr.File
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dvr.CWD, path)
+		}
+	} else {
+		// Form DATA from nginx-rtmp
+		if c.FormValue("call") != "record_done" {
+			return nil
+		}
+
+		application, name, path = c.FormValue("app"), c.FormValue("name"), c.FormValue("path")
+	}
+
+	if !s.conf.Recording.Enabled() {
+		c.Logger().Infof("RecordingDone: recordings aren't set up, ignoring %s", path)
+		return c.String(http.StatusOK, "0")
+	}
+
+	if !s.conf.Recording.Authorised(c.QueryParam("secret")) {
+		c.Logger().Warnf("RecordingDone: wrong secret for %s/%s recording %s from %s", application, name, path, c.RealIP())
+		return c.String(http.StatusUnauthorized, "401 Unauthorized")
+	}
+
+	uri, err := s.conf.Recording.URI(path)
+	if err != nil {
+		c.Logger().Warnf("RecordingDone: %+v", err)
+		return c.String(http.StatusBadRequest, "400 Bad Request")
+	}
+
+	source, err := s.stream.GetRecordingSource(c.Request().Context(), application, name)
+	if err != nil {
+		c.Logger().Errorf("RecordingDone: failed to get recording source: %+v", err)
+		return c.String(http.StatusInternalServerError, "500 Internal Server Error")
+	}
+
+	videoID, err := s.video.NewItemFromRecording(c.Request().Context(), video.Recording{
+		SeriesID:      s.conf.Recording.SeriesID,
+		PresetID:      s.conf.Recording.PresetID,
+		Name:          source.Title,
+		URLName:       source.URLName,
+		Description:   fmt.Sprintf("Recording of %s/%s", application, name),
+		BroadcastDate: source.StartedAt,
+		URI:           uri,
+	})
+	if err != nil {
+		if errors.Is(err, video.ErrRecordingNotFound) {
+			c.Logger().Warnf("RecordingDone: %+v", err)
+			return c.String(http.StatusBadRequest, "400 Bad Request")
+		}
+		c.Logger().Errorf("RecordingDone: failed to create video from recording: %+v", err)
+		if videoID == 0 {
+			return c.String(http.StatusInternalServerError, "500 Internal Server Error")
+		}
+	}
+
+	c.Logger().Infof("RecordingDone: %s/%s recording %s is video %d", application, name, uri, videoID)
+
+	// SRS needs zero response
+	return c.String(http.StatusOK, "0")
+}
@@ -0,0 +1,492 @@
+package stream
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/ystv/web-api/services/stream"
+	"github.com/ystv/web-api/utils"
+)
+
+// ListRestreamIncoming lists the restream incoming streams
+//
+// @Summary List restream incoming streams
+// @ID get-restream-incomings
+// @Tags stream-restream
+// @Produce json
+// @Success 200 {array} stream.Incoming
+// @Router /v1/internal/restream/incoming [get]
+func (s *Store) ListRestreamIncoming(c echo.Context) error {
+	list, err := s.stream.ListIncoming(c.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("ListRestreamIncoming: failed to get incoming streams: %w", err))
+	}
+
+	return c.JSON(http.StatusOK, utils.NonNil(list))
+}
+
+// NewRestreamIncoming adds a restream incoming stream
+//
+// @Summary New restream incoming stream
+// @ID new-restream-incoming
+// @Tags stream-restream
+// @Accept json
+// @Param incoming body stream.Incoming true "Incoming object"
+// @Produce json
+// @Success 201 {object} stream.Incoming
+// @Error 400
+// @Router /v1/internal/restream/incoming [post]
+func (s *Store) NewRestreamIncoming(c echo.Context) error {
+	var item stream.Incoming
+
+	err := c.Bind(&item)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("NewRestreamIncoming: failed to bind to request json: %w", err))
+	}
+
+	item, err = s.stream.AddIncoming(c.Request().Context(), item)
+	if err != nil {
+		return restreamHTTPError("NewRestreamIncoming", err)
+	}
+
+	return c.JSON(http.StatusCreated, item)
+}
+
+// EditRestreamIncoming edits a restream incoming stream
+//
+// @Summary Edit restream incoming stream
+// @ID edit-restream-incoming
+// @Tags stream-restream
+// @Accept json
+// @Param incomingid path int true "Incoming ID"
+// @Param incoming body stream.Incoming true "Incoming object"
+// @Produce json
+// @Success 200 {object} stream.Incoming
+// @Error 400
+// @Error 404
+// @Router /v1/internal/restream/incoming/{incomingid} [put]
+func (s *Store) EditRestreamIncoming(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("incomingid"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "EditRestreamIncoming: invalid incoming stream ID")
+	}
+
+	var item stream.Incoming
+
+	err = c.Bind(&item)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("EditRestreamIncoming: failed to bind to request json: %w", err))
+	}
+
+	item.IncomingID = id
+
+	item, err = s.stream.EditIncoming(c.Request().Context(), item)
+	if err != nil {
+		return restreamHTTPError("EditRestreamIncoming", err)
+	}
+
+	return c.JSON(http.StatusOK, item)
+}
+
+// DeleteRestreamIncoming deletes a restream incoming stream, it can't be used by a mapping
+//
+// @Summary Delete restream incoming stream
+// @ID delete-restream-incoming
+// @Tags stream-restream
+// @Param incomingid path int true "Incoming ID"
+// @Success 204
+// @Error 404
+// @Error 409
+// @Router /v1/internal/restream/incoming/{incomingid} [delete]
+func (s *Store) DeleteRestreamIncoming(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("incomingid"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "DeleteRestreamIncoming: invalid incoming stream ID")
+	}
+
+	err = s.stream.DeleteIncoming(c.Request().Context(), id)
+	if err != nil {
+		return restreamHTTPError("DeleteRestreamIncoming", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListRestreamProviders lists the restream providers
+//
+// @Summary List restream providers
+// @ID get-restream-providers
+// @Tags stream-restream
+// @Produce json
+// @Success 200 {array} stream.Provider
+// @Router /v1/internal/restream/providers [get]
+func (s *Store) ListRestreamProviders(c echo.Context) error {
+	list, err := s.stream.ListProviders(c.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("ListRestreamProviders: failed to get providers: %w", err))
+	}
+
+	return c.JSON(http.StatusOK, utils.NonNil(list))
+}
+
+// NewRestreamProvider adds a restream provider
+//
+// @Summary New restream provider
+// @Description Keys are masked, when editing leave the key empty or masked to keep it.
+// @ID new-restream-provider
+// @Tags stream-restream
+// @Accept json
+// @Param provider body stream.Provider true "Provider object"
+// @Produce json
+// @Success 201 {object} stream.Provider
+// @Error 400
+// @Router /v1/internal/restream/providers [post]
+func (s *Store) NewRestreamProvider(c echo.Context) error {
+	var item stream.Provider
+
+	err := c.Bind(&item)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("NewRestreamProvider: failed to bind to request json: %w", err))
+	}
+
+	item, err = s.stream.AddProvider(c.Request().Context(), item)
+	if err != nil {
+		return restreamHTTPError("NewRestreamProvider", err)
+	}
+
+	return c.JSON(http.StatusCreated, item)
+}
+
+// EditRestreamProvider edits a restream provider
+//
+// @Summary Edit restream provider
+// @Description Keys are masked, when editing leave the key empty or masked to keep it.
+// @ID edit-restream-provider
+// @Tags stream-restream
+// @Accept json
+// @Param providerid path int true "Provider ID"
+// @Param provider body stream.Provider true "Provider object"
+// @Produce json
+// @Success 200 {object} stream.Provider
+// @Error 400
+// @Error 404
+// @Router /v1/internal/restream/providers/{providerid} [put]
+func (s *Store) EditRestreamProvider(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("providerid"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "EditRestreamProvider: invalid provider ID")
+	}
+
+	var item stream.Provider
+
+	err = c.Bind(&item)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("EditRestreamProvider: failed to bind to request json: %w", err))
+	}
+
+	item.ProviderID = id
+
+	item, err = s.stream.EditProvider(c.Request().Context(), item)
+	if err != nil {
+		return restreamHTTPError("EditRestreamProvider", err)
+	}
+
+	return c.JSON(http.StatusOK, item)
+}
+
+// DeleteRestreamProvider deletes a restream provider, it can't be used by a mapping
+//
+// @Summary Delete restream provider
+// @ID delete-restream-provider
+// @Tags stream-restream
+// @Param providerid path int true "Provider ID"
+// @Success 204
+// @Error 404
+// @Error 409
+// @Router /v1/internal/restream/providers/{providerid} [delete]
+func (s *Store) DeleteRestreamProvider(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("providerid"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "DeleteRestreamProvider: invalid provider ID")
+	}
+
+	err = s.stream.DeleteProvider(c.Request().Context(), id)
+	if err != nil {
+		return restreamHTTPError("DeleteRestreamProvider", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListRestreamQualities lists the restream qualities
+//
+// @Summary List restream qualities
+// @ID get-restream-qualitys
+// @Tags stream-restream
+// @Produce json
+// @Success 200 {array} stream.Quality
+// @Router /v1/internal/restream/qualities [get]
+func (s *Store) ListRestreamQualities(c echo.Context) error {
+	list, err := s.stream.ListQualities(c.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("ListRestreamQualities: failed to get qualities: %w", err))
+	}
+
+	return c.JSON(http.StatusOK, utils.NonNil(list))
+}
+
+// NewRestreamQuality adds a restream quality
+//
+// @Summary New restream quality
+// @Description The command is ffmpeg output options, checked the same as encode formats.
+// @ID new-restream-quality
+// @Tags stream-restream
+// @Accept json
+// @Param quality body stream.Quality true "Quality object"
+// @Produce json
+// @Success 201 {object} stream.Quality
+// @Error 400
+// @Router /v1/internal/restream/qualities [post]
+func (s *Store) NewRestreamQuality(c echo.Context) error {
+	var item stream.Quality
+
+	err := c.Bind(&item)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("NewRestreamQuality: failed to bind to request json: %w", err))
+	}
+
+	item, err = s.stream.AddQuality(c.Request().Context(), item)
+	if err != nil {
+		return restreamHTTPError("NewRestreamQuality", err)
+	}
+
+	return c.JSON(http.StatusCreated, item)
+}
+
+// EditRestreamQuality edits a restream quality
+//
+// @Summary Edit restream quality
+// @Description The command is ffmpeg output options, checked the same as encode formats.
+// @ID edit-restream-quality
+// @Tags stream-restream
+// @Accept json
+// @Param qualityid path int true "Quality ID"
+// @Param quality body stream.Quality true "Quality object"
+// @Produce json
+// @Success 200 {object} stream.Quality
+// @Error 400
+// @Error 404
+// @Router /v1/internal/restream/qualities/{qualityid} [put]
+func (s *Store) EditRestreamQuality(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("qualityid"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "EditRestreamQuality: invalid quality ID")
+	}
+
+	var item stream.Quality
+
+	err = c.Bind(&item)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("EditRestreamQuality: failed to bind to request json: %w", err))
+	}
+
+	item.QualityID = id
+
+	item, err = s.stream.EditQuality(c.Request().Context(), item)
+	if err != nil {
+		return restreamHTTPError("EditRestreamQuality", err)
+	}
+
+	return c.JSON(http.StatusOK, item)
+}
+
+// DeleteRestreamQuality deletes a restream quality, it can't be used by a mapping
+//
+// @Summary Delete restream quality
+// @ID delete-restream-quality
+// @Tags stream-restream
+// @Param qualityid path int true "Quality ID"
+// @Success 204
+// @Error 404
+// @Error 409
+// @Router /v1/internal/restream/qualities/{qualityid} [delete]
+func (s *Store) DeleteRestreamQuality(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("qualityid"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "DeleteRestreamQuality: invalid quality ID")
+	}
+
+	err = s.stream.DeleteQuality(c.Request().Context(), id)
+	if err != nil {
+		return restreamHTTPError("DeleteRestreamQuality", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListRestreamMappings lists which incoming streams go to which provider at which quality
+//
+// @Summary List restream mappings
+// @Description Lists the transcode mappings, as the "All transcodes" view with their IDs.
+// @ID get-restream-mappings
+// @Tags stream-restream
+// @Param incomingId query int false "Only list the mappings of an incoming stream"
+// @Produce json
+// @Success 200 {array} stream.TranscodeMapping
+// @Router /v1/internal/restream/mappings [get]
+func (s *Store) ListRestreamMappings(c echo.Context) error {
+	var incomingID *int
+
+	if q := c.QueryParam("incomingId"); q != "" {
+		id, err := strconv.Atoi(q)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "ListRestreamMappings: invalid incoming stream ID")
+		}
+		incomingID = &id
+	}
+
+	mappings, err := s.stream.ListTranscodeMappings(c.Request().Context(), incomingID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("ListRestreamMappings: failed to get mappings: %w", err))
+	}
+
+	return c.JSON(http.StatusOK, utils.NonNil(mappings))
+}
+
+// NewRestreamMapping maps an incoming stream at a quality to a provider
+//
+// @Summary New restream mapping
+// @ID new-restream-mapping
+// @Tags stream-restream
+// @Accept json
+// @Param mapping body stream.TranscodeMappingAddEdit true "Mapping object"
+// @Produce json
+// @Success 201 {object} stream.TranscodeMapping
+// @Error 400
+// @Router /v1/internal/restream/mappings [post]
+func (s *Store) NewRestreamMapping(c echo.Context) error {
+	var m stream.TranscodeMappingAddEdit
+
+	err := c.Bind(&m)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("NewRestreamMapping: failed to bind to request json: %w", err))
+	}
+
+	mapping, err := s.stream.AddTranscodeMapping(c.Request().Context(), m)
+	if err != nil {
+		return restreamHTTPError("NewRestreamMapping", err)
+	}
+
+	return c.JSON(http.StatusCreated, mapping)
+}
+
+// EditRestreamMapping changes what a mapping connects
+//
+// @Summary Edit restream mapping
+// @ID edit-restream-mapping
+// @Tags stream-restream
+// @Accept json
+// @Param mappingid path int true "Mapping ID"
+// @Param mapping body stream.TranscodeMappingAddEdit true "Mapping object"
+// @Produce json
+// @Success 200 {object} stream.TranscodeMapping
+// @Error 400
+// @Error 404
+// @Router /v1/internal/restream/mappings/{mappingid} [put]
+func (s *Store) EditRestreamMapping(c echo.Context) error {
+	mappingID, err := strconv.Atoi(c.Param("mappingid"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "EditRestreamMapping: invalid mapping ID")
+	}
+
+	var m stream.TranscodeMappingAddEdit
+
+	err = c.Bind(&m)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("EditRestreamMapping: failed to bind to request json: %w", err))
+	}
+
+	mapping, err := s.stream.EditTranscodeMapping(c.Request().Context(), mappingID, m)
+	if err != nil {
+		return restreamHTTPError("EditRestreamMapping", err)
+	}
+
+	return c.JSON(http.StatusOK, mapping)
+}
+
+// DeleteRestreamMapping deletes a mapping
+//
+// @Summary Delete restream mapping
+// @ID delete-restream-mapping
+// @Tags stream-restream
+// @Param mappingid path int true "Mapping ID"
+// @Success 204
+// @Error 404
+// @Router /v1/internal/restream/mappings/{mappingid} [delete]
+func (s *Store) DeleteRestreamMapping(c echo.Context) error {
+	mappingID, err := strconv.Atoi(c.Param("mappingid"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "DeleteRestreamMapping: invalid mapping ID")
+	}
+
+	err = s.stream.DeleteTranscodeMapping(c.Request().Context(), mappingID)
+	if err != nil {
+		return restreamHTTPError("DeleteRestreamMapping", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetRestreamConfig renders the config to restream an incoming stream
+//
+// @Summary Get restream config
+// @Description Renders what a media server needs to push an incoming stream to every provider it's mapped to.
+// @Description ffmpeg is a shell script running one ffmpeg with an output per mapping, srs is a transcode
+// @Description section for the SRS config. It includes the providers' keys, and the ffmpeg source carries
+// @Description a service playback token when the incoming stream is an endpoint, so fetch it again before that expires.
+// @ID get-restream-config
+// @Tags stream-restream
+// @Param incomingid path int true "Incoming stream ID"
+// @Param format query string false "ffmpeg or srs, defaults to ffmpeg"
+// @Produce plain
+// @Success 200 {string} string
+// @Error 404
+// @Router /v1/internal/restream/incoming/{incomingid}/config [get]
+func (s *Store) GetRestreamConfig(c echo.Context) error {
+	incomingID, err := strconv.Atoi(c.Param("incomingid"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "GetRestreamConfig: invalid incoming stream ID")
+	}
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = stream.RestreamFormatFFmpeg
+	}
+
+	config, err := s.stream.RenderRestreamConfig(c.Request().Context(), incomingID, format, s.conf.Ingest, s.conf.PlaybackKey)
+	if err != nil {
+		return restreamHTTPError("GetRestreamConfig", err)
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+
+	return c.String(http.StatusOK, config)
+}
+
+// restreamHTTPError maps the restream errors to their status
+func restreamHTTPError(handler string, err error) error {
+	switch {
+	case errors.Is(err, stream.ErrRestreamNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("%s: %s", handler, err))
+	case errors.Is(err, stream.ErrInvalidRestream):
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("%s: %s", handler, err))
+	case errors.Is(err, stream.ErrRestreamInUse):
+		return echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("%s: %s", handler, err))
+	}
+
+	return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("%s: %w", handler, err))
+}
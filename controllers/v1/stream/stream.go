@@ -7,16 +7,29 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"time"
 
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/jmoiron/sqlx"
 	"github.com/labstack/echo/v4"
 
+	"github.com/ystv/web-api/services/creator"
+	"github.com/ystv/web-api/services/creator/video"
+	"github.com/ystv/web-api/services/encoder"
 	"github.com/ystv/web-api/services/stream"
 	"github.com/ystv/web-api/utils"
 )
 
+const (
+	// defaultExpiringWithin is how soon an endpoint has to stop being valid to be listed as expiring soon
+	defaultExpiringWithin = 24 * time.Hour
+
+	// defaultMetricsSince is how far back metrics are listed when no time is given
+	defaultMetricsSince = time.Hour
+)
+
 // Repos encapsulates the dependency
 type (
 	Repos interface {
@@ -27,23 +40,65 @@ type (
 		NewStream(c echo.Context) error
 		EditStream(c echo.Context) error
 		DeleteStream(c echo.Context) error
+		RotateStreamKey(c echo.Context) error
+		NewGuestStream(c echo.Context) error
+		RevokeStream(c echo.Context) error
+		PlayStream(c echo.Context) error
+		RecordingDone(c echo.Context) error
+		NewPlaybackToken(c echo.Context) error
+		NewServicePlaybackToken(c echo.Context) error
+		ListRestreamIncoming(c echo.Context) error
+		NewRestreamIncoming(c echo.Context) error
+		EditRestreamIncoming(c echo.Context) error
+		DeleteRestreamIncoming(c echo.Context) error
+		ListRestreamProviders(c echo.Context) error
+		NewRestreamProvider(c echo.Context) error
+		EditRestreamProvider(c echo.Context) error
+		DeleteRestreamProvider(c echo.Context) error
+		ListRestreamQualities(c echo.Context) error
+		NewRestreamQuality(c echo.Context) error
+		EditRestreamQuality(c echo.Context) error
+		DeleteRestreamQuality(c echo.Context) error
+		ListRestreamMappings(c echo.Context) error
+		NewRestreamMapping(c echo.Context) error
+		EditRestreamMapping(c echo.Context) error
+		DeleteRestreamMapping(c echo.Context) error
+		GetRestreamConfig(c echo.Context) error
+		ListStreamSessions(c echo.Context) error
+		ListRecentStreamSessions(c echo.Context) error
+		ListStreamMetrics(c echo.Context) error
 	}
 
 	Store struct {
 		stream stream.Repo
+		video  creator.VideoRepo
+		access utils.Repo
+		conf   Config
+	}
+
+	// Config is where guests are told to stream to and how they are emailed,
+	// the key playback tokens are signed with and what recordings become
+	Config struct {
+		Ingest      stream.IngestConfig
+		Mail        utils.MailConfig
+		MailFrom    string
+		PlaybackKey []byte
+		Recording   stream.RecordingConfig
 	}
 )
 
 // NewRepos creates our data store
-func NewRepos(db *sqlx.DB) Repos {
-	return &Store{stream.NewStore(db)}
+func NewRepos(db *sqlx.DB, cdn *s3.S3, enc encoder.Repo, access utils.Repo, conf Config) Repos {
+	// Recordings are already in their bucket, so the video store doesn't need any
+	return &Store{stream.NewStore(db), video.NewStore(db, cdn, enc, &creator.Config{}), access, conf}
 }
 
 // PublishStream handles a stream publish request
 //
 // @Summary Publish a stream
 // @Description Checks existing stream endpoints and changes it to active; this is for Nginx RTMP module
-// @Description containing the application, name and pwd
+// @Description containing the application, name and pwd. Endpoints outside their start and end valid
+// @Description times are refused. Scheduled channels fed by the endpoint go live.
 // @ID publish-stream
 // @Tags stream-endpoints
 // @Accept json
@@ -52,7 +107,7 @@ func NewRepos(db *sqlx.DB) Repos {
 // @Error 401
 // @Router /v1/internal/stream/publish [post]
 func (s *Store) PublishStream(c echo.Context) error {
-	var application, name, pwd, action string
+	var application, name, pwd, action, clientIP, server string
 	var err error
 
 	defer func(Body io.ReadCloser) {
@@ -61,13 +116,15 @@ func (s *Store) PublishStream(c echo.Context) error {
 
 	if c.Request().Header.Get("Content-Type") == "application/json" {
 		// SRS publish handler
-		application, name, pwd, action, err = _handleSRSPublish(c)
+		application, name, pwd, action, clientIP, err = _handleSRSPublish(c)
 		if action != "on_publish" {
 			err = errors.New("invalid action " + action)
 		}
+		server = stream.SessionServerSRS
 	} else {
 		// Form DATA from nginx-rtmp/srtrelay
-		application, name, pwd, action = _handleNginxPublish(c)
+		application, name, pwd, action, clientIP = _handleNginxPublish(c)
+		server = stream.SessionServerNginx
 
 		// only apply auth for a publish request
 		if action != "publish" {
@@ -91,6 +148,11 @@ func (s *Store) PublishStream(c echo.Context) error {
 		return c.String(http.StatusUnauthorized, "401 Unauthorized")
 	}
 
+	if !endpoint.ValidAt(time.Now()) {
+		c.Logger().Warnf("PublishStream: endpoint %d outside of its validity window", endpoint.EndpointID)
+		return c.String(http.StatusUnauthorized, "401 Unauthorized")
+	}
+
 	err = s.stream.SetEndpointActiveByID(c.Request().Context(), endpoint.EndpointID)
 	if err != nil {
 		c.Logger().Errorf("PublishStream: failed to set endpoint active: %+v", err)
@@ -99,6 +161,26 @@ func (s *Store) PublishStream(c echo.Context) error {
 
 	c.Logger().Infof("PublishStream: published %d %s/%s", endpoint.EndpointID, application, name)
 
+	_, err = s.stream.StartSession(c.Request().Context(), stream.SessionStart{
+		EndpointID:  endpoint.EndpointID,
+		Application: application,
+		Name:        name,
+		ClientIP:    clientIP,
+		Server:      server,
+	})
+	if err != nil {
+		c.Logger().Errorf("PublishStream: failed to record session, continuing: %+v", err)
+	}
+
+	events, err := s.stream.SetChannelsPublished(c.Request().Context(), endpoint.EndpointID)
+	if err != nil {
+		c.Logger().Errorf("PublishStream: failed to put channels live, continuing: %+v", err)
+	}
+
+	for _, e := range events {
+		c.Logger().Infof("PublishStream: channel %s %s -> %s", e.URLName, e.FromStatus, e.ToStatus)
+	}
+
 	// SRS needs zero response
 	return c.String(http.StatusOK, "0")
 }
@@ -107,7 +189,8 @@ func (s *Store) PublishStream(c echo.Context) error {
 //
 // @Summary Unpublish a stream
 // @Description Checks existing stream endpoints and changes it to inactive; this is for Nginx RTMP module
-// @Description containing the application, name, authentication and start and end times
+// @Description containing the application, name, authentication and start and end times.
+// @Description Live channels fed by the endpoint are finished, or scheduled again before their end.
 // @ID unpublish-stream
 // @Tags stream-endpoints
 // @Accept json
@@ -116,18 +199,18 @@ func (s *Store) PublishStream(c echo.Context) error {
 // @Error 401
 // @Router /v1/internal/stream/unpublish [post]
 func (s *Store) UnpublishStream(c echo.Context) error {
-	var application, name, pwd, action string
+	var application, name, action string
 	var err error
 
 	if c.Request().Header.Get("Content-Type") == "application/json" {
 		// SRS publish handler
-		application, name, _, action, err = _handleSRSPublish(c)
+		application, name, _, action, _, err = _handleSRSPublish(c)
 		if action != "on_unpublish" {
 			err = fmt.Errorf("invalid action %s", action)
 		}
 	} else {
 		// Form DATA from nginx-rtmp/srtrelay
-		application, name, pwd, action = _handleNginxPublish(c)
+		application, name, _, action, _ = _handleNginxPublish(c)
 		// ignore actions except unpublish
 		if action != "publish_done" {
 			return nil
@@ -139,13 +222,27 @@ func (s *Store) UnpublishStream(c echo.Context) error {
 		return c.String(http.StatusUnauthorized, "401 Unauthorized")
 	}
 
-	err = s.stream.SetEndpointInactiveByApplicationNamePwd(c.Request().Context(), application, name, pwd)
+	err = s.stream.SetEndpointInactiveByApplicationName(c.Request().Context(), application, name)
 	if err != nil {
 		c.Logger().Errorf("UnpublishStream: failed to unpublish stream, continuing, %s/%s: %+v", application, name, err)
 	}
 
 	c.Logger().Infof("UnpublishStream: unpublished %s/%s", application, name)
 
+	err = s.stream.EndSession(c.Request().Context(), application, name, stream.SessionEndUnpublish)
+	if err != nil {
+		c.Logger().Errorf("UnpublishStream: failed to end session, continuing: %+v", err)
+	}
+
+	events, err := s.stream.SetChannelsUnpublished(c.Request().Context(), application, name)
+	if err != nil {
+		c.Logger().Errorf("UnpublishStream: failed to take channels off air, continuing: %+v", err)
+	}
+
+	for _, e := range events {
+		c.Logger().Infof("UnpublishStream: channel %s %s -> %s", e.URLName, e.FromStatus, e.ToStatus)
+	}
+
 	// SRS needs zero response
 	return c.String(http.StatusOK, "0")
 }
@@ -160,7 +257,7 @@ type _srsPublish struct {
 	Param       string `json:"param"`
 }
 
-func _handleSRSPublish(c echo.Context) (application, name, pwd, action string, err error) {
+func _handleSRSPublish(c echo.Context) (application, name, pwd, action, clientIP string, err error) {
 	var publish _srsPublish
 
 	defer func(Body io.ReadCloser) {
@@ -185,15 +282,17 @@ func _handleSRSPublish(c echo.Context) (application, name, pwd, action string, e
 	name = publish.Stream
 	pwd = val.Get("pwd")
 	action = publish.Action
+	clientIP = publish.IP
 
 	return
 }
 
-func _handleNginxPublish(c echo.Context) (application, name, pwd, action string) {
+func _handleNginxPublish(c echo.Context) (application, name, pwd, action, clientIP string) {
 	application = c.FormValue("app")
 	name = c.FormValue("name")
 	pwd = c.FormValue("pwd")
 	action = c.FormValue("call")
+	clientIP = c.FormValue("addr")
 
 	return
 }
@@ -202,23 +301,53 @@ func _handleNginxPublish(c echo.Context) (application, name, pwd, action string)
 //
 // @Summary ListStreams stream endpoints
 // @Description Lists all stream endpoints; this is for Nginx RTMP module
-// @Description containing the application, name, authentication and start and end times
+// @Description containing the application, name, authentication and start and end times.
+// @Description Endpoints that stop being valid within expiringWithin are marked as expiring soon,
+// @Description setting expiring lists only those, soonest first.
 // @ID get-stream
 // @Tags stream-endpoints
 // @Accept json
+// @Param expiringWithin query string false "Duration counted as expiring soon, defaults to 24h"
+// @Param expiring query bool false "Only list endpoints expiring soon"
 // @Success 200 {array} stream.Endpoint
 // @Router /v1/internal/streams [get]
 func (s *Store) ListStreams(c echo.Context) error {
+	expiringWithin := defaultExpiringWithin
+
+	if q := c.QueryParam("expiringWithin"); q != "" {
+		d, err := time.ParseDuration(q)
+		if err != nil || d <= 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "ListStreams: invalid expiringWithin")
+		}
+		expiringWithin = d
+	}
+
+	expiringOnly, _ := strconv.ParseBool(c.QueryParam("expiring"))
+
 	e, err := s.stream.ListEndpoints(c.Request().Context())
 	if err != nil {
 		err = fmt.Errorf("ListStreams: failed to get: %w", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, err)
 	}
 
+	now := time.Now()
 	endpoints := make([]stream.Endpoint, 0)
 
 	for _, endpoint := range e {
-		endpoints = append(endpoints, s.streamEndpointDBToStreamEndpoint(endpoint))
+		ep := s.streamEndpointDBToStreamEndpoint(endpoint)
+		ep.ExpiresSoon = endpoint.ExpiresWithin(now, expiringWithin)
+
+		if expiringOnly && !ep.ExpiresSoon {
+			continue
+		}
+
+		endpoints = append(endpoints, ep)
+	}
+
+	if expiringOnly {
+		slices.SortFunc(endpoints, func(a, b stream.Endpoint) int {
+			return a.EndValid.Compare(*b.EndValid)
+		})
 	}
 
 	return c.JSON(http.StatusOK, utils.NonNil(endpoints))
@@ -315,6 +444,9 @@ func (s *Store) NewStream(c echo.Context) error {
 
 	endpointDB, err := s.stream.AddEndpoint(c.Request().Context(), newEndpoint)
 	if err != nil {
+		if errors.Is(err, stream.ErrInvalidKey) {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("NewStream: %w", err))
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("NewStream: failed to insert stream endpoint: %w", err))
 	}
 
@@ -372,6 +504,9 @@ func (s *Store) EditStream(c echo.Context) error {
 
 	endpointDB, err := s.stream.EditEndpoint(c.Request().Context(), endpointID, editEndpoint)
 	if err != nil {
+		if errors.Is(err, stream.ErrInvalidKey) {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Errorf("EditStream: %w", err))
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("EditStream: failed to edit stream endpoint: %w", err))
 	}
 
@@ -401,12 +536,134 @@ func (s *Store) DeleteStream(c echo.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
+// RotateStreamKey replaces a stream endpoint's key with a random one
+//
+// @Summary Rotate stream endpoint key
+// @Description Replaces the endpoint's key with a random one. The new key is only returned in this
+// @Description response, it's stored hashed and masked everywhere else.
+// @ID rotate-stream-key
+// @Tags stream-endpoints
+// @Param endpointid path int true "Endpoint ID"
+// @Produce json
+// @Success 200 {object} stream.RotatedKey
+// @Error 404
+// @Router /v1/internal/streams/{endpointid}/rotate-key [post]
+func (s *Store) RotateStreamKey(c echo.Context) error {
+	endpointID, err := strconv.Atoi(c.Param("endpointid"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "RotateStreamKey: invalid endpoint ID")
+	}
+
+	key, err := s.stream.RotateEndpointKey(c.Request().Context(), endpointID)
+	if err != nil {
+		if errors.Is(err, stream.ErrEndpointNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, "RotateStreamKey: endpoint not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("RotateStreamKey: failed to rotate key: %w", err))
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+
+	return c.JSON(http.StatusOK, key)
+}
+
+// ListStreamSessions lists the times a stream endpoint has published
+//
+// @Summary List stream endpoint sessions
+// @Description Lists when a stream endpoint published, from where, to which server and for how long, newest first.
+// @ID get-stream-sessions
+// @Tags stream-endpoints
+// @Param endpointid path int true "Endpoint ID"
+// @Param cursor query string false "Opaque page cursor"
+// @Param size query int false "Page size"
+// @Produce json
+// @Success 200 {object} utils.CursorPage[stream.Session]
+// @Router /v1/internal/streams/{endpointid}/sessions [get]
+func (s *Store) ListStreamSessions(c echo.Context) error {
+	endpointID, err := strconv.Atoi(c.Param("endpointid"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "ListStreamSessions: invalid endpoint ID")
+	}
+
+	cursor, size, err := utils.CursorParams(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	sessions, err := s.stream.ListSessions(c.Request().Context(), endpointID, cursor, size)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("ListStreamSessions: failed to get sessions: %w", err))
+	}
+
+	return c.JSON(http.StatusOK, sessions.WithLinks(c.Request().URL))
+}
+
+// ListRecentStreamSessions lists the recent sessions of every stream endpoint
+//
+// @Summary List recent stream sessions
+// @Description Lists the sessions of every stream endpoint, including removed ones, newest first.
+// @ID get-stream-sessions-recent
+// @Tags stream-endpoints
+// @Param cursor query string false "Opaque page cursor"
+// @Param size query int false "Page size"
+// @Produce json
+// @Success 200 {object} utils.CursorPage[stream.Session]
+// @Router /v1/internal/streams/sessions [get]
+func (s *Store) ListRecentStreamSessions(c echo.Context) error {
+	cursor, size, err := utils.CursorParams(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	sessions, err := s.stream.ListRecentSessions(c.Request().Context(), cursor, size)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("ListRecentStreamSessions: failed to get sessions: %w", err))
+	}
+
+	return c.JSON(http.StatusOK, sessions.WithLinks(c.Request().URL))
+}
+
+// ListStreamMetrics lists the bitrate, resolution and viewers of a stream endpoint over time
+//
+// @Summary List stream endpoint metrics
+// @Description Lists the samples taken of a stream endpoint while it was live, oldest first.
+// @Description Samples are taken by polling the media servers and are kept for a week.
+// @ID get-stream-metrics
+// @Tags stream-endpoints
+// @Param endpointid path int true "Endpoint ID"
+// @Param since query string false "RFC3339 time to list from, defaults to an hour ago"
+// @Produce json
+// @Success 200 {array} stream.Metric
+// @Router /v1/internal/streams/{endpointid}/metrics [get]
+func (s *Store) ListStreamMetrics(c echo.Context) error {
+	endpointID, err := strconv.Atoi(c.Param("endpointid"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "ListStreamMetrics: invalid endpoint ID")
+	}
+
+	since := time.Now().Add(-defaultMetricsSince)
+	if q := c.QueryParam("since"); q != "" {
+		since, err = time.Parse(time.RFC3339, q)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "ListStreamMetrics: since must be an RFC3339 time")
+		}
+	}
+
+	metrics, err := s.stream.ListMetrics(c.Request().Context(), endpointID, since)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Errorf("ListStreamMetrics: failed to get metrics: %w", err))
+	}
+
+	return c.JSON(http.StatusOK, utils.NonNil(metrics))
+}
+
 func (s *Store) streamEndpointDBToStreamEndpoint(streamEndpointDB stream.EndpointDB) stream.Endpoint {
 	var startValid, endValid *time.Time
 	var pwd, notes *string
 
-	if streamEndpointDB.Pwd.Valid {
-		pwd = &streamEndpointDB.Pwd.String
+	if streamEndpointDB.HasKey() {
+		masked := stream.MaskedKey
+		pwd = &masked
 	}
 	if streamEndpointDB.StartValid.Valid {
 		startValid = &streamEndpointDB.StartValid.Time
@@ -428,5 +685,7 @@ func (s *Store) streamEndpointDBToStreamEndpoint(streamEndpointDB stream.Endpoin
 		Notes:       notes,
 		Active:      streamEndpointDB.Active,
 		Blocked:     streamEndpointDB.Blocked,
+		AutoRemove:  streamEndpointDB.AutoRemove,
+		Valid:       streamEndpointDB.ValidAt(time.Now()),
 	}
 }
@@ -1,9 +1,14 @@
 package main
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 
@@ -16,10 +21,11 @@ import (
 	"github.com/ystv/web-api/controllers/v1/public"
 	"github.com/ystv/web-api/controllers/v1/stream"
 	"github.com/ystv/web-api/services/encoder"
+	streamService "github.com/ystv/web-api/services/stream"
 	"github.com/ystv/web-api/utils"
 )
 
-//go:generate swag init -o swagger/
+//go:generate swag init -o swagger/ --parseDependency --outputTypes go
 
 // Version returns web-api's current version
 var Version = "dev (0.8.0)"
@@ -118,6 +124,46 @@ func main() {
 		ServeBucket:  os.Getenv("WAPI_BUCKET_VOD_SERVE"),
 	}
 
+	// Playback tokens get their own key, so they can never pass as an access token.
+	// It's derived from the signing key when not set.
+	playbackKey := []byte(os.Getenv("WAPI_STREAM_PLAYBACK_KEY"))
+	if len(playbackKey) == 0 {
+		mac := hmac.New(sha256.New, []byte(os.Getenv("WAPI_SIGNING_KEY")))
+		mac.Write([]byte(streamService.PlaybackAudience))
+		playbackKey = mac.Sum(nil)
+	}
+
+	// Mail, connected to when something is sent
+	mailPort, _ := strconv.Atoi(os.Getenv("WAPI_MAIL_PORT"))
+	mailConfig := utils.MailConfig{
+		Host:     os.Getenv("WAPI_MAIL_HOST"),
+		Port:     mailPort,
+		Username: os.Getenv("WAPI_MAIL_USER"),
+		Password: os.Getenv("WAPI_MAIL_PASS"),
+	}
+
+	mailFrom := os.Getenv("WAPI_MAIL_FROM")
+	if mailFrom == "" {
+		mailFrom = mailConfig.Username
+	}
+
+	// Stream recordings, made into draft videos in the series when it's set
+	recordingConfig := streamService.RecordingConfig{
+		Root:   os.Getenv("WAPI_STREAM_RECORDING_ROOT"),
+		Bucket: os.Getenv("WAPI_STREAM_RECORDING_BUCKET"),
+		Secret: os.Getenv("WAPI_STREAM_RECORDING_SECRET"),
+	}
+	recordingConfig.SeriesID, _ = strconv.Atoi(os.Getenv("WAPI_STREAM_RECORDING_SERIES_ID"))
+	if presetID, err := strconv.Atoi(os.Getenv("WAPI_STREAM_RECORDING_PRESET_ID")); err == nil {
+		recordingConfig.PresetID = &presetID
+	}
+
+	// The public website, which feeds, guides, embeds and short links point to
+	siteURL := os.Getenv("WAPI_SITE_URL")
+	if siteURL == "" {
+		siteURL = "https://ystv.co.uk"
+	}
+
 	jwtCookieName := os.Getenv("WAUTH_JWT_COOKIE_NAME")
 	if jwtCookieName == "" {
 		jwtCookieName = "wauth_jwt"
@@ -129,8 +175,9 @@ func main() {
 	})
 
 	creatorConfig := &creator.Config{
-		IngestBucket: bucketConf.IngestBucket,
-		ServeBucket:  bucketConf.ServeBucket,
+		IngestBucket:    bucketConf.IngestBucket,
+		ServeBucket:     bucketConf.ServeBucket,
+		RecordingBucket: recordingConfig.Bucket,
 	}
 
 	encoderConfig := &encoder.Config{
@@ -139,6 +186,30 @@ func main() {
 	}
 	enc := encoder.NewEncoder(db, cdn, encoderConfig)
 
+	// Moving channels fed by stream endpoints on and off air at their scheduled times,
+	// and clearing up endpoints once they expire
+	streams := streamService.NewStore(db)
+
+	keys, err := streams.HashPlaintextKeys(context.Background())
+	if err != nil {
+		log.Printf("failed to hash plaintext stream keys: %+v", err)
+	}
+	if len(keys.Hashed) > 0 || len(keys.Failed) > 0 {
+		log.Printf("hashed plaintext stream keys of endpoints %v, failed %v", keys.Hashed, keys.Failed)
+	}
+	go streams.RunChannelScheduler(context.Background(), time.Minute)
+	go streams.RunEndpointJanitor(context.Background(), time.Minute)
+
+	// Keeping endpoints in step with what the media servers say is live
+	pollerConfig := streamService.PollerConfig{
+		NginxStatURLs: splitList(os.Getenv("WAPI_STREAM_NGINX_STAT_URLS")),
+		SRSAPIURLs:    splitList(os.Getenv("WAPI_STREAM_SRS_API_URLS")),
+		Interval:      30 * time.Second,
+	}
+	if len(pollerConfig.NginxStatURLs) > 0 || len(pollerConfig.SRSAPIURLs) > 0 {
+		go streams.RunPoller(context.Background(), pollerConfig)
+	}
+
 	New(&NewRouter{
 		Version:        Version,
 		Commit:         Commit,
@@ -149,9 +220,32 @@ func main() {
 		Creator:        creator.NewRepos(db, cdn, enc, access, creatorConfig, cdnConfig.Endpoint),
 		CustomSettings: customsettings.NewRepos(db, access),
 		Encoder:        encoderPackage.NewEncoderController(enc, access),
-		Misc:           misc.NewRepos(db, access),
+		Misc:           misc.NewRepos(db, access, siteURL),
 		People:         people.NewRepos(db, cdn, access, cdnConfig.Endpoint),
-		Public:         public.NewRepos(db, cdnConfig.Endpoint),
-		Stream:         stream.NewRepos(db),
+		Public:         public.NewRepos(db, cdnConfig.Endpoint, siteURL),
+		Stream: stream.NewRepos(db, cdn, enc, access, stream.Config{
+			Ingest: streamService.IngestConfig{
+				RTMPURL: os.Getenv("WAPI_STREAM_RTMP_URL"),
+				SRTURL:  os.Getenv("WAPI_STREAM_SRT_URL"),
+			},
+			Mail:        mailConfig,
+			MailFrom:    mailFrom,
+			PlaybackKey: playbackKey,
+			Recording:   recordingConfig,
+		}),
 	}).Start()
 }
+
+// splitList splits a comma separated environment variable, dropping blanks
+func splitList(s string) []string {
+	var list []string
+
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
@@ -167,6 +167,8 @@ func (r *Router) loadRoutes() {
 		{
 			stream.POST("/publish", r.stream.PublishStream)
 			stream.POST("/unpublish", r.stream.UnpublishStream)
+			stream.POST("/play", r.stream.PlayStream)
+			stream.POST("/recording", r.stream.RecordingDone)
 		}
 		// Internal user endpoints
 		if !r.router.Debug {
@@ -310,6 +312,8 @@ func (r *Router) loadRoutes() {
 					{
 						videoItem.GET("", r.creator.GetVideo)
 						videoItem.DELETE("", r.creator.DeleteVideo)
+						videoItem.GET("/file/:fileid/url", r.creator.GetVideoFileURL)
+						videoItem.GET("/stats", r.creator.VideoStats, r.access.VideoStatsAuthMiddleware)
 					}
 				}
 				series := creator.Group("/series")
@@ -320,6 +324,7 @@ func (r *Router) loadRoutes() {
 						seriesItem.GET("", r.creator.GetSeries)
 						seriesItem.PUT("", r.creator.UpdateSeries)
 						seriesItem.DELETE("", r.creator.DeleteSeries)
+						seriesItem.GET("/stats", r.creator.SeriesStats, r.access.VideoStatsAuthMiddleware)
 					}
 				}
 				playlists := creator.Group("/playlist")
@@ -341,6 +346,13 @@ func (r *Router) loadRoutes() {
 						channel.POST("", r.creator.NewChannel)
 						channel.PUT("", r.creator.UpdateChannel)
 						channel.DELETE("/:channelid", r.creator.DeleteChannel)
+						schedule := channel.Group("/:channelid/schedule")
+						{
+							schedule.GET("", r.creator.ListChannelSchedule)
+							schedule.POST("", r.creator.NewScheduleItem)
+							schedule.PUT("/:itemid", r.creator.UpdateScheduleItem)
+							schedule.DELETE("/:itemid", r.creator.DeleteScheduleItem)
+						}
 					}
 				}
 				encode := creator.Group("/encode")
@@ -358,10 +370,22 @@ func (r *Router) loadRoutes() {
 						format.PUT("", r.creator.UpdateEncodeFormat)
 						format.POST("", r.creator.NewEncodeFormat)
 						format.DELETE("/:formatid", r.creator.DeleteEncodeFormat)
+						format.GET("/:formatid/preview", r.creator.PreviewEncodeFormat)
 					}
+					encode.GET("/export", r.creator.ExportEncodePresets)
+					encode.POST("/import", r.creator.ImportEncodePresets)
+				}
+				storage := creator.Group("/storage", r.access.SuperUserAuthMiddleware)
+				{
+					storage.GET("/gc", r.creator.GetStorageGCReport)
+					storage.POST("/gc", r.creator.CollectStorageGarbage)
 				}
+				creator.GET("/preferences", r.creator.GetPreferences)
+				creator.PUT("/preferences", r.creator.UpdatePreferences)
 				creator.GET("/calendar/:year/:month", r.creator.ListVideosByMonth)
 				creator.GET("/stats", r.creator.Stats)
+				creator.GET("/stats/storage", r.creator.StorageUsage)
+				creator.GET("/stats/storage.csv", r.creator.StorageUsageCSV)
 			}
 			clapper := internal.Group("/clapper")
 			{
@@ -404,11 +428,27 @@ func (r *Router) loadRoutes() {
 			{
 				quotes := misc.Group("/quotes")
 				{
-					quotes.GET("/:amount/:page", r.misc.ListQuotes)
+					quotes.GET("", r.misc.ListQuotes)
 					quotes.POST("", r.misc.NewQuote)
 					quotes.PUT("", r.misc.UpdateQuote)
 					quotes.DELETE("/:id", r.misc.DeleteQuote)
 				}
+				redirects := misc.Group("/redirects", r.access.PermalinkAuthMiddleware)
+				{
+					redirects.GET("", r.misc.ListRedirects)
+					redirects.POST("", r.misc.NewRedirect)
+					redirects.PUT("", r.misc.UpdateRedirect)
+					redirects.GET("/:id", r.misc.GetRedirect)
+					redirects.DELETE("/:id", r.misc.DeleteRedirect)
+				}
+				shortLinks := misc.Group("/shortlinks")
+				{
+					shortLinks.GET("", r.misc.ListShortLinks)
+					shortLinks.POST("", r.misc.NewShortLink)
+					shortLinks.GET("/:id", r.misc.GetShortLink)
+					shortLinks.GET("/:id/analytics", r.misc.GetShortLinkAnalytics)
+					shortLinks.DELETE("/:id", r.misc.DeleteShortLink)
+				}
 				webcams := misc.Group("/webcam")
 				{
 					webcams.GET("/:id/*", r.misc.GetWebcam)
@@ -429,17 +469,45 @@ func (r *Router) loadRoutes() {
 					}
 				}
 			}
+			internal.POST("/stream/playback/:channelShortName", r.stream.NewPlaybackToken, r.access.PlaybackAuthMiddleware)
 			streamsAuthed := internal.Group("/streams", r.access.ManageStreamAuthMiddleware)
 			{
 				streamsAuthed.GET("", r.stream.ListStreams)
 				streamsAuthed.GET("/find", r.stream.FindStream)
 				streamsAuthed.POST("", r.stream.NewStream)
+				streamsAuthed.GET("/sessions", r.stream.ListRecentStreamSessions)
+				streamsAuthed.POST("/guest", r.stream.NewGuestStream)
 				streamAuthed := streamsAuthed.Group("/:endpointid")
 				{
 					streamAuthed.PUT("", r.stream.EditStream)
 					streamAuthed.DELETE("", r.stream.DeleteStream)
+					streamAuthed.GET("/sessions", r.stream.ListStreamSessions)
+					streamAuthed.GET("/metrics", r.stream.ListStreamMetrics)
+					streamAuthed.POST("/rotate-key", r.stream.RotateStreamKey)
+					streamAuthed.POST("/playback-token", r.stream.NewServicePlaybackToken)
+					streamAuthed.POST("/revoke", r.stream.RevokeStream)
 				}
 			}
+			restream := internal.Group("/restream", r.access.ManageStreamAuthMiddleware)
+			{
+				restream.GET("/incoming", r.stream.ListRestreamIncoming)
+				restream.POST("/incoming", r.stream.NewRestreamIncoming)
+				restream.PUT("/incoming/:incomingid", r.stream.EditRestreamIncoming)
+				restream.DELETE("/incoming/:incomingid", r.stream.DeleteRestreamIncoming)
+				restream.GET("/incoming/:incomingid/config", r.stream.GetRestreamConfig)
+				restream.GET("/providers", r.stream.ListRestreamProviders)
+				restream.POST("/providers", r.stream.NewRestreamProvider)
+				restream.PUT("/providers/:providerid", r.stream.EditRestreamProvider)
+				restream.DELETE("/providers/:providerid", r.stream.DeleteRestreamProvider)
+				restream.GET("/qualities", r.stream.ListRestreamQualities)
+				restream.POST("/qualities", r.stream.NewRestreamQuality)
+				restream.PUT("/qualities/:qualityid", r.stream.EditRestreamQuality)
+				restream.DELETE("/qualities/:qualityid", r.stream.DeleteRestreamQuality)
+				restream.GET("/mappings", r.stream.ListRestreamMappings)
+				restream.POST("/mappings", r.stream.NewRestreamMapping)
+				restream.PUT("/mappings/:mappingid", r.stream.EditRestreamMapping)
+				restream.DELETE("/mappings/:mappingid", r.stream.DeleteRestreamMapping)
+			}
 			customSettings := internal.Group("/custom-setting")
 			{
 				customSettings.GET("s", r.customSettings.ListCustomSettings)
@@ -458,18 +526,36 @@ func (r *Router) loadRoutes() {
 		{
 			public.POST("/search", r.public.Search)
 			public.GET("/find/*", r.public.Find)
+			public.GET("/oembed", r.public.GetOEmbed)
+			public.GET("/s/:code", r.misc.ResolveShortLink)
+			public.GET("/sitemap.xml", r.public.GetSitemapIndex)
+			sitemap := public.Group("/sitemap")
+			{
+				sitemap.GET("/series.xml", r.public.GetSeriesSitemap)
+				sitemap.GET("/videos/:chunk", r.public.GetVideoSitemap)
+			}
+			meta := public.Group("/meta")
+			{
+				meta.GET("/video/:id", r.public.GetVideoPageMeta)
+				meta.GET("/series/:id", r.public.GetSeriesPageMeta)
+				meta.GET("/playlist/:playlistid", r.public.GetPlaylistPageMeta)
+			}
 			video := public.Group("/video")
 			{
 				// /videos
-				video.GET("s/:offset/:page", r.public.ListVideos)
+				video.GET("s", r.public.ListVideos)
 				// /video
 				video.GET("/:id", r.public.GetVideo)
 				video.GET("/:id/breadcrumb", r.public.VideoBreadcrumb)
+				video.GET("/:id/related", r.public.ListRelatedVideos)
 			}
 			series := public.Group("/series")
 			{
 				series.GET("/:id", r.public.GetSeriesByID)
 				series.GET("/:id/breadcrumb", r.public.GetSeriesBreadcrumb)
+				series.GET("/:id/videos", r.public.ListSeriesVideos)
+				series.GET("/:id/feed.rss", r.public.GetSeriesRSS)
+				series.GET("/:id/feed.atom", r.public.GetSeriesAtom)
 				series.GET("/yearly/:year", r.public.GetSeriesByYear)
 			}
 			playlist := public.Group("/playlist")
@@ -479,6 +565,8 @@ func (r *Router) loadRoutes() {
 				playlist.GET("/popular/year", r.public.GetPlaylistPopularByPastYear)
 				playlist.GET("/popular/month", r.public.GetPlaylistPopularByPastMonth)
 				playlist.GET("/:playlistid", r.public.GetPlaylist)
+				playlist.GET("/:playlistid/videos", r.public.ListPlaylistVideos)
+				playlist.GET("/:playlistid/feed.rss", r.public.GetPlaylistRSS)
 			}
 			teams := public.Group("/teams")
 			{
@@ -501,7 +589,10 @@ func (r *Router) loadRoutes() {
 			{
 				streamChannel.GET("s", r.public.ListChannels)
 				streamChannel.GET("/:channelShortName", r.public.GetChannel)
+				streamChannel.GET("/:channelShortName/now", r.public.GetNowNext)
+				streamChannel.GET("/:channelShortName/guide.xml", r.public.GetChannelGuide)
 			}
+			public.GET("/playout/guide.xml", r.public.GetGuide)
 			customSetting := public.Group("/custom-setting")
 			{
 				customSetting.GET("/:settingid", r.public.GetCustomSettingPublic)
@@ -2,6 +2,7 @@ package creator
 
 import (
 	"context"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 
@@ -9,9 +10,13 @@ import (
 	"github.com/ystv/web-api/services/creator/types/encode"
 	"github.com/ystv/web-api/services/creator/types/playlist"
 	"github.com/ystv/web-api/services/creator/types/playout"
+	"github.com/ystv/web-api/services/creator/types/preferences"
 	"github.com/ystv/web-api/services/creator/types/series"
 	"github.com/ystv/web-api/services/creator/types/stats"
+	"github.com/ystv/web-api/services/creator/types/storage"
 	"github.com/ystv/web-api/services/creator/types/video"
+	"github.com/ystv/web-api/services/search"
+	"github.com/ystv/web-api/utils"
 )
 
 type (
@@ -23,24 +28,31 @@ type (
 	Config struct {
 		IngestBucket string
 		ServeBucket  string
-		Endpoint     string
+		// RecordingBucket is where stream recordings are written, their source
+		// files stay there and the storage GC leaves it alone
+		RecordingBucket string
+		Endpoint        string
 	}
 	// VideoRepo defines all creator video interactions
 	VideoRepo interface {
 		// GetItem gets the individual video item
 		GetItem(ctx context.Context, id int) (video.ItemDB, error)
-		ListMeta(ctx context.Context) ([]video.MetaDB, error)
+		ListMeta(ctx context.Context, cursor *utils.Cursor, size int) (utils.CursorPage[video.MetaDB], error)
 		ListMetaByUser(ctx context.Context, userID int) ([]video.MetaDB, error)
 		ListByCalendarMonth(ctx context.Context, year, month int) ([]video.MetaCal, error)
 		OfSeries(ctx context.Context, seriesID int) ([]video.MetaDB, error)
-		Search(ctx context.Context, query string) ([]video.MetaDB, error)
+		Search(ctx context.Context, params search.Params) (search.Results, error)
 		// NewItem inserts a new video
 		NewItem(ctx context.Context, v video.New) (int, error)
+		// NewItemFromRecording creates a draft video from a stream recording
+		NewItemFromRecording(ctx context.Context, r video.Recording) (int, error)
 		// UpdateMeta updates the video metadata
 		UpdateMeta(ctx context.Context, meta video.Meta) error
 		// DeleteItem removes a video
 		DeleteItem(ctx context.Context, videoID, userID int) error
 		DeleteItemPermanently(ctx context.Context, videoID int) error
+		// GetFileURL gets a URL for a video file, signed when it isn't public
+		GetFileURL(ctx context.Context, r video.FileRequest) (video.FileURL, error)
 		// DeleteFile(ctx context.Context, fileID, userID int) error
 	}
 	// SeriesRepo defines all creator series interactions
@@ -58,6 +70,10 @@ type (
 		NewChannel(ctx context.Context, ch playout.Channel) error
 		UpdateChannel(ctx context.Context, ch playout.Channel) error
 		DeleteChannel(ctx context.Context, urlName string) error
+		ListSchedule(ctx context.Context, urlName string, from, to time.Time) ([]playout.ScheduleItem, error)
+		NewScheduleItem(ctx context.Context, urlName string, item playout.ScheduleItem, userID int) (int, error)
+		UpdateScheduleItem(ctx context.Context, urlName string, item playout.ScheduleItem, userID int) error
+		DeleteScheduleItem(ctx context.Context, urlName string, scheduleItemID int) error
 	}
 	// PlaylistRepo defines all playlist interactions
 	PlaylistRepo interface {
@@ -87,10 +103,24 @@ type (
 		NewPreset(ctx context.Context, p encode.Preset) (int, error)
 		UpdatePreset(ctx context.Context, p encode.Preset) error
 		DeletePreset(ctx context.Context, presetID int) error
+		ExportPresets(ctx context.Context) (encode.PresetDocument, error)
+		ImportPresets(ctx context.Context, doc encode.PresetDocument, apply bool) (encode.ImportReport, error)
+	}
+	// StorageRepo defines all object storage interactions
+	StorageRepo interface {
+		CollectGarbage(ctx context.Context, cutoff time.Time, dryRun bool) (storage.GCReport, error)
+		StorageUsage(ctx context.Context, reconcile bool) (storage.Usage, error)
+	}
+	// PreferencesRepo defines all creator preference interactions
+	PreferencesRepo interface {
+		GetPreferences(ctx context.Context, userID int) (preferences.Preferences, error)
+		UpdatePreferences(ctx context.Context, userID int, p preferences.Preferences) error
 	}
 	// StatRepo defines all statistical interactions
 	StatRepo interface {
 		GlobalVideoStats(ctx context.Context) (stats.VideoGlobalStats, error)
+		VideoStats(ctx context.Context, videoID int, r stats.Range) (stats.VideoStats, error)
+		SeriesStats(ctx context.Context, seriesID int, r stats.Range) (stats.SeriesStats, error)
 	}
 )
 
@@ -0,0 +1,282 @@
+package encode
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ystv/web-api/services/creator/types/encode"
+)
+
+var (
+	// ErrInvalidFormat is returned when an encode format's arguments are unsafe,
+	// can't be parsed or don't match the rest of the format
+	ErrInvalidFormat = errors.New("invalid encode format")
+
+	// Options that read or write files other than the input and output
+	unsafeOptions = map[string]bool{
+		"i": true, "filter_script": true, "filter_complex_script": true, "attach": true,
+		"dump_attachment": true, "report": true, "vstats": true, "vstats_file": true,
+		"passlogfile": true, "progress": true, "sdp_file": true, "fpre": true, "vpre": true,
+		"apre": true, "spre": true, "pre": true, "stats_enc_pre": true, "stats_enc_post": true,
+		"stats_mux_pre": true, "hls_segment_filename": true, "hls_key_info_file": true,
+		"master_pl_name": true, "protocol_whitelist": true,
+	}
+
+	// Options whose value is a filter graph
+	filterOptions = map[string]bool{
+		"vf": true, "af": true, "filter": true, "filter_complex": true, "lavfi": true,
+	}
+
+	// Filters that read files or listen on sockets
+	unsafeFilter = regexp.MustCompile(`(?:^|[,;\[\]\s])(?:a?movie|subtitles|ass|a?sendcmd|a?zmq|lut1d|lut3d|frei0r|ladspa|lv2)=|textfile=`)
+
+	// Protocols that would let a value point somewhere other than the encode
+	unsafeProtocol = regexp.MustCompile(`^(?:file|pipe|fd|unix|http|https|tcp|udp|rtmp|rtmps|srt|ftp|sftp|crypto|concat|subfile|data|tee):`)
+
+	// Where the output size is set, either as -s WxH or a scale filter
+	sizeOption  = regexp.MustCompile(`^(\d+)x(\d+)$`)
+	scaleFilter = regexp.MustCompile(`scale=(?:w=)?(-?\d+)[:x](?:h=)?(-?\d+)`)
+
+	// Muxers and the mime types they write
+	muxerMimeTypes = map[string][]string{
+		"mp4":      {"video/mp4", "audio/mp4"},
+		"mov":      {"video/quicktime"},
+		"webm":     {"video/webm", "audio/webm"},
+		"matroska": {"video/x-matroska", "audio/x-matroska"},
+		"ogg":      {"video/ogg", "audio/ogg"},
+		"mp3":      {"audio/mpeg", "audio/mp3"},
+		"adts":     {"audio/aac"},
+		"flac":     {"audio/flac"},
+		"wav":      {"audio/wav", "audio/x-wav"},
+		"hls":      {"application/vnd.apple.mpegurl", "application/x-mpegurl"},
+		"mpegts":   {"video/mp2t"},
+		"image2":   {"image/jpeg", "image/png", "image/webp"},
+	}
+)
+
+// ParseArguments splits an ffmpeg output argument string into its arguments,
+// rejecting anything that would make the encoder touch other files or run
+// other commands. The input and output are added by the encoder, so the
+// arguments can only be options.
+func ParseArguments(args string) ([]string, error) {
+	tokens, err := splitArguments(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: no arguments set", ErrInvalidFormat)
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+
+		if !isOption(token) {
+			return nil, fmt.Errorf("%w: unexpected \"%s\", only options are allowed as the output is added by the encoder", ErrInvalidFormat, token)
+		}
+
+		name := optionName(token)
+
+		// ffmpeg reads the value of options starting with -/ from a file
+		if strings.HasPrefix(token, "-/") || unsafeOptions[name] {
+			return nil, fmt.Errorf("%w: option \"%s\" isn't allowed", ErrInvalidFormat, token)
+		}
+
+		// Options without a value are followed by another option, or nothing
+		if i+1 == len(tokens) || isOption(tokens[i+1]) {
+			continue
+		}
+
+		i++
+		value := tokens[i]
+
+		if unsafeProtocol.MatchString(value) {
+			return nil, fmt.Errorf("%w: \"%s\" for \"%s\" uses a protocol that isn't allowed", ErrInvalidFormat, value, token)
+		}
+
+		if filterOptions[name] && unsafeFilter.MatchString(value) {
+			return nil, fmt.Errorf("%w: filter \"%s\" reads from files", ErrInvalidFormat, value)
+		}
+
+		if name == "f" && value == "tee" {
+			return nil, fmt.Errorf("%w: the tee muxer isn't allowed", ErrInvalidFormat)
+		}
+	}
+
+	return tokens, nil
+}
+
+// ValidateFormat checks a format's arguments are safe, and that they agree
+// with its width, height and mime type
+func ValidateFormat(format encode.Format) error {
+	tokens, err := ParseArguments(format.Arguments)
+	if err != nil {
+		return err
+	}
+
+	mimeType, _, err := mime.ParseMediaType(format.MimeType)
+	if err != nil || !strings.Contains(mimeType, "/") {
+		return fmt.Errorf("%w: \"%s\" isn't a mime type", ErrInvalidFormat, format.MimeType)
+	}
+
+	audioOnly := strings.HasPrefix(mimeType, "audio/")
+	options := argumentValues(tokens)
+
+	if _, ok := options["vn"]; ok && strings.HasPrefix(mimeType, "video/") {
+		return fmt.Errorf("%w: arguments drop the video with -vn but the mime type is %s", ErrInvalidFormat, mimeType)
+	}
+
+	if muxer, ok := options["f"]; ok {
+		if accepted, known := muxerMimeTypes[muxer]; known && !slices.Contains(accepted, mimeType) {
+			return fmt.Errorf("%w: muxer \"%s\" doesn't write %s", ErrInvalidFormat, muxer, mimeType)
+		}
+	}
+
+	width, height, sized := outputSize(options)
+
+	switch {
+	case audioOnly && (sized || options["c:v"] != "" && options["c:v"] != "none"):
+		return fmt.Errorf("%w: arguments encode video but the mime type is %s", ErrInvalidFormat, mimeType)
+	case !sized:
+	case width > 0 && format.Width > 0 && width != format.Width,
+		height > 0 && format.Height > 0 && height != format.Height:
+		return fmt.Errorf("%w: arguments scale to %dx%d but the format is %dx%d",
+			ErrInvalidFormat, width, height, format.Width, format.Height)
+	}
+
+	return nil
+}
+
+// splitArguments splits arguments on whitespace, respecting quotes and escapes.
+// Shell syntax is rejected, so redirection and chaining can't be smuggled in.
+func splitArguments(args string) ([]string, error) {
+	var (
+		tokens  []string
+		current strings.Builder
+		quote   rune
+		escaped bool
+		inToken bool
+	)
+
+	for _, r := range args {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inToken = true
+		case quote != 0 && r == quote:
+			quote = 0
+		case r == '`' || r == '\n' || r == '\r' || (r == '$' && quote != '\''):
+			return nil, fmt.Errorf("%w: shell syntax \"%c\" isn't allowed", ErrInvalidFormat, r)
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inToken = true
+		case strings.ContainsRune("<>|;&", r):
+			return nil, fmt.Errorf("%w: shell syntax \"%c\" isn't allowed", ErrInvalidFormat, r)
+		case r == ' ' || r == '\t':
+			if inToken {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				inToken = false
+			}
+		default:
+			current.WriteRune(r)
+			inToken = true
+		}
+	}
+
+	if quote != 0 || escaped {
+		return nil, fmt.Errorf("%w: unterminated quote or escape", ErrInvalidFormat)
+	}
+
+	if inToken {
+		tokens = append(tokens, current.String())
+	}
+
+	return tokens, nil
+}
+
+// QuoteArgument quotes an argument for sh, leaving ones that are only letters,
+// digits and -_./:=,+@% alone
+func QuoteArgument(arg string) string {
+	if arg != "" && strings.IndexFunc(arg, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:=,+@%", r))
+	}) == -1 {
+		return arg
+	}
+
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// isOption is true for "-name", but not for negative numbers like "-2"
+func isOption(token string) bool {
+	if len(token) < 2 || token[0] != '-' {
+		return false
+	}
+
+	_, err := strconv.ParseFloat(token, 64)
+	return err != nil
+}
+
+// optionName strips the dash and any stream specifier, "-c:v:0" is "c"
+func optionName(token string) string {
+	name, _, _ := strings.Cut(strings.TrimPrefix(token, "-"), ":")
+	return name
+}
+
+// argumentValues maps each option to its value, with stream specifiers for
+// video kept so "-c:v" and "-codec:v" can be told apart from audio
+func argumentValues(tokens []string) map[string]string {
+	options := make(map[string]string)
+
+	for i := 0; i < len(tokens); i++ {
+		name := strings.TrimPrefix(tokens[i], "-")
+
+		switch name {
+		case "vcodec", "codec:v":
+			name = "c:v"
+		case "filter:v":
+			name = "vf"
+		}
+
+		value := ""
+		if i+1 < len(tokens) && !isOption(tokens[i+1]) {
+			i++
+			value = tokens[i]
+		}
+
+		options[name] = value
+	}
+
+	return options
+}
+
+// outputSize finds the size the arguments scale the video to
+func outputSize(options map[string]string) (width, height int, ok bool) {
+	for _, name := range []string{"s", "s:v"} {
+		if m := sizeOption.FindStringSubmatch(options[name]); m != nil {
+			width, _ = strconv.Atoi(m[1])
+			height, _ = strconv.Atoi(m[2])
+			return width, height, true
+		}
+	}
+
+	for _, name := range []string{"vf", "filter_complex", "lavfi"} {
+		if m := scaleFilter.FindStringSubmatch(options[name]); m != nil {
+			width, _ = strconv.Atoi(m[1])
+			height, _ = strconv.Atoi(m[2])
+			return width, height, true
+		}
+	}
+
+	return 0, 0, false
+}
@@ -0,0 +1,375 @@
+package encode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/ystv/web-api/services/creator/types/encode"
+	"github.com/ystv/web-api/utils"
+)
+
+// ErrInvalidDocument is returned when a preset document can't be imported
+var ErrInvalidDocument = errors.New("invalid preset document")
+
+const (
+	ImportCreate    = "create"
+	ImportUpdate    = "update"
+	ImportUnchanged = "unchanged"
+)
+
+// documentPreset is a preset with the names of its formats
+type documentPreset struct {
+	PresetID    int            `db:"preset_id"`
+	Name        string         `db:"name"`
+	Description string         `db:"description"`
+	Formats     pq.StringArray `db:"formats"`
+}
+
+// ExportPresets exports every format and preset as a document
+func (s *Store) ExportPresets(ctx context.Context) (encode.PresetDocument, error) {
+	doc := encode.PresetDocument{Version: encode.PresetDocumentVersion}
+
+	formats, err := selectFormats(ctx, s.db)
+	if err != nil {
+		return encode.PresetDocument{}, err
+	}
+
+	for _, f := range formats {
+		doc.Formats = append(doc.Formats, formatToDocument(f))
+	}
+
+	presets, err := selectDocumentPresets(ctx, s.db)
+	if err != nil {
+		return encode.PresetDocument{}, err
+	}
+
+	for _, p := range presets {
+		doc.Presets = append(doc.Presets, encode.PresetDocumentEntry{
+			Name:        p.Name,
+			Description: p.Description,
+			Formats:     utils.NonNil([]string(p.Formats)),
+		})
+	}
+
+	doc.Formats = utils.NonNil(doc.Formats)
+	doc.Presets = utils.NonNil(doc.Presets)
+
+	return doc, nil
+}
+
+// ImportPresets compares a document against the database, matching formats and
+// presets by name, and when apply is set creates and updates them to match.
+// Anything not in the document is left alone, so importing the same document
+// twice changes nothing the second time.
+func (s *Store) ImportPresets(ctx context.Context, doc encode.PresetDocument, apply bool) (encode.ImportReport, error) {
+	err := validateDocument(doc)
+	if err != nil {
+		return encode.ImportReport{}, err
+	}
+
+	report := encode.ImportReport{Applied: apply}
+
+	err = utils.Transact(s.db, func(tx *sqlx.Tx) error {
+		formats, err := selectFormats(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		formatIDs := make(map[string]int, len(formats))
+		existingFormats := make(map[string]encode.Format, len(formats))
+
+		for _, f := range formats {
+			if _, ok := existingFormats[f.Name]; ok {
+				return fmt.Errorf("%w: more than one format is called \"%s\"", ErrInvalidDocument, f.Name)
+			}
+
+			existingFormats[f.Name] = f
+			formatIDs[f.Name] = f.FormatID
+		}
+
+		for _, docFormat := range doc.Formats {
+			format := documentToFormat(docFormat)
+			change := encode.ImportChange{Name: format.Name, Action: ImportCreate}
+
+			existing, ok := existingFormats[format.Name]
+			if ok {
+				format.FormatID = existing.FormatID
+				change.Changes = formatChanges(existing, format)
+				change.Action = ImportUnchanged
+				if len(change.Changes) > 0 {
+					change.Action = ImportUpdate
+				}
+			}
+
+			report.Formats = append(report.Formats, change)
+
+			if !apply {
+				continue
+			}
+
+			switch change.Action {
+			case ImportCreate:
+				err = tx.GetContext(ctx, &format.FormatID, `
+					INSERT INTO video.encode_formats(name, description, mime_type, mode,
+						width, height, arguments, file_suffix, watermarked)
+					VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+					RETURNING format_id;`, format.Name, format.Description, format.MimeType,
+					format.Mode, format.Width, format.Height, format.Arguments, format.FileSuffix,
+					format.Watermarked)
+				if err != nil {
+					return fmt.Errorf("failed to create format \"%s\": %w", format.Name, err)
+				}
+
+				formatIDs[format.Name] = format.FormatID
+			case ImportUpdate:
+				_, err = tx.ExecContext(ctx, `
+					UPDATE video.encode_formats SET
+						description = $1, mime_type = $2, mode = $3, width = $4, height = $5,
+						arguments = $6, file_suffix = $7, watermarked = $8
+					WHERE format_id = $9;`, format.Description, format.MimeType, format.Mode,
+					format.Width, format.Height, format.Arguments, format.FileSuffix,
+					format.Watermarked, format.FormatID)
+				if err != nil {
+					return fmt.Errorf("failed to update format \"%s\": %w", format.Name, err)
+				}
+			}
+		}
+
+		presets, err := selectDocumentPresets(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		existingPresets := make(map[string]documentPreset, len(presets))
+
+		for _, p := range presets {
+			if _, ok := existingPresets[p.Name]; ok {
+				return fmt.Errorf("%w: more than one preset is called \"%s\"", ErrInvalidDocument, p.Name)
+			}
+
+			existingPresets[p.Name] = p
+		}
+
+		for _, docPreset := range doc.Presets {
+			wanted := slices.Compact(slices.Sorted(slices.Values(docPreset.Formats)))
+			change := encode.ImportChange{Name: docPreset.Name, Action: ImportCreate}
+
+			for _, name := range wanted {
+				_, inDatabase := existingFormats[name]
+				if !inDatabase && !slices.ContainsFunc(doc.Formats, func(f encode.FormatDocument) bool { return f.Name == name }) {
+					return fmt.Errorf("%w: preset \"%s\" uses format \"%s\" which doesn't exist", ErrInvalidDocument, docPreset.Name, name)
+				}
+			}
+
+			existing, ok := existingPresets[docPreset.Name]
+			if ok {
+				change.Action = ImportUnchanged
+
+				if existing.Description != docPreset.Description {
+					change.Changes = append(change.Changes, encode.FieldChange{
+						Field: "description", From: existing.Description, To: docPreset.Description,
+					})
+				}
+
+				// Sorted the same way as wanted, the database orders by its collation
+				current := slices.Sorted(slices.Values(existing.Formats))
+
+				if !slices.Equal(current, wanted) {
+					change.Changes = append(change.Changes, encode.FieldChange{
+						Field: "formats", From: strings.Join(current, ", "), To: strings.Join(wanted, ", "),
+					})
+				}
+
+				if len(change.Changes) > 0 {
+					change.Action = ImportUpdate
+				}
+			}
+
+			report.Presets = append(report.Presets, change)
+
+			if !apply || change.Action == ImportUnchanged {
+				continue
+			}
+
+			presetID := existing.PresetID
+
+			if change.Action == ImportCreate {
+				err = tx.GetContext(ctx, &presetID, `
+					INSERT INTO video.encode_presets(name, description)
+					VALUES ($1, $2)
+					RETURNING preset_id;`, docPreset.Name, docPreset.Description)
+				if err != nil {
+					return fmt.Errorf("failed to create preset \"%s\": %w", docPreset.Name, err)
+				}
+			} else {
+				_, err = tx.ExecContext(ctx, `
+					UPDATE video.encode_presets SET description = $1
+					WHERE preset_id = $2;`, docPreset.Description, presetID)
+				if err != nil {
+					return fmt.Errorf("failed to update preset \"%s\": %w", docPreset.Name, err)
+				}
+
+				_, err = tx.ExecContext(ctx, `DELETE FROM video.encode_preset_formats WHERE preset_id = $1;`, presetID)
+				if err != nil {
+					return fmt.Errorf("failed to delete old format links: %w", err)
+				}
+			}
+
+			for _, name := range wanted {
+				_, err = tx.ExecContext(ctx, `
+					INSERT INTO video.encode_preset_formats(preset_id, format_id)
+					VALUES ($1, $2);`, presetID, formatIDs[name])
+				if err != nil {
+					return fmt.Errorf("failed to link preset \"%s\" to format \"%s\": %w", docPreset.Name, name, err)
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return encode.ImportReport{}, err
+	}
+
+	report.Formats = utils.NonNil(report.Formats)
+	report.Presets = utils.NonNil(report.Presets)
+
+	return report, nil
+}
+
+// validateDocument checks the version, that names are unique and that every format is valid
+func validateDocument(doc encode.PresetDocument) error {
+	if doc.Version != encode.PresetDocumentVersion {
+		return fmt.Errorf("%w: version %d isn't supported, expected %d", ErrInvalidDocument, doc.Version, encode.PresetDocumentVersion)
+	}
+
+	formatNames := make(map[string]bool, len(doc.Formats))
+
+	for _, f := range doc.Formats {
+		if f.Name == "" {
+			return fmt.Errorf("%w: a format has no name", ErrInvalidDocument)
+		}
+
+		if formatNames[f.Name] {
+			return fmt.Errorf("%w: format \"%s\" is in the document more than once", ErrInvalidDocument, f.Name)
+		}
+
+		formatNames[f.Name] = true
+
+		err := ValidateFormat(documentToFormat(f))
+		if err != nil {
+			return fmt.Errorf("%w: format \"%s\": %w", ErrInvalidDocument, f.Name, err)
+		}
+	}
+
+	presetNames := make(map[string]bool, len(doc.Presets))
+
+	for _, p := range doc.Presets {
+		if p.Name == "" {
+			return fmt.Errorf("%w: a preset has no name", ErrInvalidDocument)
+		}
+
+		if presetNames[p.Name] {
+			return fmt.Errorf("%w: preset \"%s\" is in the document more than once", ErrInvalidDocument, p.Name)
+		}
+
+		presetNames[p.Name] = true
+	}
+
+	return nil
+}
+
+// formatChanges lists the fields that differ between an existing format and its replacement
+func formatChanges(from, to encode.Format) []encode.FieldChange {
+	fields := []struct {
+		name     string
+		from, to string
+	}{
+		{"description", from.Description, to.Description},
+		{"mimeType", from.MimeType, to.MimeType},
+		{"mode", from.Mode, to.Mode},
+		{"width", strconv.Itoa(from.Width), strconv.Itoa(to.Width)},
+		{"height", strconv.Itoa(from.Height), strconv.Itoa(to.Height)},
+		{"arguments", from.Arguments, to.Arguments},
+		{"fileSuffix", from.FileSuffix, to.FileSuffix},
+		{"watermarked", strconv.FormatBool(from.Watermarked), strconv.FormatBool(to.Watermarked)},
+	}
+
+	var changes []encode.FieldChange
+
+	for _, f := range fields {
+		if f.from != f.to {
+			changes = append(changes, encode.FieldChange{Field: f.name, From: f.from, To: f.to})
+		}
+	}
+
+	return changes
+}
+
+func selectFormats(ctx context.Context, q sqlx.QueryerContext) ([]encode.Format, error) {
+	var formats []encode.Format
+
+	err := sqlx.SelectContext(ctx, q, &formats, `
+		SELECT format_id, name, description, mime_type, mode, width, height,
+			arguments, file_suffix, watermarked
+		FROM video.encode_formats
+		ORDER BY name;`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get formats: %w", err)
+	}
+
+	return formats, nil
+}
+
+func selectDocumentPresets(ctx context.Context, q sqlx.QueryerContext) ([]documentPreset, error) {
+	var presets []documentPreset
+
+	err := sqlx.SelectContext(ctx, q, &presets, `
+		SELECT preset.preset_id, preset.name, preset.description,
+			COALESCE(array_agg(format.name ORDER BY format.name) FILTER (WHERE format.name IS NOT NULL), '{}') AS formats
+		FROM video.encode_presets preset
+		LEFT JOIN video.encode_preset_formats link ON preset.preset_id = link.preset_id
+		LEFT JOIN video.encode_formats format ON link.format_id = format.format_id
+		GROUP BY preset.preset_id
+		ORDER BY preset.name;`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get presets: %w", err)
+	}
+
+	return presets, nil
+}
+
+func formatToDocument(f encode.Format) encode.FormatDocument {
+	return encode.FormatDocument{
+		Name:        f.Name,
+		Description: f.Description,
+		MimeType:    f.MimeType,
+		Mode:        f.Mode,
+		Width:       f.Width,
+		Height:      f.Height,
+		Arguments:   f.Arguments,
+		FileSuffix:  f.FileSuffix,
+		Watermarked: f.Watermarked,
+	}
+}
+
+func documentToFormat(f encode.FormatDocument) encode.Format {
+	return encode.Format{
+		Name:        f.Name,
+		Description: f.Description,
+		MimeType:    f.MimeType,
+		Mode:        f.Mode,
+		Width:       f.Width,
+		Height:      f.Height,
+		Arguments:   f.Arguments,
+		FileSuffix:  f.FileSuffix,
+		Watermarked: f.Watermarked,
+	}
+}
@@ -22,11 +22,17 @@ func (s *Store) ListFormat(ctx context.Context) ([]encode.Format, error) {
 
 // NewFormat creates a new format
 func (s *Store) NewFormat(ctx context.Context, format encode.Format) (int, error) {
+	err := ValidateFormat(format)
+	if err != nil {
+		return 0, err
+	}
+
 	formatID := 0
-	err := s.db.GetContext(ctx, &formatID, `
+	err = s.db.GetContext(ctx, &formatID, `
 		INSERT INTO video.encode_formats(name, description, mime_type, mode,
 					width, height, arguments, file_suffix, watermarked)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);`, format.Name, format.Description,
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING format_id;`, format.Name, format.Description,
 		format.MimeType, format.Mode, format.Width, format.Height, format.Arguments,
 		format.FileSuffix, format.Watermarked)
 	if err != nil {
@@ -37,7 +43,12 @@ func (s *Store) NewFormat(ctx context.Context, format encode.Format) (int, error
 
 // UpdateFormat will update the format
 func (s *Store) UpdateFormat(ctx context.Context, format encode.Format) error {
-	_, err := s.db.ExecContext(ctx, `
+	err := ValidateFormat(format)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, `
 		UPDATE video.encode_formats SET
 			name = $1,
 			description = $2,
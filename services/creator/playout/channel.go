@@ -9,6 +9,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/ystv/web-api/services/creator"
 	"github.com/ystv/web-api/services/creator/types/playout"
@@ -31,7 +32,7 @@ func (s *Store) ListChannels(ctx context.Context) ([]playout.Channel, error) {
 	var chs []playout.Channel
 	err := s.db.SelectContext(ctx, &chs, `
 		SELECT url_name, name, description, thumbnail, output_type, output_url,
-		visibility, status, location, scheduled_start, scheduled_end
+		visibility, status, location, scheduled_start, scheduled_end, endpoint_id
 		FROM playout.channel;`)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get channels: %w", err)
@@ -44,7 +45,7 @@ func (s *Store) GetChannel(ctx context.Context, urlName string) (playout.Channel
 	var chs playout.Channel
 	err := s.db.GetContext(ctx, &chs, `
 		SELECT url_name, name, description, thumbnail, output_type, output_url,
-		visibility, status, location, scheduled_start, scheduled_end
+		visibility, status, location, scheduled_start, scheduled_end, endpoint_id
 		FROM playout.channel WHERE url_name = $1;`, urlName)
 	if err != nil {
 		return playout.Channel{}, fmt.Errorf("failed to get channel: %w", err)
@@ -54,14 +55,19 @@ func (s *Store) GetChannel(ctx context.Context, urlName string) (playout.Channel
 
 // NewChannel create a new channel
 func (s *Store) NewChannel(ctx context.Context, ch playout.Channel) error {
-	_, err := s.db.ExecContext(ctx,
+	err := s.checkEndpoint(ctx, ch.EndpointID)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx,
 		`INSERT INTO playout.channel
 		(url_name, name, description, thumbnail, output_type, output_url,
-		visibility, status, location, scheduled_start, scheduled_end)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);`,
+		visibility, status, location, scheduled_start, scheduled_end, endpoint_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);`,
 		ch.URLName, ch.Name, ch.Description, ch.Thumbnail, ch.OutputType,
 		ch.OutputURL, ch.Visibility, ch.Status, ch.Location, ch.ScheduledStart,
-		ch.ScheduledEnd)
+		ch.ScheduledEnd, ch.EndpointID)
 	if err != nil {
 		return fmt.Errorf("failed to create channe: %w", err)
 	}
@@ -75,6 +81,11 @@ func (s *Store) UpdateChannel(ctx context.Context, ch playout.Channel) error {
 		return fmt.Errorf("failed to find channel to update: %w", err)
 	}
 
+	err = s.checkEndpoint(ctx, ch.EndpointID)
+	if err != nil {
+		return err
+	}
+
 	if ch.Thumbnail != "" && !strings.Contains(ch.Thumbnail, s.cdn.Endpoint) {
 		reg := regexp.MustCompile(`.*/`)
 		res := reg.ReplaceAllString(ch.Thumbnail, "${1}")
@@ -97,11 +108,11 @@ func (s *Store) UpdateChannel(ctx context.Context, ch playout.Channel) error {
 		`UPDATE playout.channel SET
 			url_name = $1, name = $2, description = $3, thumbnail = $4,
 			output_type = $5, output_url = $6, visibility = $7,	status = $8,
-			location = $9, scheduled_start = $10, scheduled_end = $11
+			location = $9, scheduled_start = $10, scheduled_end = $11, endpoint_id = $12
 		WHERE url_name = $1;`,
 		ch.URLName, ch.Name, ch.Description, ch.Thumbnail, ch.OutputType,
 		ch.OutputURL, ch.Visibility, ch.Status, ch.Location, ch.ScheduledStart,
-		ch.ScheduledEnd)
+		ch.ScheduledEnd, ch.EndpointID)
 	if err != nil {
 		return fmt.Errorf("failed to update channel: %w", err)
 	}
@@ -118,3 +129,24 @@ func (s *Store) DeleteChannel(ctx context.Context, urlName string) error {
 	}
 	return nil
 }
+
+// checkEndpoint checks the stream endpoint a channel is linked to exists
+func (s *Store) checkEndpoint(ctx context.Context, endpointID null.Int) error {
+	if !endpointID.Valid {
+		return nil
+	}
+
+	var exists bool
+
+	err := s.db.GetContext(ctx, &exists, `
+		SELECT EXISTS(SELECT 1 FROM web_api.stream_endpoints WHERE endpoint_id = $1);`, endpointID)
+	if err != nil {
+		return fmt.Errorf("failed to check stream endpoint: %w", err)
+	}
+
+	if !exists {
+		return fmt.Errorf("%w: stream endpoint %d doesn't exist", playout.ErrInvalidChannel, endpointID.Int64)
+	}
+
+	return nil
+}
@@ -0,0 +1,253 @@
+package playout
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ystv/web-api/services/creator/types/playout"
+	"github.com/ystv/web-api/utils"
+)
+
+// maxTitleLength is the longest a schedule item's title can be
+const maxTitleLength = 100
+
+// scheduleItemSelect selects schedule items with their name filled in from
+// their video or event
+const scheduleItemSelect = `
+	SELECT sched.schedule_item_id, sched.item_type, sched.video_id, sched.event_id,
+		sched.title, sched.description, sched.scheduled_start, sched.scheduled_end,
+		COALESCE(NULLIF(sched.title, ''), item.name, event.name, '') AS name,
+		sched.created_at, sched.created_by, sched.updated_at, sched.updated_by
+	FROM playout.schedule_items sched
+	LEFT JOIN video.items item ON sched.video_id = item.video_id
+	LEFT JOIN event.events event ON sched.event_id = event.event_id`
+
+// ListSchedule lists the items of a channel's schedule that are on between from and to
+func (s *Store) ListSchedule(ctx context.Context, urlName string, from, to time.Time) ([]playout.ScheduleItem, error) {
+	channelID, err := getChannelID(ctx, s.db, urlName, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []playout.ScheduleItem
+
+	err = s.db.SelectContext(ctx, &items, scheduleItemSelect+`
+		WHERE sched.channel_id = $1 AND sched.scheduled_end > $2 AND sched.scheduled_start < $3
+		ORDER BY sched.scheduled_start;`, channelID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
+	}
+
+	return items, nil
+}
+
+// NewScheduleItem adds an item to a channel's schedule, it can't overlap any
+// of the channel's other items
+func (s *Store) NewScheduleItem(ctx context.Context, urlName string, item playout.ScheduleItem, userID int) (int, error) {
+	err := s.validateScheduleItem(ctx, item)
+	if err != nil {
+		return 0, err
+	}
+
+	var itemID int
+
+	err = utils.Transact(s.db, func(tx *sqlx.Tx) error {
+		channelID, err := getChannelID(ctx, tx, urlName, true)
+		if err != nil {
+			return err
+		}
+
+		err = checkOverlap(ctx, tx, channelID, item)
+		if err != nil {
+			return err
+		}
+
+		err = tx.GetContext(ctx, &itemID, `
+			INSERT INTO playout.schedule_items (channel_id, item_type, video_id, event_id,
+				title, description, scheduled_start, scheduled_end, created_by)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			RETURNING schedule_item_id;`, channelID, item.Type, item.VideoID, item.EventID,
+			item.Title, item.Description, item.ScheduledStart, item.ScheduledEnd, userID)
+		if err != nil {
+			return fmt.Errorf("failed to insert schedule item: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return itemID, nil
+}
+
+// UpdateScheduleItem updates an item of a channel's schedule, it can't be
+// moved to overlap any of the channel's other items
+func (s *Store) UpdateScheduleItem(ctx context.Context, urlName string, item playout.ScheduleItem, userID int) error {
+	err := s.validateScheduleItem(ctx, item)
+	if err != nil {
+		return err
+	}
+
+	return utils.Transact(s.db, func(tx *sqlx.Tx) error {
+		channelID, err := getChannelID(ctx, tx, urlName, true)
+		if err != nil {
+			return err
+		}
+
+		err = checkOverlap(ctx, tx, channelID, item)
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.ExecContext(ctx, `
+			UPDATE playout.schedule_items SET
+				item_type = $1, video_id = $2, event_id = $3, title = $4, description = $5,
+				scheduled_start = $6, scheduled_end = $7, updated_at = NOW(), updated_by = $8
+			WHERE schedule_item_id = $9 AND channel_id = $10;`, item.Type, item.VideoID,
+			item.EventID, item.Title, item.Description, item.ScheduledStart, item.ScheduledEnd,
+			userID, item.ScheduleItemID, channelID)
+		if err != nil {
+			return fmt.Errorf("failed to update schedule item: %w", err)
+		}
+
+		rows, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+
+		if rows == 0 {
+			return playout.ErrScheduleItemNotFound
+		}
+
+		return nil
+	})
+}
+
+// DeleteScheduleItem removes an item from a channel's schedule
+func (s *Store) DeleteScheduleItem(ctx context.Context, urlName string, scheduleItemID int) error {
+	res, err := s.db.ExecContext(ctx, `
+		DELETE FROM playout.schedule_items sched
+		USING playout.channel ch
+		WHERE sched.channel_id = ch.channel_id AND ch.url_name = $1
+		AND sched.schedule_item_id = $2;`, urlName, scheduleItemID)
+	if err != nil {
+		return fmt.Errorf("failed to delete schedule item: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return playout.ErrScheduleItemNotFound
+	}
+
+	return nil
+}
+
+// validateScheduleItem checks the item's times and that what it links to exists
+func (s *Store) validateScheduleItem(ctx context.Context, item playout.ScheduleItem) error {
+	if !item.ScheduledEnd.After(item.ScheduledStart) {
+		return fmt.Errorf("%w: scheduledEnd must be after scheduledStart", playout.ErrInvalidScheduleItem)
+	}
+
+	if len([]rune(item.Title)) > maxTitleLength {
+		return fmt.Errorf("%w: title can't be longer than %d characters", playout.ErrInvalidScheduleItem, maxTitleLength)
+	}
+
+	switch item.Type {
+	case "video":
+		if !item.VideoID.Valid || item.EventID.Valid {
+			return fmt.Errorf("%w: video items need a videoID and no eventID", playout.ErrInvalidScheduleItem)
+		}
+
+		var exists bool
+
+		err := s.db.GetContext(ctx, &exists, `
+			SELECT EXISTS(SELECT 1 FROM video.items WHERE video_id = $1 AND deleted_at IS NULL);`, item.VideoID)
+		if err != nil {
+			return fmt.Errorf("failed to check video: %w", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("%w: video %d doesn't exist", playout.ErrInvalidScheduleItem, item.VideoID.Int64)
+		}
+	case "event":
+		if !item.EventID.Valid || item.VideoID.Valid {
+			return fmt.Errorf("%w: event items need an eventID and no videoID", playout.ErrInvalidScheduleItem)
+		}
+
+		var exists bool
+
+		err := s.db.GetContext(ctx, &exists, `
+			SELECT EXISTS(SELECT 1 FROM event.events WHERE event_id = $1 AND deleted_at IS NULL);`, item.EventID)
+		if err != nil {
+			return fmt.Errorf("failed to check event: %w", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("%w: event %d doesn't exist", playout.ErrInvalidScheduleItem, item.EventID.Int64)
+		}
+	case "text":
+		if item.VideoID.Valid || item.EventID.Valid {
+			return fmt.Errorf("%w: text items can't have a videoID or eventID", playout.ErrInvalidScheduleItem)
+		}
+
+		if item.Title == "" {
+			return fmt.Errorf("%w: text items need a title", playout.ErrInvalidScheduleItem)
+		}
+	default:
+		return fmt.Errorf("%w: type must be video, event or text", playout.ErrInvalidScheduleItem)
+	}
+
+	return nil
+}
+
+// getChannelID finds a channel by its URL name, when lock is set the channel
+// is locked until the end of the transaction so schedule changes happen one
+// at a time and can't overlap each other
+func getChannelID(ctx context.Context, q sqlx.QueryerContext, urlName string, lock bool) (int, error) {
+	query := `SELECT channel_id FROM playout.channel WHERE url_name = $1`
+	if lock {
+		query += ` FOR UPDATE`
+	}
+
+	var channelID int
+
+	err := sqlx.GetContext(ctx, q, &channelID, query+`;`, urlName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, playout.ErrChannelNotFound
+		}
+		return 0, fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	return channelID, nil
+}
+
+// checkOverlap returns ErrScheduleOverlap when an item would overlap another item on the channel
+func checkOverlap(ctx context.Context, tx *sqlx.Tx, channelID int, item playout.ScheduleItem) error {
+	var clash playout.ScheduleItem
+
+	err := tx.GetContext(ctx, &clash, scheduleItemSelect+`
+		WHERE sched.channel_id = $1 AND sched.schedule_item_id <> $2
+		AND sched.scheduled_start < $4 AND sched.scheduled_end > $3
+		ORDER BY sched.scheduled_start
+		LIMIT 1;`, channelID, item.ScheduleItemID, item.ScheduledStart, item.ScheduledEnd)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to check for overlapping items: %w", err)
+	}
+
+	return fmt.Errorf("%w: \"%s\" (%d) is on from %s to %s", playout.ErrScheduleOverlap, clash.Name,
+		clash.ScheduleItemID, clash.ScheduledStart.Format(time.RFC3339), clash.ScheduledEnd.Format(time.RFC3339))
+}
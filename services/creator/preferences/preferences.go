@@ -0,0 +1,151 @@
+package preferences
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ystv/web-api/services/creator"
+	"github.com/ystv/web-api/services/creator/types/preferences"
+)
+
+// ErrInvalidPreferences is returned when preferences don't match the schema
+var ErrInvalidPreferences = errors.New("invalid preferences")
+
+// maxPageSize is the largest page the video list can be set to
+const maxPageSize = 100
+
+var (
+	publishStatuses = []string{"private", "internal", "public"}
+	layouts         = []string{"table", "grid"}
+	sortFields      = []string{"broadcastDate", "createdAt", "name", "views"}
+)
+
+// Store contains our dependency
+type Store struct {
+	db *sqlx.DB
+}
+
+// NewStore creates a new store
+func NewStore(db *sqlx.DB) creator.PreferencesRepo {
+	return &Store{db: db}
+}
+
+// GetPreferences gets a user's preferences, anything they haven't set is the default
+func (s *Store) GetPreferences(ctx context.Context, userID int) (preferences.Preferences, error) {
+	var raw []byte
+
+	err := s.db.GetContext(ctx, &raw, `
+		SELECT preferences
+		FROM creator.preferences
+		WHERE user_id = $1;`, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return preferences.Default(), nil
+		}
+		return preferences.Preferences{}, fmt.Errorf("failed to get preferences: %w", err)
+	}
+
+	// Decoding over the defaults, so fields added since they were saved are filled in
+	p := preferences.Default()
+
+	err = json.Unmarshal(raw, &p)
+	if err != nil {
+		return preferences.Preferences{}, fmt.Errorf("failed to decode stored preferences: %w", err)
+	}
+
+	return p, nil
+}
+
+// DecodePreferences reads a preferences document, rejecting unknown fields.
+// Fields that are left out are the default.
+func DecodePreferences(r io.Reader) (preferences.Preferences, error) {
+	p := preferences.Default()
+
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+
+	err := dec.Decode(&p)
+	if err != nil {
+		return preferences.Preferences{}, fmt.Errorf("%w: %w", ErrInvalidPreferences, err)
+	}
+
+	return p, nil
+}
+
+// UpdatePreferences validates and stores a user's preferences, replacing what was there
+func (s *Store) UpdatePreferences(ctx context.Context, userID int, p preferences.Preferences) error {
+	err := s.validatePreferences(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	var raw bytes.Buffer
+
+	err = json.NewEncoder(&raw).Encode(p)
+	if err != nil {
+		return fmt.Errorf("failed to encode preferences: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO creator.preferences (user_id, preferences)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET preferences = EXCLUDED.preferences;`, userID, raw.String())
+	if err != nil {
+		return fmt.Errorf("failed to store preferences: %w", err)
+	}
+
+	return nil
+}
+
+// validatePreferences checks each field against the values it can take, and
+// that the default preset and series exist
+func (s *Store) validatePreferences(ctx context.Context, p preferences.Preferences) error {
+	switch {
+	case !slices.Contains(publishStatuses, p.DefaultPublishStatus):
+		return fmt.Errorf("%w: defaultPublishStatus must be private, internal or public", ErrInvalidPreferences)
+	case !slices.Contains(layouts, p.ListView.Layout):
+		return fmt.Errorf("%w: listView.layout must be table or grid", ErrInvalidPreferences)
+	case p.ListView.PageSize < 1 || p.ListView.PageSize > maxPageSize:
+		return fmt.Errorf("%w: listView.pageSize must be between 1 and %d", ErrInvalidPreferences, maxPageSize)
+	case !slices.Contains(sortFields, p.ListView.SortBy):
+		return fmt.Errorf("%w: listView.sortBy must be broadcastDate, createdAt, name or views", ErrInvalidPreferences)
+	}
+
+	if p.DefaultPresetID != nil {
+		var exists bool
+
+		err := s.db.GetContext(ctx, &exists, `
+			SELECT EXISTS(SELECT 1 FROM video.encode_presets WHERE preset_id = $1);`, *p.DefaultPresetID)
+		if err != nil {
+			return fmt.Errorf("failed to check preset: %w", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("%w: preset %d doesn't exist", ErrInvalidPreferences, *p.DefaultPresetID)
+		}
+	}
+
+	if p.DefaultSeriesID != nil {
+		var exists bool
+
+		err := s.db.GetContext(ctx, &exists, `
+			SELECT EXISTS(SELECT 1 FROM video.series WHERE series_id = $1 AND deleted_at IS NULL);`, *p.DefaultSeriesID)
+		if err != nil {
+			return fmt.Errorf("failed to check series: %w", err)
+		}
+
+		if !exists {
+			return fmt.Errorf("%w: series %d doesn't exist", ErrInvalidPreferences, *p.DefaultSeriesID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,287 @@
+package storage
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/lib/pq"
+
+	"github.com/ystv/web-api/services/creator/types/storage"
+	"github.com/ystv/web-api/utils"
+)
+
+// deleteBatchSize is the most keys S3 will delete in one request
+const deleteBatchSize = 1000
+
+// object is what we need to know about a key from a bucket listing
+type object struct {
+	size         int64
+	lastModified time.Time
+}
+
+// CollectGarbage diffs the ingest and serve buckets against the video files,
+// thumbnails and avatars in the database. Objects nothing refers to are orphans,
+// and file rows whose object is missing are dangling. Anything that has been
+// that way since before the cutoff is deleted, unless it is a dry run.
+//
+// Ingest uploads are copied to the serve bucket when they are claimed, so
+// anything left in the ingest bucket past the grace period is an orphan.
+// Stream recordings are left alone, the recordings bucket isn't listed and
+// source files in it are skipped. Video files in any other bucket stop
+// anything being deleted, since their objects may have been copied to one of
+// ours under the wrong bucket name.
+func (s *Store) CollectGarbage(ctx context.Context, cutoff time.Time, dryRun bool) (storage.GCReport, error) {
+	report := storage.GCReport{
+		DryRun: dryRun,
+		Cutoff: cutoff,
+	}
+
+	// Listing everything before deleting anything, a partial listing
+	// would make every unlisted object look missing
+	buckets := []string{s.conf.IngestBucket, s.conf.ServeBucket}
+	listed := make(map[string]map[string]object, len(buckets))
+
+	for _, bucket := range buckets {
+		objects, err := s.listBucket(ctx, bucket)
+		if err != nil {
+			return storage.GCReport{}, err
+		}
+
+		listed[bucket] = objects
+	}
+
+	var files []storage.DanglingFile
+
+	err := s.db.SelectContext(ctx, &files, `
+		SELECT f.file_id, f.video_id, f.uri, f.status, f.is_source,
+			COALESCE(i.updated_at, i.created_at) AS modified_at
+		FROM video.files f
+		INNER JOIN video.items i ON f.video_id = i.video_id
+		ORDER BY f.file_id;`)
+	if err != nil {
+		return storage.GCReport{}, fmt.Errorf("failed to get video files: %w", err)
+	}
+
+	var references []storage.DanglingReference
+
+	err = s.db.SelectContext(ctx, &references, `
+		SELECT 'video.items' AS source, video_id::text AS id, thumbnail AS url
+		FROM video.items WHERE thumbnail <> ''
+		UNION ALL
+		SELECT 'video.series', series_id::text, thumbnail
+		FROM video.series WHERE thumbnail <> ''
+		UNION ALL
+		SELECT 'video.playlists', playlist_id::text, thumbnail
+		FROM video.playlists WHERE thumbnail <> ''
+		UNION ALL
+		SELECT 'playout.channel', url_name, thumbnail
+		FROM playout.channel WHERE thumbnail <> ''
+		UNION ALL
+		SELECT 'people.users', user_id::text, avatar
+		FROM people.users WHERE avatar <> '';`)
+	if err != nil {
+		return storage.GCReport{}, fmt.Errorf("failed to get thumbnails and avatars: %w", err)
+	}
+
+	referenced := make(map[string]map[string]bool, len(buckets))
+	for _, bucket := range buckets {
+		referenced[bucket] = make(map[string]bool)
+	}
+
+	unlisted := make(map[string]bool)
+
+	for _, f := range files {
+		bucket, key := SplitURI(f.URI)
+
+		objects, ok := listed[bucket]
+		if !ok {
+			if bucket != s.conf.RecordingBucket || bucket == "" {
+				unlisted[bucket] = true
+			}
+			continue
+		}
+
+		referenced[bucket][key] = true
+
+		if _, ok = objects[key]; ok {
+			continue
+		}
+
+		// Encodes in progress won't have written their object yet
+		f.Collectable = f.Status != "processing" && f.ModifiedAt.Before(cutoff)
+		report.DanglingFiles = append(report.DanglingFiles, f)
+	}
+
+	for _, ref := range references {
+		bucket, key, ok := s.objectFromURL(ref.URL)
+		if !ok {
+			continue
+		}
+
+		objects, ok := listed[bucket]
+		if !ok {
+			continue
+		}
+
+		referenced[bucket][key] = true
+
+		if _, ok = objects[key]; !ok {
+			report.DanglingReferences = append(report.DanglingReferences, ref)
+		}
+	}
+
+	for _, bucket := range buckets {
+		summary := storage.GCBucket{Bucket: bucket}
+
+		for key, obj := range listed[bucket] {
+			summary.Objects++
+			summary.Size += obj.size
+
+			if referenced[bucket][key] {
+				continue
+			}
+
+			summary.Orphans++
+
+			orphan := storage.OrphanObject{
+				Bucket:       bucket,
+				Key:          key,
+				Size:         obj.size,
+				LastModified: obj.lastModified,
+				Collectable:  obj.lastModified.Before(cutoff),
+			}
+			report.OrphanObjects = append(report.OrphanObjects, orphan)
+
+			if orphan.Collectable {
+				report.ReclaimedSize += orphan.Size
+			}
+		}
+
+		report.Buckets = append(report.Buckets, summary)
+	}
+
+	slices.SortFunc(report.OrphanObjects, func(a, b storage.OrphanObject) int {
+		return cmp.Or(cmp.Compare(a.Bucket, b.Bucket), cmp.Compare(a.Key, b.Key))
+	})
+
+	report.UnlistedBuckets = slices.Sorted(maps.Keys(unlisted))
+
+	switch {
+	case dryRun:
+	case len(report.UnlistedBuckets) > 0:
+		report.Errors = append(report.Errors, fmt.Sprintf("not deleting anything, video files are in buckets that weren't listed: %s",
+			strings.Join(report.UnlistedBuckets, ", ")))
+	default:
+		s.deleteOrphans(ctx, &report)
+		s.deleteDanglingFiles(ctx, &report)
+	}
+
+	report.OrphanObjects = utils.NonNil(report.OrphanObjects)
+	report.DanglingFiles = utils.NonNil(report.DanglingFiles)
+	report.DanglingReferences = utils.NonNil(report.DanglingReferences)
+	report.UnlistedBuckets = utils.NonNil(report.UnlistedBuckets)
+	report.Errors = utils.NonNil(report.Errors)
+
+	return report, nil
+}
+
+// listBucket lists every object in a bucket by key
+func (s *Store) listBucket(ctx context.Context, bucket string) (map[string]object, error) {
+	objects := make(map[string]object)
+
+	err := s.cdn.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+	}, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, o := range page.Contents {
+			objects[aws.StringValue(o.Key)] = object{
+				size:         aws.Int64Value(o.Size),
+				lastModified: aws.TimeValue(o.LastModified),
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bucket \"%s\": %w", bucket, err)
+	}
+
+	return objects, nil
+}
+
+// deleteOrphans deletes the collectable orphan objects, failures are added to
+// the report rather than stopping the run so the next one can retry them
+func (s *Store) deleteOrphans(ctx context.Context, report *storage.GCReport) {
+	report.ReclaimedSize = 0
+
+	keys := make(map[string][]*s3.ObjectIdentifier)
+	sizes := make(map[string]int64)
+
+	for _, o := range report.OrphanObjects {
+		if !o.Collectable {
+			continue
+		}
+
+		keys[o.Bucket] = append(keys[o.Bucket], &s3.ObjectIdentifier{Key: aws.String(o.Key)})
+		sizes[o.Bucket+"/"+o.Key] = o.Size
+	}
+
+	for bucket, ids := range keys {
+		for start := 0; start < len(ids); start += deleteBatchSize {
+			batch := ids[start:min(start+deleteBatchSize, len(ids))]
+
+			res, err := s.cdn.DeleteObjectsWithContext(ctx, &s3.DeleteObjectsInput{
+				Bucket: aws.String(bucket),
+				Delete: &s3.Delete{Objects: batch, Quiet: aws.Bool(false)},
+			})
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("failed to delete objects from \"%s\": %s", bucket, err))
+				continue
+			}
+
+			for _, deleted := range res.Deleted {
+				report.DeletedObjects++
+				report.ReclaimedSize += sizes[bucket+"/"+aws.StringValue(deleted.Key)]
+			}
+
+			for _, e := range res.Errors {
+				report.Errors = append(report.Errors, fmt.Sprintf("failed to delete object \"%s/%s\": %s",
+					bucket, aws.StringValue(e.Key), aws.StringValue(e.Message)))
+			}
+		}
+	}
+}
+
+// deleteDanglingFiles removes the collectable file rows whose object is missing
+func (s *Store) deleteDanglingFiles(ctx context.Context, report *storage.GCReport) {
+	var fileIDs []int64
+
+	for _, f := range report.DanglingFiles {
+		if f.Collectable {
+			fileIDs = append(fileIDs, int64(f.FileID))
+		}
+	}
+
+	if len(fileIDs) == 0 {
+		return
+	}
+
+	res, err := s.db.ExecContext(ctx, `DELETE FROM video.files WHERE file_id = ANY($1);`, pq.Array(fileIDs))
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("failed to delete dangling video files: %s", err))
+		return
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("failed to get rows affected: %s", err))
+		return
+	}
+
+	report.DeletedFiles = int(rows)
+}
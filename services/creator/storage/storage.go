@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ystv/web-api/services/creator"
+)
+
+// Store encapsulates our dependencies
+type Store struct {
+	db   *sqlx.DB
+	cdn  *s3.S3
+	conf *creator.Config
+}
+
+// NewStore creates a new store
+func NewStore(db *sqlx.DB, cdn *s3.S3, conf *creator.Config) creator.StorageRepo {
+	return &Store{db: db, cdn: cdn, conf: conf}
+}
+
+// SplitURI splits a video file URI, which is stored as "bucket/key", into its parts
+func SplitURI(uri string) (bucket, key string) {
+	bucket, key, _ = strings.Cut(uri, "/")
+	return bucket, key
+}
+
+// objectFromURL gets the bucket and key of a CDN URL, such as a thumbnail,
+// returning false when it isn't on the CDN
+func (s *Store) objectFromURL(url string) (bucket, key string, ok bool) {
+	if s.conf.Endpoint == "" {
+		return "", "", false
+	}
+
+	uri, ok := strings.CutPrefix(url, strings.TrimSuffix(s.conf.Endpoint, "/")+"/")
+	if !ok {
+		return "", "", false
+	}
+
+	bucket, key = SplitURI(uri)
+	if key == "" {
+		return "", "", false
+	}
+
+	return bucket, key, true
+}
@@ -0,0 +1,250 @@
+package storage
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/ystv/web-api/services/creator/types/storage"
+	"github.com/ystv/web-api/utils"
+)
+
+// reconcileWorkers is how many HeadObject requests are made at once when reconciling
+const reconcileWorkers = 16
+
+type (
+	// usageFile is a video file with everything it is broken down by
+	usageFile struct {
+		FileID     int         `db:"file_id"`
+		VideoID    int         `db:"video_id"`
+		URI        string      `db:"uri"`
+		Size       int64       `db:"size"`
+		IsSource   bool        `db:"is_source"`
+		FormatID   int         `db:"format_id"`
+		FormatName string      `db:"format_name"`
+		SeriesID   int         `db:"series_id"`
+		UserID     null.Int    `db:"created_by"`
+		UserName   null.String `db:"user_name"`
+		Year       int         `db:"year"`
+		ActualSize int64       `db:"-"`
+		Missing    bool        `db:"-"`
+	}
+
+	// usageSeries is a series and where it sits in the tree
+	usageSeries struct {
+		SeriesID int    `db:"series_id"`
+		Name     string `db:"name"`
+		Lft      int    `db:"lft"`
+		Rgt      int    `db:"rgt"`
+	}
+)
+
+// StorageUsage breaks down the storage used by the video library by series
+// subtree, creating user, encode format and broadcast year. When reconcile is
+// set every file is checked against the object store, which is slow on a
+// large library.
+func (s *Store) StorageUsage(ctx context.Context, reconcile bool) (storage.Usage, error) {
+	var files []usageFile
+
+	err := s.db.SelectContext(ctx, &files, `
+		SELECT f.file_id, f.video_id, f.uri, f.size, f.is_source, f.format_id, format.name AS format_name,
+			i.series_id, i.created_by, EXTRACT(YEAR FROM i.broadcast_date)::int AS year,
+			COALESCE(NULLIF(u.nickname, ''), u.first_name || ' ' || u.last_name) AS user_name
+		FROM video.files f
+		INNER JOIN video.items i ON f.video_id = i.video_id
+		INNER JOIN video.encode_formats format ON f.format_id = format.format_id
+		LEFT JOIN people.users u ON i.created_by = u.user_id
+		ORDER BY f.file_id;`)
+	if err != nil {
+		return storage.Usage{}, fmt.Errorf("failed to get video files: %w", err)
+	}
+
+	var series []usageSeries
+
+	err = s.db.SelectContext(ctx, &series, `
+		SELECT series_id, name, lft, rgt
+		FROM video.series
+		ORDER BY lft;`)
+	if err != nil {
+		return storage.Usage{}, fmt.Errorf("failed to get series: %w", err)
+	}
+
+	if reconcile {
+		err = s.reconcileFiles(ctx, files)
+		if err != nil {
+			return storage.Usage{}, fmt.Errorf("failed to reconcile video files: %w", err)
+		}
+	}
+
+	u := storage.Usage{Reconciled: reconcile}
+
+	// Working out each series' depth and ancestors from the nested set, so a
+	// file can be counted towards every series above it
+	u.Series = make([]storage.SeriesUsage, len(series))
+	lineage := make(map[int][]int, len(series))
+
+	var stack []int
+
+	for i, ser := range series {
+		for len(stack) > 0 && series[stack[len(stack)-1]].Rgt < ser.Lft {
+			stack = stack[:len(stack)-1]
+		}
+
+		u.Series[i] = storage.SeriesUsage{SeriesID: ser.SeriesID, Name: ser.Name, Depth: len(stack)}
+		lineage[ser.SeriesID] = append(slices.Clone(stack), i)
+		stack = append(stack, i)
+	}
+
+	users := make(map[null.Int]*storage.UserUsage)
+	formats := make(map[int]*storage.FormatUsage)
+	years := make(map[int]*storage.YearUsage)
+
+	for _, f := range files {
+		addUsage(&u.Total, f, reconcile)
+
+		for _, i := range lineage[f.SeriesID] {
+			addUsage(&u.Series[i].UsageFigure, f, reconcile)
+		}
+
+		user, ok := users[f.UserID]
+		if !ok {
+			user = &storage.UserUsage{UserID: f.UserID, Name: f.UserName.String}
+			users[f.UserID] = user
+		}
+		addUsage(&user.UsageFigure, f, reconcile)
+
+		format, ok := formats[f.FormatID]
+		if !ok {
+			format = &storage.FormatUsage{FormatID: f.FormatID, Name: f.FormatName}
+			formats[f.FormatID] = format
+		}
+		addUsage(&format.UsageFigure, f, reconcile)
+
+		if f.IsSource {
+			format.SourceFiles++
+		} else {
+			format.DropSavings += f.Size * 1024
+			format.DropSavingsActual += f.ActualSize
+		}
+
+		year, ok := years[f.Year]
+		if !ok {
+			year = &storage.YearUsage{Year: f.Year}
+			years[f.Year] = year
+		}
+		addUsage(&year.UsageFigure, f, reconcile)
+
+		if reconcile && (f.Missing || max(f.ActualSize-f.Size*1024, f.Size*1024-f.ActualSize) >= 1024) {
+			u.Discrepancies = append(u.Discrepancies, storage.FileDiscrepancy{
+				FileID:     f.FileID,
+				VideoID:    f.VideoID,
+				URI:        f.URI,
+				Size:       f.Size * 1024,
+				ActualSize: f.ActualSize,
+				Missing:    f.Missing,
+			})
+		}
+	}
+
+	for _, user := range users {
+		u.Users = append(u.Users, *user)
+	}
+	slices.SortFunc(u.Users, func(a, b storage.UserUsage) int {
+		return cmp.Or(cmp.Compare(b.Size, a.Size), cmp.Compare(a.UserID.Int64, b.UserID.Int64))
+	})
+
+	for _, format := range formats {
+		u.Formats = append(u.Formats, *format)
+	}
+	slices.SortFunc(u.Formats, func(a, b storage.FormatUsage) int {
+		return cmp.Or(cmp.Compare(b.Size, a.Size), cmp.Compare(a.FormatID, b.FormatID))
+	})
+
+	for _, year := range years {
+		u.Years = append(u.Years, *year)
+	}
+	slices.SortFunc(u.Years, func(a, b storage.YearUsage) int {
+		return cmp.Compare(a.Year, b.Year)
+	})
+
+	u.Series = utils.NonNil(u.Series)
+	u.Users = utils.NonNil(u.Users)
+	u.Formats = utils.NonNil(u.Formats)
+	u.Years = utils.NonNil(u.Years)
+	u.Discrepancies = utils.NonNil(u.Discrepancies)
+
+	return u, nil
+}
+
+// addUsage counts a file towards a figure, file sizes are stored in KB
+func addUsage(figure *storage.UsageFigure, f usageFile, reconciled bool) {
+	figure.Files++
+	figure.Size += f.Size * 1024
+
+	if reconciled {
+		figure.ActualSize += f.ActualSize
+		if f.Missing {
+			figure.Missing++
+		}
+	}
+}
+
+// reconcileFiles fills in the actual size of each file from its object
+func (s *Store) reconcileFiles(ctx context.Context, files []usageFile) error {
+	indexes := make(chan int)
+	errs := make([]error, reconcileWorkers)
+
+	var wg sync.WaitGroup
+
+	for w := range reconcileWorkers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range indexes {
+				// Draining the rest once this worker has failed
+				if errs[w] != nil {
+					continue
+				}
+
+				bucket, key := SplitURI(files[i].URI)
+
+				res, err := s.cdn.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+					Bucket: aws.String(bucket),
+					Key:    aws.String(key),
+				})
+				if err != nil {
+					var reqErr awserr.RequestFailure
+					if errors.As(err, &reqErr) && reqErr.StatusCode() == http.StatusNotFound {
+						files[i].Missing = true
+						continue
+					}
+
+					errs[w] = fmt.Errorf("failed to head object \"%s\": %w", files[i].URI, err)
+					continue
+				}
+
+				files[i].ActualSize = aws.Int64Value(res.ContentLength)
+			}
+		}()
+	}
+
+	for i := range files {
+		indexes <- i
+	}
+
+	close(indexes)
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
@@ -24,3 +24,60 @@ type (
 		Watermarked bool   `json:"watermarked" db:"watermarked"`
 	}
 )
+
+// PresetDocumentVersion is the version of PresetDocument this web-api reads and writes
+const PresetDocumentVersion = 1
+
+type (
+	// PresetDocument is every preset and format as a portable document. Rows are
+	// matched by name rather than ID, so it can be kept in git and moved between
+	// environments.
+	PresetDocument struct {
+		Version int                   `json:"version" yaml:"version"`
+		Formats []FormatDocument      `json:"formats" yaml:"formats"`
+		Presets []PresetDocumentEntry `json:"presets" yaml:"presets"`
+	}
+
+	// FormatDocument is an encode format without its ID
+	FormatDocument struct {
+		Name        string `json:"name" yaml:"name"`
+		Description string `json:"description" yaml:"description"`
+		MimeType    string `json:"mimeType" yaml:"mimeType"`
+		Mode        string `json:"mode" yaml:"mode"`
+		Width       int    `json:"width" yaml:"width"`
+		Height      int    `json:"height" yaml:"height"`
+		Arguments   string `json:"arguments" yaml:"arguments"`
+		FileSuffix  string `json:"fileSuffix" yaml:"fileSuffix"`
+		Watermarked bool   `json:"watermarked" yaml:"watermarked"`
+	}
+
+	// PresetDocumentEntry is a preset with its formats by name
+	PresetDocumentEntry struct {
+		Name        string   `json:"name" yaml:"name"`
+		Description string   `json:"description" yaml:"description"`
+		Formats     []string `json:"formats" yaml:"formats"`
+	}
+
+	// ImportReport is the difference between a preset document and the database,
+	// Applied is false when it was only a dry run
+	ImportReport struct {
+		Applied bool           `json:"applied"`
+		Formats []ImportChange `json:"formats"`
+		Presets []ImportChange `json:"presets"`
+	}
+
+	// ImportChange is what an import does to a format or preset
+	ImportChange struct {
+		Name string `json:"name"`
+		// Action is create, update or unchanged
+		Action  string        `json:"action"`
+		Changes []FieldChange `json:"changes,omitempty"`
+	}
+
+	// FieldChange is a field an import updates
+	FieldChange struct {
+		Field string `json:"field"`
+		From  string `json:"from"`
+		To    string `json:"to"`
+	}
+)
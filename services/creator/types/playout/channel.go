@@ -1,9 +1,13 @@
 package playout
 
-import "time"
+import (
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
 
 // This is currently quite bare bones; it is hoped it will integrate with
-// ystv/playout to provide more data
+// ystv/playout to provide more data. Programmes are on the channel's schedule.
 
 // Channel represents a derivative of ystv/playout's channels.
 // These are events only rather than linear or events.
@@ -19,4 +23,7 @@ type Channel struct {
 	Location       string    `db:"location" json:"location"` // "Central Hall"
 	ScheduledStart time.Time `db:"scheduled_start" json:"scheduledStart"`
 	ScheduledEnd   time.Time `db:"scheduled_end" json:"scheduledEnd"`
+	// EndpointID is the stream endpoint feeding the channel, when set the
+	// channel's status follows it going live and its scheduled times
+	EndpointID null.Int `db:"endpoint_id" json:"endpointID"`
 }
@@ -0,0 +1,38 @@
+package playout
+
+import (
+	"errors"
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+var (
+	ErrChannelNotFound      = errors.New("channel not found")
+	ErrInvalidChannel       = errors.New("invalid channel")
+	ErrScheduleItemNotFound = errors.New("schedule item not found")
+	ErrInvalidScheduleItem  = errors.New("invalid schedule item")
+	ErrScheduleOverlap      = errors.New("schedule item overlaps another")
+)
+
+type (
+	// ScheduleItem is a timed programme on a channel's schedule, it is either a
+	// video, an event or free text
+	ScheduleItem struct {
+		ScheduleItemID int      `db:"schedule_item_id" json:"scheduleItemID"`
+		Type           string   `db:"item_type" json:"type"` // "video" or "event" or "text"
+		VideoID        null.Int `db:"video_id" json:"videoID"`
+		EventID        null.Int `db:"event_id" json:"eventID"`
+		// Title overrides the video or event name, it is required for text items
+		Title          string    `db:"title" json:"title"`
+		Description    string    `db:"description" json:"description"`
+		ScheduledStart time.Time `db:"scheduled_start" json:"scheduledStart"`
+		ScheduledEnd   time.Time `db:"scheduled_end" json:"scheduledEnd"`
+		// Name is the title, or the name of the video or event when it isn't set
+		Name      string    `db:"name" json:"name"`
+		CreatedAt time.Time `db:"created_at" json:"createdAt"`
+		CreatedBy null.Int  `db:"created_by" json:"createdBy"`
+		UpdatedAt null.Time `db:"updated_at" json:"updatedAt"`
+		UpdatedBy null.Int  `db:"updated_by" json:"updatedBy"`
+	}
+)
@@ -0,0 +1,51 @@
+package preferences
+
+type (
+	// Preferences are a creator's defaults and options for the creator studio
+	Preferences struct {
+		// DefaultPresetID is the encode preset new videos use when none is given
+		DefaultPresetID *int `json:"defaultPresetID"`
+		// DefaultSeriesID is the series new videos go in when none is given
+		DefaultSeriesID *int `json:"defaultSeriesID"`
+		// DefaultPublishStatus is private, internal or public
+		DefaultPublishStatus string        `json:"defaultPublishStatus"`
+		ListView             ListView      `json:"listView"`
+		Notifications        Notifications `json:"notifications"`
+	}
+
+	// ListView is how the video list is shown
+	ListView struct {
+		// Layout is table or grid
+		Layout   string `json:"layout"`
+		PageSize int    `json:"pageSize"`
+		// SortBy is broadcastDate, createdAt, name or views
+		SortBy         string `json:"sortBy"`
+		SortDescending bool   `json:"sortDescending"`
+	}
+
+	// Notifications are what a creator wants to be told about
+	Notifications struct {
+		EncodeFinished bool `json:"encodeFinished"`
+		EncodeFailed   bool `json:"encodeFailed"`
+		VideoPublished bool `json:"videoPublished"`
+		// Email sends notifications by email as well
+		Email bool `json:"email"`
+	}
+)
+
+// Default is what a creator gets before they change anything
+func Default() Preferences {
+	return Preferences{
+		DefaultPublishStatus: "internal",
+		ListView: ListView{
+			Layout:         "table",
+			PageSize:       25,
+			SortBy:         "broadcastDate",
+			SortDescending: true,
+		},
+		Notifications: Notifications{
+			EncodeFinished: true,
+			EncodeFailed:   true,
+		},
+	}
+}
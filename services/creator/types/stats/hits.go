@@ -0,0 +1,79 @@
+package stats
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidRange is returned when a stats time range or interval can't be used
+var ErrInvalidRange = errors.New("invalid stats range")
+
+type (
+	// Range is the time range stats are collected over, split into buckets of
+	// hour, day, week or month
+	Range struct {
+		From     time.Time `json:"from"`
+		To       time.Time `json:"to"`
+		Interval string    `json:"interval"`
+	}
+
+	// HitStats summarises the hits of one or more videos. Unique viewers are
+	// approximated by IP address and client. Downloads are always logged as
+	// fully watched, so they're left out of the average percent and retention.
+	HitStats struct {
+		Views          int               `json:"views"`
+		UniqueViewers  int               `json:"uniqueViewers"`
+		AveragePercent float64           `json:"averagePercent"`
+		Modes          []ModeStats       `json:"modes"`
+		Timeline       []TimeBucket      `json:"timeline"`
+		Retention      []RetentionBucket `json:"retention"`
+	}
+
+	// ModeStats is the number of views of a mode, watch, embed or download
+	ModeStats struct {
+		Mode  string `db:"mode" json:"mode"`
+		Views int    `db:"views" json:"views"`
+	}
+
+	// TimeBucket is the views in an interval starting at Start
+	TimeBucket struct {
+		Start         time.Time `db:"start" json:"start"`
+		Views         int       `db:"views" json:"views"`
+		UniqueViewers int       `db:"unique_viewers" json:"uniqueViewers"`
+	}
+
+	// RetentionBucket is the views that stopped between Percent and the next
+	// bucket, and the share of views that made it to at least Percent
+	RetentionBucket struct {
+		Percent  int     `json:"percent"`
+		Views    int     `json:"views"`
+		Retained float64 `json:"retained"`
+	}
+
+	// VideoStats is how a video has been watched over a range
+	VideoStats struct {
+		VideoID int    `json:"videoID"`
+		Name    string `json:"name"`
+		Range
+		HitStats
+	}
+
+	// SeriesStats is how the videos in a series and the series below it have
+	// been watched over a range
+	SeriesStats struct {
+		SeriesID int    `json:"seriesID"`
+		Name     string `json:"name"`
+		Range
+		HitStats
+		Videos []VideoSummary `json:"videos"`
+	}
+
+	// VideoSummary is a video's share of a series' views
+	VideoSummary struct {
+		VideoID        int     `db:"video_id" json:"videoID"`
+		Name           string  `db:"name" json:"name"`
+		Views          int     `db:"views" json:"views"`
+		UniqueViewers  int     `db:"unique_viewers" json:"uniqueViewers"`
+		AveragePercent float64 `db:"average_percent" json:"averagePercent"`
+	}
+)
@@ -0,0 +1,65 @@
+package storage
+
+import "time"
+
+type (
+	// GCReport is what the storage garbage collector found, and what it removed
+	// when it wasn't a dry run
+	GCReport struct {
+		DryRun bool `json:"dryRun"`
+		// Cutoff is the end of the grace period, only things older than it are collectable
+		Cutoff  time.Time  `json:"cutoff"`
+		Buckets []GCBucket `json:"buckets"`
+		// OrphanObjects are objects that nothing in the database refers to
+		OrphanObjects []OrphanObject `json:"orphanObjects"`
+		// DanglingFiles are video file rows whose object is missing
+		DanglingFiles []DanglingFile `json:"danglingFiles"`
+		// DanglingReferences are thumbnails and avatars pointing at a missing object
+		DanglingReferences []DanglingReference `json:"danglingReferences"`
+		// UnlistedBuckets are buckets video files are in that weren't listed. Their
+		// objects could be anywhere, so nothing is deleted while there are any.
+		UnlistedBuckets []string `json:"unlistedBuckets"`
+		DeletedObjects  int      `json:"deletedObjects"`
+		DeletedFiles    int      `json:"deletedFiles"`
+		// ReclaimedSize is the size in bytes of the deleted objects, or what would be
+		// reclaimed on a dry run
+		ReclaimedSize int64    `json:"reclaimedSize"`
+		Errors        []string `json:"errors"`
+	}
+
+	// GCBucket summarises a bucket the garbage collector listed
+	GCBucket struct {
+		Bucket  string `json:"bucket"`
+		Objects int    `json:"objects"`
+		Size    int64  `json:"size"`
+		Orphans int    `json:"orphans"`
+	}
+
+	// OrphanObject is an object in storage with no database references
+	OrphanObject struct {
+		Bucket       string    `json:"bucket"`
+		Key          string    `json:"key"`
+		Size         int64     `json:"size"`
+		LastModified time.Time `json:"lastModified"`
+		Collectable  bool      `json:"collectable"`
+	}
+
+	// DanglingFile is a video file row whose object isn't in storage
+	DanglingFile struct {
+		FileID      int       `db:"file_id" json:"fileID"`
+		VideoID     int       `db:"video_id" json:"videoID"`
+		URI         string    `db:"uri" json:"uri"`
+		Status      string    `db:"status" json:"status"`
+		IsSource    bool      `db:"is_source" json:"isSource"`
+		ModifiedAt  time.Time `db:"modified_at" json:"modifiedAt"`
+		Collectable bool      `db:"-" json:"collectable"`
+	}
+
+	// DanglingReference is a thumbnail or avatar URL whose object isn't in storage,
+	// these are only reported since the row itself is still valid
+	DanglingReference struct {
+		Table string `db:"source" json:"table"`
+		ID    string `db:"id" json:"id"`
+		URL   string `db:"url" json:"url"`
+	}
+)
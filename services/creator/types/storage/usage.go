@@ -0,0 +1,72 @@
+package storage
+
+import "gopkg.in/guregu/null.v4"
+
+type (
+	// Usage is a breakdown of the storage used by the video library. Sizes are
+	// in bytes, when reconciled the actual sizes come from the object store.
+	Usage struct {
+		Reconciled bool          `json:"reconciled"`
+		Total      UsageFigure   `json:"total"`
+		Series     []SeriesUsage `json:"series"`
+		Users      []UserUsage   `json:"users"`
+		Formats    []FormatUsage `json:"formats"`
+		Years      []YearUsage   `json:"years"`
+		// Discrepancies are files whose recorded size doesn't match the object store
+		Discrepancies []FileDiscrepancy `json:"discrepancies"`
+	}
+
+	// UsageFigure is the storage used by a group of video files
+	UsageFigure struct {
+		Files int `json:"files"`
+		// Size is the recorded size of the files
+		Size int64 `json:"size"`
+		// ActualSize is the size of the objects, only set when reconciled
+		ActualSize int64 `json:"actualSize"`
+		// Missing is the number of files without an object, only set when reconciled
+		Missing int `json:"missing"`
+	}
+
+	// SeriesUsage is the storage used by a series and everything below it
+	SeriesUsage struct {
+		SeriesID int    `json:"seriesID"`
+		Name     string `json:"name"`
+		Depth    int    `json:"depth"`
+		UsageFigure
+	}
+
+	// UserUsage is the storage used by the videos a user created
+	UserUsage struct {
+		UserID null.Int `json:"userID"`
+		Name   string   `json:"name"`
+		UsageFigure
+	}
+
+	// FormatUsage is the storage used by an encode format
+	FormatUsage struct {
+		FormatID int    `json:"formatID"`
+		Name     string `json:"name"`
+		UsageFigure
+		// DropSavings is what would be freed by dropping the format, source
+		// files aren't included since they would have to be kept
+		DropSavings       int64 `json:"dropSavings"`
+		DropSavingsActual int64 `json:"dropSavingsActual"`
+		SourceFiles       int   `json:"sourceFiles"`
+	}
+
+	// YearUsage is the storage used by the videos broadcast in a year
+	YearUsage struct {
+		Year int `json:"year"`
+		UsageFigure
+	}
+
+	// FileDiscrepancy is a video file whose recorded size differs from its object
+	FileDiscrepancy struct {
+		FileID     int    `json:"fileID"`
+		VideoID    int    `json:"videoID"`
+		URI        string `json:"uri"`
+		Size       int64  `json:"size"`
+		ActualSize int64  `json:"actualSize"`
+		Missing    bool   `json:"missing"`
+	}
+)
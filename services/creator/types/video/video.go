@@ -28,6 +28,7 @@ type (
 
 	// FileDB represents a more readable VideoFile.
 	FileDB struct {
+		FileID       int      `db:"file_id"`
 		URI          string   `db:"uri"`
 		EncodeFormat string   `db:"name"`
 		Status       string   `db:"status"`
@@ -37,6 +38,7 @@ type (
 
 	// File represents a more readable VideoFile.
 	File struct {
+		FileID       int    `json:"fileID"`
 		URI          string `json:"uri"`
 		EncodeFormat string `json:"encodeFormat"`
 		Status       string `json:"status"`
@@ -135,11 +137,51 @@ type (
 		BroadcastDate time.Time `json:"broadcastDate" db:"broadcast_date"`
 	}
 
+	// Recording is a stream recording to be made into a draft video. The file is
+	// already in the object store, so it's linked as the source rather than copied.
+	Recording struct {
+		SeriesID int
+		// PresetID is applied once the video is created, nothing is encoded without one
+		PresetID      *int
+		Name          string
+		URLName       string
+		Description   string
+		Tags          []string
+		BroadcastDate time.Time
+		// URI of the recording, as "bucket/key"
+		URI string
+	}
+
+	// FileURL is where a video file can be fetched from
+	FileURL struct {
+		URL string `json:"url"`
+		// ExpiresAt is when a signed URL stops working, public files aren't signed
+		ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	}
+
+	// FileRequest is who is asking for a video file and how
+	FileRequest struct {
+		VideoID int
+		FileID  int
+		UserID  int
+		// Privileged users can fetch private files they didn't create
+		Privileged bool
+		// Download logs a download hit and asks the browser to save the file
+		Download   bool
+		IPAddress  string
+		ClientInfo string
+	}
+
 	Tag []string
 )
 
 var (
-	ErrNotFound = errors.New("video not found")
+	ErrNotFound       = errors.New("video not found")
+	ErrFileNotFound   = errors.New("video file not found")
+	ErrFileForbidden  = errors.New("video file is private")
+	ErrFileProcessing = errors.New("video file is still processing")
+	// ErrRecordingNotFound is returned when a recording isn't in the object store
+	ErrRecordingNotFound = errors.New("recording not found")
 )
 
 func (t *Tag) Value() (driver.Value, error) {
@@ -2,12 +2,14 @@ package video
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/jmoiron/sqlx"
 
+	"github.com/ystv/web-api/services/creator/storage"
 	"github.com/ystv/web-api/utils"
 )
 
@@ -34,12 +36,12 @@ func (s *Store) DeleteItemPermanently(ctx context.Context, videoID int) error {
 	// Then we will need to delete the object files
 	// * VOD files
 	// * Original primary
-	var fileURLs []string
+	var fileURIs []string
 	// Wrapped in transaction, so we can roll back if it fails, however,
 	// S3 doesn't support transactions, so only a database is protected
 	err := utils.Transact(s.db, func(tx *sqlx.Tx) error {
 		// Get child files
-		err := tx.SelectContext(ctx, &fileURLs, `
+		err := tx.SelectContext(ctx, &fileURIs, `
 			SELECT  uri
 			FROM video.files
 			WHERE video_id = $1;`, videoID)
@@ -47,33 +49,47 @@ func (s *Store) DeleteItemPermanently(ctx context.Context, videoID int) error {
 			return fmt.Errorf("failed to find video file URLs: %w", err)
 		}
 
-		// First delete from the database
+		_, err = tx.ExecContext(ctx, `DELETE FROM video.hits WHERE video_id = $1;`, videoID)
+		if err != nil {
+			return fmt.Errorf("failed to delete video hits from database: %w", err)
+		}
+
 		_, err = tx.ExecContext(ctx, `DELETE FROM video.files WHERE video_id = $1;`, videoID)
 		if err != nil {
 			return fmt.Errorf("failed to delete video file from database: %w", err)
 		}
 
-		// Then deleting from object store
-		for _, file := range fileURLs {
-			_, err = s.cdn.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
-				Bucket: aws.String(s.conf.ServeBucket),
-				Key:    aws.String(file),
-			})
-			if err != nil {
-				return fmt.Errorf("failed to delete video file object: %w", err)
-			}
-
-			// Finally, removing the video item / meta from the database
-			_, err = tx.ExecContext(ctx, `DELETE FROM video.items WHERE video_id = $1`, videoID)
-			if err != nil {
-				return fmt.Errorf("failed to delete video item from database: %w", err)
-			}
+		// Finally, removing the video item / meta from the database
+		_, err = tx.ExecContext(ctx, `DELETE FROM video.items WHERE video_id = $1;`, videoID)
+		if err != nil {
+			return fmt.Errorf("failed to delete video item from database: %w", err)
 		}
+
 		return nil
 	})
 	if err != nil {
 		return fmt.Errorf("failed to permanently delete video \"%d\": %w", videoID, err)
 	}
 
+	// Then deleting from object store, carrying on past failures since the
+	// rows are already gone, anything left behind is picked up by the storage GC
+	var errs []error
+
+	for _, uri := range fileURIs {
+		bucket, key := storage.SplitURI(uri)
+
+		_, err = s.cdn.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete video file object \"%s\": %w", uri, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("deleted video \"%d\" but not all of its files: %w", videoID, errors.Join(errs...))
+	}
+
 	return nil
 }
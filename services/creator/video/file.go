@@ -0,0 +1,91 @@
+package video
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/ystv/web-api/services/creator/storage"
+	"github.com/ystv/web-api/services/creator/types/video"
+)
+
+// signedURLExpiry is how long a signed file URL works for
+const signedURLExpiry = 15 * time.Minute
+
+// GetFileURL gets a URL for a video file. Public files are served straight from
+// the CDN, anything else gets a short-lived signed URL. Private files are only
+// available to the video's creator and privileged users.
+func (s *Store) GetFileURL(ctx context.Context, r video.FileRequest) (video.FileURL, error) {
+	var f struct {
+		URI         string `db:"uri"`
+		FileStatus  string `db:"file_status"`
+		VideoStatus string `db:"video_status"`
+		CreatedBy   *int   `db:"created_by"`
+	}
+
+	err := s.db.GetContext(ctx, &f, `
+		SELECT file.uri, file.status AS file_status, item.status AS video_status, item.created_by
+		FROM video.files file
+		INNER JOIN video.items item ON file.video_id = item.video_id
+		WHERE file.file_id = $1 AND file.video_id = $2 AND item.deleted_at IS NULL;`, r.FileID, r.VideoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return video.FileURL{}, video.ErrFileNotFound
+		}
+		return video.FileURL{}, fmt.Errorf("failed to get video file: %w", err)
+	}
+
+	if f.FileStatus == "processing" {
+		return video.FileURL{}, video.ErrFileProcessing
+	}
+
+	private := f.FileStatus == "private" || f.VideoStatus == "private"
+	if private && !r.Privileged && (f.CreatedBy == nil || *f.CreatedBy != r.UserID) {
+		return video.FileURL{}, video.ErrFileForbidden
+	}
+
+	var u video.FileURL
+
+	if f.FileStatus == "public" && f.VideoStatus == "public" && !r.Download {
+		u.URL = s.conf.Endpoint + "/" + f.URI
+	} else {
+		bucket, key := storage.SplitURI(f.URI)
+
+		input := &s3.GetObjectInput{
+			Bucket: aws.String(bucket),
+			Key:    aws.String(key),
+		}
+
+		if r.Download {
+			input.ResponseContentDisposition = aws.String(fmt.Sprintf("attachment; filename=\"%s\"", path.Base(key)))
+		}
+
+		req, _ := s.cdn.GetObjectRequest(input)
+
+		u.URL, err = req.Presign(signedURLExpiry)
+		if err != nil {
+			return video.FileURL{}, fmt.Errorf("failed to sign video file url: %w", err)
+		}
+
+		expiresAt := time.Now().Add(signedURLExpiry)
+		u.ExpiresAt = &expiresAt
+	}
+
+	if r.Download {
+		// A download is the whole file, so it counts as fully watched
+		_, err = s.db.ExecContext(ctx, `
+			INSERT INTO video.hits (start_time, mode, ip_address, client_info, percent, video_id)
+			VALUES (NOW(), 'download', $1, $2, 100, $3);`, r.IPAddress, r.ClientInfo, r.VideoID)
+		if err != nil {
+			return video.FileURL{}, fmt.Errorf("failed to log download: %w", err)
+		}
+	}
+
+	return u, nil
+}
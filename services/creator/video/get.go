@@ -8,6 +8,8 @@ import (
 
 	"github.com/ystv/web-api/services/creator/types/series"
 	"github.com/ystv/web-api/services/creator/types/video"
+	"github.com/ystv/web-api/services/search"
+	"github.com/ystv/web-api/utils"
 )
 
 // GetItem returns a VideoItem by its ID.
@@ -30,7 +32,7 @@ func (s *Store) GetItem(ctx context.Context, videoID int) (video.ItemDB, error)
 	}
 
 	err = s.db.SelectContext(ctx, &v.Files,
-		`SELECT uri, name, status, size, mime_type
+		`SELECT file_id, uri, name, status, size, mime_type
 		FROM video.files file
 		INNER JOIN video.encode_formats format ON file.format_id = format.format_id
 		WHERE video_id = $1;`, videoID)
@@ -42,17 +44,32 @@ func (s *Store) GetItem(ctx context.Context, videoID int) (video.ItemDB, error)
 	return v, nil
 }
 
-// ListMeta returns a list of VideoMeta's
-func (s *Store) ListMeta(ctx context.Context) ([]video.MetaDB, error) {
+// ListMeta returns a page of VideoMeta's, newest first
+func (s *Store) ListMeta(ctx context.Context, cursor *utils.Cursor, size int) (utils.CursorPage[video.MetaDB], error) {
 	var v []video.MetaDB
 
-	err := s.db.SelectContext(ctx, &v,
-		`SELECT video_id, series_id, name video_name, url,
-		duration, views, tags, status, broadcast_date,	created_at
-		FROM video.items
-		ORDER BY broadcast_date DESC;`)
+	where, order := utils.Keyset(cursor, "broadcast_date", "video_id", true)
 
-	return v, err
+	builder := utils.PSQL().Select("video_id", "series_id", "name video_name", "url", "duration", "views", "tags",
+		"status", "broadcast_date", "created_at").
+		From("video.items").
+		Where(where).
+		OrderBy(order...).
+		Limit(uint64(size + 1))
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		panic(fmt.Errorf("failed to build sql for ListMeta: %w", err))
+	}
+
+	err = s.db.SelectContext(ctx, &v, sql, args...)
+	if err != nil {
+		return utils.CursorPage[video.MetaDB]{}, err
+	}
+
+	return utils.NewCursorPage(v, size, cursor, func(meta video.MetaDB) (string, int) {
+		return utils.TimeKey(meta.BroadcastDate), meta.ID
+	}), nil
 }
 
 // ListMetaByUser returns a list of VideoMeta's for a given user
@@ -101,55 +118,10 @@ func (s *Store) OfSeries(ctx context.Context, seriesID int) ([]video.MetaDB, err
 	return v, nil
 }
 
-// Search performs a full-text search on video library
-//
-// Uses postgres full-text search, video and series tables to try to make some sense
-func (s *Store) Search(ctx context.Context, query string) ([]video.MetaDB, error) {
-	var videos []video.MetaDB
-
-	err := s.db.SelectContext(ctx, &videos,
-		`SELECT
-			video_id,
-			name,
-			url,
-			description,
-			thumbnail,
-			broadcast_date,
-			views,
-			duration,
-			tags,
-			status
-   		FROM (
-			SELECT
-				video.video_id,
-				video.name,
-				video.url,
-				video.description,
-				video.thumbnail,
-				video.views,
-				video.duration,
-				video.tags,
-				video.status,
-				video.broadcast_date broadcast_date,
-
-				to_tsvector(video.name) || ' ' ||
-				to_tsvector(video.description) || ' ' ||
-				to_tsvector(unnest(video.tags)) || ' ' ||
-				to_tsvector(CAST(video.broadcast_date AS text)) || ' ' ||
-				to_tsvector(unnest(array_agg(series.name))) || ' ' ||
-				to_tsvector(unnest(array_agg(series.description)))
-				AS document
-   			FROM video.items video
-			INNER JOIN video.series series ON video.series_id = series.series_id
-			GROUP BY video.video_id) p_search,
-			
-			ts_rank_cd(p_search.document, replace(plainto_tsquery($1)::text, '&', '|')::tsquery) rank
-
-   WHERE p_search.document @@ replace(plainto_tsquery($1)::text, '&', '|')::tsquery
-   ORDER BY rank DESC;`, query)
-	if err != nil {
-		return nil, fmt.Errorf("failed to search videos: %w", err)
-	}
+// Search performs a ranked full-text search on the whole video library,
+// including videos that aren't public
+func (s *Store) Search(ctx context.Context, params search.Params) (search.Results, error) {
+	params.PublicOnly = false
 
-	return videos, nil
+	return s.search.Search(ctx, params)
 }
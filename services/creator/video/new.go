@@ -2,6 +2,7 @@ package video
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -10,16 +11,18 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/ystv/web-api/services/creator"
 	"github.com/ystv/web-api/services/creator/types/video"
 	"github.com/ystv/web-api/services/encoder"
+	"github.com/ystv/web-api/services/search"
 	"github.com/ystv/web-api/utils"
 )
 
 // NewStore returns a new store
 func NewStore(db *sqlx.DB, cdn *s3.S3, enc encoder.Repo, conf *creator.Config) creator.VideoRepo {
-	return &Store{db: db, cdn: cdn, enc: enc, conf: conf}
+	return &Store{db: db, cdn: cdn, enc: enc, search: search.NewStore(db), conf: conf}
 }
 
 // NewItem creates a new video item
@@ -38,16 +41,21 @@ func (s *Store) NewItem(ctx context.Context, v video.New) (int, error) {
 
 	// New video ID will be filled when created
 	var videoID int
+	// Key of the copy in the serve bucket, so it can be removed if the insert fails
+	var servedKey string
 
 	err = utils.Transact(s.db, func(tx *sqlx.Tx) error {
 		// Inserting video item record
 		itemQuery := `INSERT INTO video.items (series_id, name, url, description, tags,
-			status, created_at, created_by, broadcast_date)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			status, preset_id, created_at, created_by, broadcast_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		RETURNING video_id;`
 
+		// No preset is a zero ID
+		presetID := null.NewInt(int64(v.PresetID), v.PresetID != 0)
+
 		err = tx.QueryRowContext(ctx,
-			itemQuery, &v.SeriesID, &v.Name, &v.URLName, &v.Description, pq.Array(v.Tags), &v.PublishType, &v.CreatedAt, &v.CreatedBy, &v.BroadcastDate).Scan(&videoID)
+			itemQuery, &v.SeriesID, &v.Name, &v.URLName, &v.Description, pq.Array(v.Tags), &v.PublishType, presetID, &v.CreatedAt, &v.CreatedBy, &v.BroadcastDate).Scan(&videoID)
 		if err != nil {
 			err = fmt.Errorf("failed to insert video item: %w", err)
 			return err
@@ -67,11 +75,14 @@ func (s *Store) NewItem(ctx context.Context, v video.New) (int, error) {
 			return fmt.Errorf("failed to copy video object from pending bucket to video bucket: %w", err)
 		}
 
+		servedKey = key
+
 		// Updating DB to reflect this
 		fileQuery := `INSERT INTO video.files (video_id, format_id, uri, status, size, is_source)
 					VALUES ($1, $2, $3, $4, $5, $6);`
 
-		_, err = tx.ExecContext(ctx, fileQuery, videoID, 1, "videos/"+key, "internal", *obj.ContentLength, true) // TODO make an original encode format
+		// Size is stored in KB
+		_, err = tx.ExecContext(ctx, fileQuery, videoID, 1, s.conf.ServeBucket+"/"+key, "internal", *obj.ContentLength/1024, true) // TODO make an original encode format
 		if err != nil {
 			return fmt.Errorf("failed to insert video file row: %w", err)
 		}
@@ -79,16 +90,21 @@ func (s *Store) NewItem(ctx context.Context, v video.New) (int, error) {
 		return nil
 	})
 	if err != nil {
+		err = fmt.Errorf("failed to insert create: %w", err)
+
 		// Since we've wrapped in transaction the DB is safe, will just need to make sure s3 is back to the original state
-		_, err = s.cdn.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
-			Bucket: aws.String(s.conf.ServeBucket),
-			Key:    aws.String(s.conf.IngestBucket + "/" + v.FileID[:32]),
-		})
-		if err != nil {
-			return 0, err
+		if servedKey != "" {
+			_, deleteErr := s.cdn.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+				Bucket: aws.String(s.conf.ServeBucket),
+				Key:    aws.String(servedKey),
+			})
+			if deleteErr != nil {
+				// The storage GC will pick the copy up later
+				err = errors.Join(err, fmt.Errorf("failed to remove copied video object \"%s\": %w", servedKey, deleteErr))
+			}
 		}
 
-		return 0, fmt.Errorf("failed to insert create: %w", err)
+		return 0, err
 	}
 
 	// Check if a preset was attached, if so we will start transcoding jobs
@@ -0,0 +1,88 @@
+package video
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/ystv/web-api/services/creator/storage"
+	"github.com/ystv/web-api/services/creator/types/video"
+	"github.com/ystv/web-api/utils"
+)
+
+// NewItemFromRecording creates a private video with the recording as its source
+// file, then applies the preset so it's encoded. A recording that has already
+// been registered returns the video it belongs to, since media servers can call
+// their hooks more than once.
+func (s *Store) NewItemFromRecording(ctx context.Context, r video.Recording) (int, error) {
+	var videoID int
+
+	err := s.db.GetContext(ctx, &videoID, `
+		SELECT video_id
+		FROM video.files
+		WHERE uri = $1 AND is_source;`, r.URI)
+	if err == nil {
+		return videoID, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("failed to check for existing recording: %w", err)
+	}
+
+	bucket, key := storage.SplitURI(r.URI)
+
+	obj, err := s.cdn.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var reqErr awserr.RequestFailure
+		if errors.As(err, &reqErr) && reqErr.StatusCode() == http.StatusNotFound {
+			return 0, fmt.Errorf("%w: \"%s\"", video.ErrRecordingNotFound, r.URI)
+		}
+		return 0, fmt.Errorf("failed to find recording \"%s\": %w", r.URI, err)
+	}
+
+	err = utils.Transact(s.db, func(tx *sqlx.Tx) error {
+		err := tx.GetContext(ctx, &videoID, `
+			INSERT INTO video.items (series_id, name, url, description, tags,
+				status, preset_id, created_at, broadcast_date)
+			VALUES ($1, $2, $3, $4, $5, 'private', $6, $7, $8)
+			RETURNING video_id;`, r.SeriesID, r.Name, r.URLName, r.Description, pq.Array(r.Tags),
+			r.PresetID, time.Now(), r.BroadcastDate)
+		if err != nil {
+			return fmt.Errorf("failed to insert video item: %w", err)
+		}
+
+		// Size is stored in KB
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO video.files (video_id, format_id, uri, status, size, is_source)
+			VALUES ($1, $2, $3, 'private', $4, true);`,
+			videoID, 1, r.URI, aws.Int64Value(obj.ContentLength)/1024) // TODO make an original encode format
+		if err != nil {
+			return fmt.Errorf("failed to insert video file row: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create video from recording: %w", err)
+	}
+
+	if r.PresetID != nil {
+		err = s.enc.RefreshVideo(ctx, videoID)
+		if err != nil {
+			return videoID, fmt.Errorf("failed to refresh video: %w", err)
+		}
+	}
+
+	return videoID, nil
+}
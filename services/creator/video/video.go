@@ -11,14 +11,16 @@ import (
 	"github.com/ystv/web-api/services/creator/types/series"
 	"github.com/ystv/web-api/services/creator/types/video"
 	"github.com/ystv/web-api/services/encoder"
+	"github.com/ystv/web-api/services/search"
 )
 
 // Store encapsulates our dependencies
 type Store struct {
-	db   *sqlx.DB
-	cdn  *s3.S3
-	enc  encoder.Repo
-	conf *creator.Config
+	db     *sqlx.DB
+	cdn    *s3.S3
+	enc    encoder.Repo
+	search search.Repo
+	conf   *creator.Config
 }
 
 func getSeason(t time.Time) string {
@@ -40,6 +42,7 @@ func FileDBToFile(fileDB video.FileDB) video.File {
 	}
 
 	return video.File{
+		FileID:       fileDB.FileID,
 		URI:          fileDB.URI,
 		EncodeFormat: fileDB.EncodeFormat,
 		Status:       fileDB.Status,
@@ -0,0 +1,222 @@
+package creator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/ystv/web-api/services/creator/types/series"
+	"github.com/ystv/web-api/services/creator/types/stats"
+	"github.com/ystv/web-api/services/creator/types/video"
+	"github.com/ystv/web-api/utils"
+)
+
+// maxStatsBuckets stops a range being split into an unreasonable number of buckets
+const maxStatsBuckets = 1000
+
+var (
+	statsIntervals = map[string]time.Duration{
+		"hour":  time.Hour,
+		"day":   24 * time.Hour,
+		"week":  7 * 24 * time.Hour,
+		"month": 28 * 24 * time.Hour,
+	}
+	hitModes = []string{"watch", "embed", "download"}
+)
+
+// VideoStats returns how a video has been watched over a range
+func (m *Store) VideoStats(ctx context.Context, videoID int, r stats.Range) (stats.VideoStats, error) {
+	err := validateRange(r)
+	if err != nil {
+		return stats.VideoStats{}, err
+	}
+
+	s := stats.VideoStats{VideoID: videoID, Range: r}
+
+	err = m.db.GetContext(ctx, &s.Name, `
+		SELECT name
+		FROM video.items
+		WHERE video_id = $1 AND deleted_at IS NULL;`, videoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return stats.VideoStats{}, video.ErrNotFound
+		}
+		return stats.VideoStats{}, fmt.Errorf("failed to get video: %w", err)
+	}
+
+	s.HitStats, err = m.hitStats(ctx, []int{videoID}, r)
+	if err != nil {
+		return stats.VideoStats{}, err
+	}
+
+	return s, nil
+}
+
+// SeriesStats returns how the videos in a series and all the series below it
+// have been watched over a range, along with each video's share
+func (m *Store) SeriesStats(ctx context.Context, seriesID int, r stats.Range) (stats.SeriesStats, error) {
+	err := validateRange(r)
+	if err != nil {
+		return stats.SeriesStats{}, err
+	}
+
+	s := stats.SeriesStats{SeriesID: seriesID, Range: r}
+
+	err = m.db.GetContext(ctx, &s.Name, `
+		SELECT name
+		FROM video.series
+		WHERE series_id = $1 AND deleted_at IS NULL;`, seriesID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return stats.SeriesStats{}, series.ErrNotFound
+		}
+		return stats.SeriesStats{}, fmt.Errorf("failed to get series: %w", err)
+	}
+
+	err = m.db.SelectContext(ctx, &s.Videos, `
+		SELECT item.video_id, item.name, COUNT(hit.hit_id) AS views,
+			COUNT(DISTINCT hit.ip_address::text || ' ' || hit.client_info) AS unique_viewers,
+			COALESCE(AVG(hit.percent) FILTER (WHERE hit.mode <> 'download'), 0) AS average_percent
+		FROM video.series parent
+		INNER JOIN video.series child ON child.lft BETWEEN parent.lft AND parent.rgt
+		INNER JOIN video.items item ON item.series_id = child.series_id
+		LEFT JOIN video.hits hit ON hit.video_id = item.video_id
+			AND hit.start_time >= $2 AND hit.start_time < $3
+		WHERE parent.series_id = $1 AND child.deleted_at IS NULL AND item.deleted_at IS NULL
+		GROUP BY item.video_id
+		ORDER BY views DESC, item.video_id;`, seriesID, r.From, r.To)
+	if err != nil {
+		return stats.SeriesStats{}, fmt.Errorf("failed to get series videos: %w", err)
+	}
+
+	videoIDs := make([]int, 0, len(s.Videos))
+	for _, v := range s.Videos {
+		videoIDs = append(videoIDs, v.VideoID)
+	}
+
+	s.HitStats, err = m.hitStats(ctx, videoIDs, r)
+	if err != nil {
+		return stats.SeriesStats{}, err
+	}
+
+	s.Videos = utils.NonNil(s.Videos)
+
+	return s, nil
+}
+
+// validateRange checks the interval is known and the range is split into a
+// sensible number of buckets
+func validateRange(r stats.Range) error {
+	interval, ok := statsIntervals[r.Interval]
+	if !ok {
+		return fmt.Errorf("%w: interval must be hour, day, week or month", stats.ErrInvalidRange)
+	}
+
+	if !r.From.Before(r.To) {
+		return fmt.Errorf("%w: from must be before to", stats.ErrInvalidRange)
+	}
+
+	if r.To.Sub(r.From)/interval > maxStatsBuckets {
+		return fmt.Errorf("%w: range would be more than %d %ss", stats.ErrInvalidRange, maxStatsBuckets, r.Interval)
+	}
+
+	return nil
+}
+
+// hitStats summarises the hits of a set of videos over a range
+func (m *Store) hitStats(ctx context.Context, videoIDs []int, r stats.Range) (stats.HitStats, error) {
+	var s stats.HitStats
+
+	ids := pq.Array(videoIDs)
+
+	err := m.db.QueryRowxContext(ctx, `
+		SELECT COUNT(*),
+			COUNT(DISTINCT ip_address::text || ' ' || client_info),
+			COALESCE(AVG(percent) FILTER (WHERE mode <> 'download'), 0)
+		FROM video.hits
+		WHERE video_id = ANY($1) AND start_time >= $2 AND start_time < $3;`, ids, r.From, r.To).
+		Scan(&s.Views, &s.UniqueViewers, &s.AveragePercent)
+	if err != nil {
+		return stats.HitStats{}, fmt.Errorf("failed to get hit totals: %w", err)
+	}
+
+	var modes []stats.ModeStats
+
+	err = m.db.SelectContext(ctx, &modes, `
+		SELECT mode, COUNT(*) AS views
+		FROM video.hits
+		WHERE video_id = ANY($1) AND start_time >= $2 AND start_time < $3
+		GROUP BY mode;`, ids, r.From, r.To)
+	if err != nil {
+		return stats.HitStats{}, fmt.Errorf("failed to get hit modes: %w", err)
+	}
+
+	// Every mode is listed, even without views, so the breakdown is always the same shape
+	for _, mode := range hitModes {
+		idx := slices.IndexFunc(modes, func(ms stats.ModeStats) bool { return ms.Mode == mode })
+		if idx == -1 {
+			s.Modes = append(s.Modes, stats.ModeStats{Mode: mode})
+			continue
+		}
+		s.Modes = append(s.Modes, modes[idx])
+	}
+
+	err = m.db.SelectContext(ctx, &s.Timeline, `
+		SELECT bucket.start, COUNT(hit.hit_id) AS views,
+			COUNT(DISTINCT hit.ip_address::text || ' ' || hit.client_info) AS unique_viewers
+		FROM generate_series(date_trunc($4::text, $2::timestamptz), $3::timestamptz,
+			('1 ' || $4::text)::interval) AS bucket(start)
+		LEFT JOIN video.hits hit ON date_trunc($4::text, hit.start_time) = bucket.start
+			AND hit.video_id = ANY($1) AND hit.start_time >= $2 AND hit.start_time < $3
+		WHERE bucket.start < $3
+		GROUP BY bucket.start
+		ORDER BY bucket.start;`, ids, r.From, r.To, r.Interval)
+	if err != nil {
+		return stats.HitStats{}, fmt.Errorf("failed to get hit timeline: %w", err)
+	}
+
+	var retention []struct {
+		Bucket int `db:"bucket"`
+		Views  int `db:"views"`
+	}
+
+	err = m.db.SelectContext(ctx, &retention, `
+		SELECT LEAST(GREATEST(percent, 0) / 10, 9) AS bucket, COUNT(*) AS views
+		FROM video.hits
+		WHERE video_id = ANY($1) AND start_time >= $2 AND start_time < $3 AND mode <> 'download'
+		GROUP BY bucket;`, ids, r.From, r.To)
+	if err != nil {
+		return stats.HitStats{}, fmt.Errorf("failed to get hit retention: %w", err)
+	}
+
+	// Bucketing by tenths, with 100% in the last one
+	s.Retention = make([]stats.RetentionBucket, 10)
+	total := 0
+
+	for i := range s.Retention {
+		s.Retention[i].Percent = i * 10
+	}
+
+	for _, b := range retention {
+		s.Retention[b.Bucket].Views = b.Views
+		total += b.Views
+	}
+
+	// Working down from the end, each bucket retained everyone at or above it
+	if total > 0 {
+		retained := 0
+		for i := len(s.Retention) - 1; i >= 0; i-- {
+			retained += s.Retention[i].Views
+			s.Retention[i].Retained = float64(retained) / float64(total)
+		}
+	}
+
+	s.Timeline = utils.NonNil(s.Timeline)
+
+	return s, nil
+}
@@ -3,7 +3,9 @@ package encoder
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"path/filepath"
@@ -12,6 +14,8 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/ystv/web-api/services/creator/encode"
 )
 
 func (e *Encoder) getVideoFilesAndPreset(ctx context.Context, videoID int) (VideoItem, error) {
@@ -36,10 +40,32 @@ func (e *Encoder) getVideoFilesAndPreset(ctx context.Context, videoID int) (Vide
 	return v, nil
 }
 
-type EncodeResult struct {
-	URI   string
-	JobID string
-}
+type (
+	EncodeResult struct {
+		URI   string
+		JobID string
+	}
+
+	// EncodeTask is the job VT is sent to create an encode
+	EncodeTask struct {
+		SrcURL  string `json:"srcURL"`
+		DstArgs string `json:"dstArgs"`
+		DstURL  string `json:"dstURL"`
+	}
+
+	// EncodePreview is what CreateEncode would send VT, without sending it
+	EncodePreview struct {
+		FileID int        `json:"fileID"`
+		Task   EncodeTask `json:"task"`
+		// DstKey is the key the encode will be written to in the serve bucket
+		DstKey string `json:"dstKey"`
+		// Command is the ffmpeg command the task amounts to
+		Command string `json:"command"`
+		// SourceExists is false when the source object can't be found,
+		// CreateEncode would fail
+		SourceExists bool `json:"sourceExists"`
+	}
+)
 
 // CreateEncode creates an encode item in the message queue.
 func (e *Encoder) CreateEncode(ctx context.Context, file VideoFile, formatID int) (EncodeResult, error) {
@@ -47,53 +73,20 @@ func (e *Encoder) CreateEncode(ctx context.Context, file VideoFile, formatID int
 	// Validate encode format
 	// Send the job to VT
 
-	URI := strings.Split(file.URI, "/")
-	// URI[0] - Bucket
-	bucket := URI[0]
-	// URI[1] - Key (we have this joiner in the scenario there are multiple slashes in the name)
-	key := strings.Join(URI[1:], "")
-	_, err := e.cdn.GetObjectWithContext(ctx, &s3.GetObjectInput{
+	bucket, key := splitURI(file.URI)
+	_, err := e.cdn.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
 		Bucket: aws.String(bucket),
 		Key:    aws.String(key),
 	})
-
 	if err != nil {
 		return EncodeResult{}, fmt.Errorf("failed to get object: %w", err)
 	}
 
-	var format EncodeFormat
-
-	err = e.db.GetContext(ctx, &format, `
-			SELECT arguments, file_suffix
-			FROM video.encode_formats
-			WHERE format_id = $1`, formatID)
+	taskVOD, err := e.encodeTask(ctx, file, formatID)
 	if err != nil {
 		return EncodeResult{}, err
 	}
-	if format.Arguments == "" {
-		return EncodeResult{}, ErrNoArgs
-	}
-	if format.FileSuffix == "" {
-		format.FileSuffix = strconv.Itoa(formatID)
-	}
-
-	// Splitting the URI again, this time on "." so we
-	// can apply the file suffix and then put the file
-	// extension on after it
-
-	extension := filepath.Ext(key)
-	keyWithoutExtension := strings.TrimSuffix(key, extension)
-
-	// Setting the name of the transcoded file
-	dstURL := fmt.Sprintf("%s/%s_%s%s", e.conf.ServeBucket, keyWithoutExtension, format.FileSuffix, extension)
-
-	taskVOD := struct {
-		SrcURL  string `json:"srcURL"`
-		DstArgs string `json:"dstArgs"`
-		DstURL  string `json:"dstURL"`
-	}{SrcURL: file.URI,
-		DstArgs: format.Arguments,
-		DstURL:  dstURL}
+	dstURL := taskVOD.DstURL
 
 	reqJSON, err := json.Marshal(taskVOD)
 	if err != nil {
@@ -128,3 +121,98 @@ func (e *Encoder) CreateEncode(ctx context.Context, file VideoFile, formatID int
 
 	return EncodeResult{URI: dstURL, JobID: task.TaskID}, nil
 }
+
+// PreviewEncode renders the task CreateEncode would send VT to encode a
+// video's source file in a format
+func (e *Encoder) PreviewEncode(ctx context.Context, videoID, formatID int) (EncodePreview, error) {
+	v, err := e.getVideoFilesAndPreset(ctx, videoID)
+	if err != nil {
+		return EncodePreview{}, fmt.Errorf("failed to get video: %w", err)
+	}
+
+	file, err := sourceFile(v)
+	if err != nil {
+		return EncodePreview{}, err
+	}
+
+	task, err := e.encodeTask(ctx, file, formatID)
+	if err != nil {
+		return EncodePreview{}, err
+	}
+
+	args, err := encode.ParseArguments(task.DstArgs)
+	if err != nil {
+		return EncodePreview{}, err
+	}
+
+	command := []string{"ffmpeg", "-i", encode.QuoteArgument(task.SrcURL)}
+	for _, arg := range args {
+		command = append(command, encode.QuoteArgument(arg))
+	}
+	command = append(command, encode.QuoteArgument(task.DstURL))
+
+	bucket, key := splitURI(file.URI)
+	_, err = e.cdn.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+
+	_, dstKey := splitURI(task.DstURL)
+
+	return EncodePreview{
+		FileID:       file.FileID,
+		Task:         task,
+		DstKey:       dstKey,
+		Command:      strings.Join(command, " "),
+		SourceExists: err == nil,
+	}, nil
+}
+
+// encodeTask builds the VT task to encode a file in a format, checking the
+// format's arguments are still safe to run
+func (e *Encoder) encodeTask(ctx context.Context, file VideoFile, formatID int) (EncodeTask, error) {
+	var format EncodeFormat
+
+	err := e.db.GetContext(ctx, &format, `
+			SELECT arguments, file_suffix
+			FROM video.encode_formats
+			WHERE format_id = $1`, formatID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return EncodeTask{}, ErrFormatNotFound
+		}
+		return EncodeTask{}, err
+	}
+	if format.Arguments == "" {
+		return EncodeTask{}, ErrNoArgs
+	}
+	_, err = encode.ParseArguments(format.Arguments)
+	if err != nil {
+		return EncodeTask{}, err
+	}
+	if format.FileSuffix == "" {
+		format.FileSuffix = strconv.Itoa(formatID)
+	}
+
+	_, key := splitURI(file.URI)
+
+	// Splitting the URI again, this time on "." so we
+	// can apply the file suffix and then put the file
+	// extension on after it
+
+	extension := filepath.Ext(key)
+	keyWithoutExtension := strings.TrimSuffix(key, extension)
+
+	// Setting the name of the transcoded file
+	return EncodeTask{
+		SrcURL:  file.URI,
+		DstArgs: format.Arguments,
+		DstURL:  fmt.Sprintf("%s/%s_%s%s", e.conf.ServeBucket, keyWithoutExtension, format.FileSuffix, extension),
+	}, nil
+}
+
+// splitURI splits a file URI into its bucket and key, the key can have slashes in it
+func splitURI(uri string) (bucket, key string) {
+	bucket, key, _ = strings.Cut(uri, "/")
+	return bucket, key
+}
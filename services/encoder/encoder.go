@@ -18,6 +18,7 @@ var (
 	ErrTooManySourceFiles     = errors.New("too many source files set")
 	ErrNoFormats              = errors.New("preset has no formats set")
 	ErrNoArgs                 = errors.New("no encoding arguments set")
+	ErrFormatNotFound         = errors.New("encode format not found")
 	ErrVTFailedToCreate       = errors.New("vt failed to create encode job")
 	ErrVTFailedToAuthenticate = errors.New("failed to authenticate to vt")
 	ErrVTUnknownResponse      = errors.New("unknown vt response")
@@ -27,6 +28,7 @@ var (
 type (
 	Repo interface {
 		CreateEncode(ctx context.Context, file VideoFile, formatID int) (EncodeResult, error)
+		PreviewEncode(ctx context.Context, videoID, formatID int) (EncodePreview, error)
 		RefreshVideo(ctx context.Context, videoID int) error
 		Refresh(_ context.Context) error
 		TranscodeFinished(ctx context.Context, taskID string) error
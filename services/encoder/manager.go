@@ -22,25 +22,9 @@ func (e *Encoder) RefreshVideo(ctx context.Context, videoID int) error {
 	if err != nil {
 		return fmt.Errorf("failed to get video: %w", err)
 	}
-	if len(v.Files) == 0 {
-		return ErrNoVideoFiles
-	}
-	// We are keeping track of the number of source files since we are ensuring that each
-	// video only has one source file.
-	// If there is more than one, it returns an error
-	numOfSrcFiles := 0
-	srcFileIdx := 0
-	for i, file := range v.Files {
-		if file.IsSource {
-			numOfSrcFiles++
-			srcFileIdx = i
-		}
-	}
-	if numOfSrcFiles < 1 {
-		return ErrNoSourceFile
-	}
-	if numOfSrcFiles > 1 {
-		return ErrTooManySourceFiles
+	src, err := sourceFile(v)
+	if err != nil {
+		return err
 	}
 
 	if v.PresetID == nil {
@@ -54,9 +38,9 @@ func (e *Encoder) RefreshVideo(ctx context.Context, videoID int) error {
 		return ErrNoFormats
 	}
 	for _, format := range p.Formats {
-		res, err := e.CreateEncode(ctx, v.Files[srcFileIdx], format.FormatID)
+		res, err := e.CreateEncode(ctx, src, format.FormatID)
 		if err != nil {
-			return fmt.Errorf("failed to create encode fileID=%d format=%d : %w", v.Files[srcFileIdx].FileID, format.FormatID, err)
+			return fmt.Errorf("failed to create encode fileID=%d format=%d : %w", src.FileID, format.FormatID, err)
 		}
 		_, err = e.db.ExecContext(ctx, `
 		INSERT INTO video.files(video_id, format_id, uri, status)
@@ -69,6 +53,32 @@ func (e *Encoder) RefreshVideo(ctx context.Context, videoID int) error {
 	return nil
 }
 
+// sourceFile finds the file a video's encodes are made from
+func sourceFile(v VideoItem) (VideoFile, error) {
+	if len(v.Files) == 0 {
+		return VideoFile{}, ErrNoVideoFiles
+	}
+	// We are keeping track of the number of source files since we are ensuring that each
+	// video only has one source file.
+	// If there is more than one, it returns an error
+	numOfSrcFiles := 0
+	srcFileIdx := 0
+	for i, file := range v.Files {
+		if file.IsSource {
+			numOfSrcFiles++
+			srcFileIdx = i
+		}
+	}
+	if numOfSrcFiles < 1 {
+		return VideoFile{}, ErrNoSourceFile
+	}
+	if numOfSrcFiles > 1 {
+		return VideoFile{}, ErrTooManySourceFiles
+	}
+
+	return v.Files[srcFileIdx], nil
+}
+
 // Refresh will check all existing video items to ensure that they
 // match their preset, creating a new job
 func (e *Encoder) Refresh(_ context.Context) error {
@@ -2,8 +2,12 @@ package misc
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+
+	"github.com/ystv/web-api/utils"
 )
 
 type (
@@ -11,11 +15,13 @@ type (
 		QuoteRepo
 		WebcamRepo
 		ListRepo
+		RedirectRepo
+		ShortLinkRepo
 	}
 
 	// QuoteRepo defines all quote interactions
 	QuoteRepo interface {
-		ListQuotes(ctx context.Context, amount, page int) (QuotePage, error)
+		ListQuotes(ctx context.Context, cursor *utils.Cursor, size int) (utils.CursorPage[Quote], error)
 		NewQuote(ctx context.Context, q Quote) error
 		UpdateQuote(ctx context.Context, q Quote) error
 		DeleteQuote(ctx context.Context, quoteID int) error
@@ -40,13 +46,35 @@ type (
 		UnsubscribeByUUID(ctx context.Context, uuid string) error
 	}
 
+	// RedirectRepo represents all redirect interactions
+	RedirectRepo interface {
+		ListRedirects(ctx context.Context, unusedSince *time.Time) ([]Redirect, error)
+		GetRedirect(ctx context.Context, redirectID int) (Redirect, error)
+		NewRedirect(ctx context.Context, r Redirect) (int, error)
+		UpdateRedirect(ctx context.Context, r Redirect) error
+		DeleteRedirect(ctx context.Context, redirectID int) error
+		ResolveRedirect(ctx context.Context, path string) (ResolvedRedirect, error)
+	}
+
+	// ShortLinkRepo represents all short link interactions
+	ShortLinkRepo interface {
+		ListShortLinks(ctx context.Context, createdBy *int) ([]ShortLink, error)
+		GetShortLink(ctx context.Context, linkID int) (ShortLink, error)
+		NewShortLink(ctx context.Context, l ShortLink) (ShortLink, error)
+		DeleteShortLink(ctx context.Context, linkID int) error
+		ResolveShortLink(ctx context.Context, code, referrer string) (string, error)
+		GetShortLinkAnalytics(ctx context.Context, linkID int, since time.Time) (ShortLinkAnalytics, error)
+	}
+
 	// Store contains our dependency
 	Store struct {
 		db *sqlx.DB
+		// siteURL is the public website, short links are under it
+		siteURL string
 	}
 )
 
 // NewStore creates a new store
-func NewStore(db *sqlx.DB) Repos {
-	return &Store{db: db}
+func NewStore(db *sqlx.DB, siteURL string) Repos {
+	return &Store{db: db, siteURL: strings.TrimSuffix(siteURL, "/")}
 }
@@ -2,35 +2,46 @@ package misc
 
 import (
 	"context"
+	"fmt"
 	"time"
-)
 
-type (
-	// Quote is an individual quote
-	Quote struct {
-		QuoteID     int    `db:"quote_id" json:"id"`
-		Quote       string `db:"quote" json:"quote"`
-		Description string `db:"description" json:"description"`
-		CreatedBy   int    `db:"created_by" json:"createdBy"`
-	}
-	// QuotePage is a group of quotes including the last page index
-	QuotePage struct {
-		Quotes        []Quote
-		LastPageIndex int
-	}
+	"github.com/ystv/web-api/utils"
 )
 
-// ListQuotes returns a section of quotes
-func (m *Store) ListQuotes(ctx context.Context, amount, page int) (QuotePage, error) {
-	q := QuotePage{LastPageIndex: 20}
+// Quote is an individual quote
+type Quote struct {
+	QuoteID     int       `db:"quote_id" json:"id"`
+	Quote       string    `db:"quote" json:"quote"`
+	Description string    `db:"description" json:"description"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+	CreatedBy   int       `db:"created_by" json:"createdBy"`
+}
+
+// ListQuotes returns a page of quotes, newest first
+func (m *Store) ListQuotes(ctx context.Context, cursor *utils.Cursor, size int) (utils.CursorPage[Quote], error) {
+	var q []Quote
 
-	err := m.db.SelectContext(ctx, &q.Quotes,
-		`SELECT quote_id, quote, description, created_by
-		FROM misc.quotes
-		ORDER BY created_at DESC
-		OFFSET $1 LIMIT $2;`, amount, page)
+	where, order := utils.Keyset(cursor, "created_at", "quote_id", true)
+
+	builder := utils.PSQL().Select("quote_id", "quote", "description", "created_at", "created_by").
+		From("misc.quotes").
+		Where(where).
+		OrderBy(order...).
+		Limit(uint64(size + 1))
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		panic(fmt.Errorf("failed to build sql for ListQuotes: %w", err))
+	}
+
+	err = m.db.SelectContext(ctx, &q, sql, args...)
+	if err != nil {
+		return utils.CursorPage[Quote]{}, err
+	}
 
-	return q, err
+	return utils.NewCursorPage(q, size, cursor, func(quote Quote) (string, int) {
+		return utils.TimeKey(quote.CreatedAt), quote.QuoteID
+	}), nil
 }
 
 // NewQuote creates a new quote
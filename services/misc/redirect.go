@@ -0,0 +1,357 @@
+package misc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/ystv/web-api/utils"
+)
+
+type (
+	// Redirect sends an old URL path to a new one on the site, or an external URL
+	Redirect struct {
+		RedirectID int `db:"redirect_id" json:"id"`
+		// SourceURL is the path being redirected, without leading or trailing slashes.
+		// Wildcard rules use * to match anything.
+		SourceURL string `db:"source_url" json:"sourceURL"`
+		// DestinationURL is a site path, or a full URL when External.
+		// Wildcard rules can use $1, $2... for whatever each * matched.
+		DestinationURL string    `db:"destination_url" json:"destinationURL"`
+		External       bool      `db:"external" json:"external"`
+		MatchType      string    `db:"match_type" json:"matchType"`
+		Hits           int64     `db:"hits" json:"hits"`
+		LastUsedAt     null.Time `db:"last_used_at" json:"lastUsedAt"`
+		CreatedAt      time.Time `db:"created_at" json:"createdAt"`
+		CreatedBy      null.Int  `db:"created_by" json:"createdBy"`
+	}
+
+	// ResolvedRedirect is where a path should be redirected to
+	ResolvedRedirect struct {
+		RedirectID int `json:"id"`
+		// Target is the destination with any prefix remainder or wildcards filled in
+		Target   string `json:"target"`
+		External bool   `json:"external"`
+	}
+)
+
+const (
+	RedirectExact    = "exact"
+	RedirectPrefix   = "prefix"
+	RedirectWildcard = "wildcard"
+)
+
+var (
+	ErrRedirectNotFound = errors.New("redirect not found")
+	ErrRedirectConflict = errors.New("a redirect already exists for this source")
+	ErrRedirectInvalid  = errors.New("invalid redirect")
+
+	wildcardReference = regexp.MustCompile(`\$(\d+)`)
+
+	// wildcardRules is shared by every Store, so changes made through the admin
+	// store are seen by the public one straight away
+	wildcardRules = &wildcardCache{}
+)
+
+// wildcardCacheTTL is how long the wildcard rules are kept before being
+// reloaded, to pick up changes made by other instances
+const wildcardCacheTTL = time.Minute
+
+type (
+	// wildcardRule is a wildcard redirect with its compiled source
+	wildcardRule struct {
+		Redirect
+		pattern *regexp.Regexp
+	}
+
+	// wildcardCache keeps the wildcard redirects compiled, as every path that
+	// doesn't match an exact or prefix rule is checked against all of them
+	wildcardCache struct {
+		mu       sync.Mutex
+		rules    []wildcardRule
+		loadedAt time.Time
+	}
+)
+
+// get returns the wildcard rules longest source first, loading them if they
+// haven't been or have expired
+func (c *wildcardCache) get(ctx context.Context, db *sqlx.DB) ([]wildcardRule, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.loadedAt.IsZero() && time.Since(c.loadedAt) < wildcardCacheTTL {
+		return c.rules, nil
+	}
+
+	var wildcards []Redirect
+
+	err := db.SelectContext(ctx, &wildcards, `
+		SELECT redirect_id, source_url, destination_url, external, match_type
+		FROM misc.redirects
+		WHERE match_type = 'wildcard'
+		ORDER BY length(source_url) DESC, redirect_id;`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wildcard redirects: %w", err)
+	}
+
+	rules := make([]wildcardRule, 0, len(wildcards))
+	for _, w := range wildcards {
+		rules = append(rules, wildcardRule{Redirect: w, pattern: wildcardPattern(w.SourceURL)})
+	}
+
+	c.rules, c.loadedAt = rules, time.Now()
+
+	return c.rules, nil
+}
+
+// invalidate drops the rules so they're loaded again on the next lookup
+func (c *wildcardCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.rules, c.loadedAt = nil, time.Time{}
+}
+
+// NormaliseRedirectPath trims the slashes redirect sources are stored without
+func NormaliseRedirectPath(path string) string {
+	return strings.Trim(strings.TrimSpace(path), "/")
+}
+
+// ListRedirects returns all redirects, when unusedSince is set only redirects that
+// haven't been used since then are returned, least recently used first
+func (m *Store) ListRedirects(ctx context.Context, unusedSince *time.Time) ([]Redirect, error) {
+	var r []Redirect
+
+	builder := utils.PSQL().Select("redirect_id", "source_url", "destination_url", "external", "match_type",
+		"hits", "last_used_at", "created_at", "created_by").
+		From("misc.redirects")
+
+	if unusedSince != nil {
+		builder = builder.Where("COALESCE(last_used_at, created_at) < ?", *unusedSince).
+			OrderBy("COALESCE(last_used_at, created_at)", "redirect_id")
+	} else {
+		builder = builder.OrderBy("source_url")
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		panic(fmt.Errorf("failed to build sql for ListRedirects: %w", err))
+	}
+
+	err = m.db.SelectContext(ctx, &r, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list redirects: %w", err)
+	}
+
+	return utils.NonNil(r), nil
+}
+
+// GetRedirect returns a redirect by ID
+func (m *Store) GetRedirect(ctx context.Context, redirectID int) (Redirect, error) {
+	var r Redirect
+
+	err := m.db.GetContext(ctx, &r, `
+		SELECT redirect_id, source_url, destination_url, external, match_type, hits, last_used_at,
+			created_at, created_by
+		FROM misc.redirects
+		WHERE redirect_id = $1;`, redirectID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Redirect{}, ErrRedirectNotFound
+		}
+		return Redirect{}, fmt.Errorf("failed to get redirect: %w", err)
+	}
+
+	return r, nil
+}
+
+// NewRedirect creates a redirect, returning its ID
+func (m *Store) NewRedirect(ctx context.Context, r Redirect) (int, error) {
+	err := validateRedirect(&r)
+	if err != nil {
+		return 0, err
+	}
+
+	var redirectID int
+
+	err = m.db.GetContext(ctx, &redirectID, `
+		INSERT INTO misc.redirects(source_url, destination_url, external, match_type, created_at, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING redirect_id;`, r.SourceURL, r.DestinationURL, r.External, r.MatchType, time.Now(), r.CreatedBy)
+	if err != nil {
+		return 0, redirectWriteError(err)
+	}
+
+	wildcardRules.invalidate()
+
+	return redirectID, nil
+}
+
+// UpdateRedirect updates the rule of a redirect, keeping its usage
+func (m *Store) UpdateRedirect(ctx context.Context, r Redirect) error {
+	err := validateRedirect(&r)
+	if err != nil {
+		return err
+	}
+
+	res, err := m.db.ExecContext(ctx, `
+		UPDATE misc.redirects
+		SET source_url = $1, destination_url = $2, external = $3, match_type = $4
+		WHERE redirect_id = $5;`, r.SourceURL, r.DestinationURL, r.External, r.MatchType, r.RedirectID)
+	if err != nil {
+		return redirectWriteError(err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return ErrRedirectNotFound
+	}
+
+	wildcardRules.invalidate()
+
+	return nil
+}
+
+// DeleteRedirect deletes a redirect
+func (m *Store) DeleteRedirect(ctx context.Context, redirectID int) error {
+	_, err := m.db.ExecContext(ctx, `DELETE FROM misc.redirects WHERE redirect_id = $1;`, redirectID)
+	if err != nil {
+		return fmt.Errorf("failed to delete redirect: %w", err)
+	}
+
+	wildcardRules.invalidate()
+
+	return nil
+}
+
+// ResolveRedirect finds the redirect for a path and records that it was used.
+// Exact rules are checked first, then the longest matching prefix, then the
+// longest matching wildcard.
+func (m *Store) ResolveRedirect(ctx context.Context, path string) (ResolvedRedirect, error) {
+	path = NormaliseRedirectPath(path)
+
+	resolved, err := m.resolveRedirect(ctx, path)
+	if err != nil {
+		return ResolvedRedirect{}, err
+	}
+
+	_, err = m.db.ExecContext(ctx, `
+		UPDATE misc.redirects
+		SET hits = hits + 1, last_used_at = NOW()
+		WHERE redirect_id = $1;`, resolved.RedirectID)
+	if err != nil {
+		return ResolvedRedirect{}, fmt.Errorf("failed to record redirect hit: %w", err)
+	}
+
+	return resolved, nil
+}
+
+func (m *Store) resolveRedirect(ctx context.Context, path string) (ResolvedRedirect, error) {
+	var r Redirect
+
+	err := m.db.GetContext(ctx, &r, `
+		SELECT redirect_id, source_url, destination_url, external, match_type
+		FROM misc.redirects
+		WHERE (match_type = 'exact' AND source_url = $1)
+		OR (match_type = 'prefix' AND ($1 = source_url OR left($1, length(source_url) + 1) = source_url || '/'))
+		ORDER BY match_type = 'exact' DESC, length(source_url) DESC
+		LIMIT 1;`, path)
+	if err == nil {
+		target := r.DestinationURL
+		if r.MatchType == RedirectPrefix {
+			target = strings.TrimSuffix(target, "/") + strings.TrimPrefix(path, r.SourceURL)
+		}
+		return ResolvedRedirect{RedirectID: r.RedirectID, Target: target, External: r.External}, nil
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return ResolvedRedirect{}, fmt.Errorf("failed to resolve redirect: %w", err)
+	}
+
+	wildcards, err := wildcardRules.get(ctx, m.db)
+	if err != nil {
+		return ResolvedRedirect{}, err
+	}
+
+	for _, w := range wildcards {
+		matches := w.pattern.FindStringSubmatch(path)
+		if matches == nil {
+			continue
+		}
+
+		target := wildcardReference.ReplaceAllStringFunc(w.DestinationURL, func(ref string) string {
+			i, _ := strconv.Atoi(ref[1:])
+			if i < 1 || i >= len(matches) {
+				return ""
+			}
+			return matches[i]
+		})
+
+		return ResolvedRedirect{RedirectID: w.RedirectID, Target: target, External: w.External}, nil
+	}
+
+	return ResolvedRedirect{}, ErrRedirectNotFound
+}
+
+// wildcardPattern converts a wildcard source to a regular expression, with each * a capture group
+func wildcardPattern(source string) *regexp.Regexp {
+	parts := strings.Split(source, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+
+	return regexp.MustCompile("^" + strings.Join(parts, "(.*)") + "$")
+}
+
+func validateRedirect(r *Redirect) error {
+	r.SourceURL = NormaliseRedirectPath(r.SourceURL)
+	r.DestinationURL = strings.TrimSpace(r.DestinationURL)
+
+	if r.MatchType == "" {
+		r.MatchType = RedirectExact
+	}
+
+	switch {
+	case r.SourceURL == "":
+		return fmt.Errorf("%w: source is required", ErrRedirectInvalid)
+	case r.DestinationURL == "":
+		return fmt.Errorf("%w: destination is required", ErrRedirectInvalid)
+	case r.MatchType != RedirectExact && r.MatchType != RedirectPrefix && r.MatchType != RedirectWildcard:
+		return fmt.Errorf("%w: match type must be exact, prefix or wildcard", ErrRedirectInvalid)
+	case r.MatchType == RedirectWildcard && !strings.Contains(r.SourceURL, "*"):
+		return fmt.Errorf("%w: wildcard source must contain *", ErrRedirectInvalid)
+	case r.External && !strings.HasPrefix(r.DestinationURL, "https://") && !strings.HasPrefix(r.DestinationURL, "http://"):
+		return fmt.Errorf("%w: external destination must be a full URL", ErrRedirectInvalid)
+	case !r.External && NormaliseRedirectPath(r.DestinationURL) == r.SourceURL:
+		return fmt.Errorf("%w: redirect would loop", ErrRedirectInvalid)
+	}
+
+	if !r.External {
+		r.DestinationURL = NormaliseRedirectPath(r.DestinationURL)
+	}
+
+	return nil
+}
+
+func redirectWriteError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" {
+		return ErrRedirectConflict
+	}
+
+	return fmt.Errorf("failed to write redirect: %w", err)
+}
@@ -0,0 +1,374 @@
+package misc
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/ystv/web-api/utils"
+)
+
+type (
+	// ShortLink is a short URL, <site>/s/<code>, to a page on the site or an external URL
+	ShortLink struct {
+		LinkID int `db:"link_id" json:"id"`
+		// Code is a vanity code, or generated when left empty
+		Code     string `db:"code" json:"code"`
+		ShortURL string `db:"-" json:"shortURL"`
+		// TargetType is video, series, playlist, channel or url
+		TargetType string `db:"target_type" json:"targetType"`
+		// TargetID is the ID of the video, series, playlist or channel
+		TargetID null.Int `db:"target_id" json:"targetID"`
+		// TargetURL is the URL when the target type is url
+		TargetURL null.String `db:"target_url" json:"targetURL"`
+		ExpiresAt null.Time   `db:"expires_at" json:"expiresAt"`
+		CreatedAt time.Time   `db:"created_at" json:"createdAt"`
+		CreatedBy null.Int    `db:"created_by" json:"createdBy"`
+		// Clicks is the total number of times the link has been followed
+		Clicks int64 `db:"clicks" json:"clicks"`
+	}
+
+	// ShortLinkAnalytics is the breakdown of clicks on a short link
+	ShortLinkAnalytics struct {
+		LinkID    int                 `json:"id"`
+		Since     time.Time           `json:"since"`
+		Total     int64               `json:"total"`
+		Days      []ShortLinkDay      `json:"days"`
+		Referrers []ShortLinkReferrer `json:"referrers"`
+	}
+
+	// ShortLinkDay is the clicks on a short link on a day
+	ShortLinkDay struct {
+		Day    time.Time `db:"day" json:"day"`
+		Clicks int64     `db:"clicks" json:"clicks"`
+	}
+
+	// ShortLinkReferrer is the clicks on a short link from a site, an empty
+	// referrer is direct traffic, such as a QR code on a poster
+	ShortLinkReferrer struct {
+		Referrer string `db:"referrer" json:"referrer"`
+		Clicks   int64  `db:"clicks" json:"clicks"`
+	}
+)
+
+const (
+	ShortLinkVideo    = "video"
+	ShortLinkSeries   = "series"
+	ShortLinkPlaylist = "playlist"
+	ShortLinkChannel  = "channel"
+	ShortLinkURL      = "url"
+
+	// generatedCodeLength gives 36^6, over 2 billion codes
+	generatedCodeLength = 6
+	codeAlphabet        = "abcdefghijklmnopqrstuvwxyz0123456789"
+	// codeAttempts is how many generated codes are tried before giving up
+	codeAttempts = 5
+)
+
+var (
+	ErrShortLinkNotFound = errors.New("short link not found")
+	ErrShortLinkExpired  = errors.New("short link has expired")
+	ErrShortLinkConflict = errors.New("short link code is already in use")
+	ErrShortLinkInvalid  = errors.New("invalid short link")
+
+	shortLinkCode = regexp.MustCompile(`^[a-z0-9-]{3,32}$`)
+)
+
+const shortLinkColumns = `link.link_id, link.code, link.target_type, link.target_id, link.target_url,
+	link.expires_at, link.created_at, link.created_by,
+	COALESCE((SELECT SUM(click.clicks) FROM misc.short_link_clicks click WHERE click.link_id = link.link_id), 0) AS clicks`
+
+// ListShortLinks returns short links newest first, when createdBy is set only that user's links are returned
+func (m *Store) ListShortLinks(ctx context.Context, createdBy *int) ([]ShortLink, error) {
+	var l []ShortLink
+
+	builder := utils.PSQL().Select(shortLinkColumns).
+		From("misc.short_links link").
+		OrderBy("link.created_at DESC", "link.link_id DESC")
+
+	if createdBy != nil {
+		builder = builder.Where("link.created_by = ?", *createdBy)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		panic(fmt.Errorf("failed to build sql for ListShortLinks: %w", err))
+	}
+
+	err = m.db.SelectContext(ctx, &l, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list short links: %w", err)
+	}
+
+	for i := range l {
+		l[i].ShortURL = m.shortURL(l[i].Code)
+	}
+
+	return utils.NonNil(l), nil
+}
+
+// GetShortLink returns a short link by ID
+func (m *Store) GetShortLink(ctx context.Context, linkID int) (ShortLink, error) {
+	var l ShortLink
+
+	err := m.db.GetContext(ctx, &l, `SELECT `+shortLinkColumns+`
+		FROM misc.short_links link
+		WHERE link.link_id = $1;`, linkID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ShortLink{}, ErrShortLinkNotFound
+		}
+		return ShortLink{}, fmt.Errorf("failed to get short link: %w", err)
+	}
+
+	l.ShortURL = m.shortURL(l.Code)
+
+	return l, nil
+}
+
+// NewShortLink creates a short link, generating a code if a vanity code isn't given
+func (m *Store) NewShortLink(ctx context.Context, l ShortLink) (ShortLink, error) {
+	err := m.validateShortLink(ctx, &l)
+	if err != nil {
+		return ShortLink{}, err
+	}
+
+	vanity := l.Code != ""
+
+	for attempt := 0; attempt < codeAttempts; attempt++ {
+		if !vanity {
+			l.Code, err = generateCode()
+			if err != nil {
+				return ShortLink{}, err
+			}
+		}
+
+		err = m.db.GetContext(ctx, &l.LinkID, `
+			INSERT INTO misc.short_links(code, target_type, target_id, target_url, expires_at, created_at, created_by)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING link_id;`, l.Code, l.TargetType, l.TargetID, l.TargetURL, l.ExpiresAt, time.Now(), l.CreatedBy)
+		if err == nil {
+			return m.GetShortLink(ctx, l.LinkID)
+		}
+
+		var pqErr *pq.Error
+		if !errors.As(err, &pqErr) || pqErr.Code.Name() != "unique_violation" {
+			return ShortLink{}, fmt.Errorf("failed to insert short link: %w", err)
+		}
+
+		if vanity {
+			return ShortLink{}, ErrShortLinkConflict
+		}
+	}
+
+	return ShortLink{}, fmt.Errorf("failed to generate a unique code after %d attempts", codeAttempts)
+}
+
+// DeleteShortLink deletes a short link and its analytics
+func (m *Store) DeleteShortLink(ctx context.Context, linkID int) error {
+	_, err := m.db.ExecContext(ctx, `DELETE FROM misc.short_links WHERE link_id = $1;`, linkID)
+	if err != nil {
+		return fmt.Errorf("failed to delete short link: %w", err)
+	}
+
+	return nil
+}
+
+// ResolveShortLink returns the URL a short link points to and records the click
+// against today and the referrer's host
+func (m *Store) ResolveShortLink(ctx context.Context, code, referrer string) (string, error) {
+	var l ShortLink
+
+	err := m.db.GetContext(ctx, &l, `
+		SELECT link_id, code, target_type, target_id, target_url, expires_at
+		FROM misc.short_links
+		WHERE code = $1;`, strings.ToLower(code))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrShortLinkNotFound
+		}
+		return "", fmt.Errorf("failed to get short link: %w", err)
+	}
+
+	if l.ExpiresAt.Valid && l.ExpiresAt.Time.Before(time.Now()) {
+		return "", ErrShortLinkExpired
+	}
+
+	target, err := m.shortLinkTarget(ctx, l)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = m.db.ExecContext(ctx, `
+		INSERT INTO misc.short_link_clicks(link_id, day, referrer, clicks)
+		VALUES ($1, CURRENT_DATE, $2, 1)
+		ON CONFLICT ON CONSTRAINT short_link_clicks_pkey DO UPDATE
+		SET clicks = short_link_clicks.clicks + 1;`, l.LinkID, referrerHost(referrer))
+	if err != nil {
+		return "", fmt.Errorf("failed to record short link click: %w", err)
+	}
+
+	return target, nil
+}
+
+// GetShortLinkAnalytics returns the clicks on a short link since a date, by day and by referrer
+func (m *Store) GetShortLinkAnalytics(ctx context.Context, linkID int, since time.Time) (ShortLinkAnalytics, error) {
+	a := ShortLinkAnalytics{LinkID: linkID, Since: since}
+
+	err := m.db.SelectContext(ctx, &a.Days, `
+		SELECT day, SUM(clicks) AS clicks
+		FROM misc.short_link_clicks
+		WHERE link_id = $1 AND day >= $2
+		GROUP BY day
+		ORDER BY day;`, linkID, since)
+	if err != nil {
+		return ShortLinkAnalytics{}, fmt.Errorf("failed to get short link clicks by day: %w", err)
+	}
+
+	err = m.db.SelectContext(ctx, &a.Referrers, `
+		SELECT referrer, SUM(clicks) AS clicks
+		FROM misc.short_link_clicks
+		WHERE link_id = $1 AND day >= $2
+		GROUP BY referrer
+		ORDER BY clicks DESC, referrer;`, linkID, since)
+	if err != nil {
+		return ShortLinkAnalytics{}, fmt.Errorf("failed to get short link clicks by referrer: %w", err)
+	}
+
+	for _, d := range a.Days {
+		a.Total += d.Clicks
+	}
+
+	a.Days = utils.NonNil(a.Days)
+	a.Referrers = utils.NonNil(a.Referrers)
+
+	return a, nil
+}
+
+// shortLinkTarget builds the URL of a short link's target
+func (m *Store) shortLinkTarget(ctx context.Context, l ShortLink) (string, error) {
+	switch l.TargetType {
+	case ShortLinkVideo:
+		return fmt.Sprintf("%s/watch/video/%d", m.siteURL, l.TargetID.Int64), nil
+	case ShortLinkSeries:
+		return fmt.Sprintf("%s/watch/series/%d", m.siteURL, l.TargetID.Int64), nil
+	case ShortLinkPlaylist:
+		return fmt.Sprintf("%s/watch/playlist/%d", m.siteURL, l.TargetID.Int64), nil
+	case ShortLinkChannel:
+		var urlName string
+
+		err := m.db.GetContext(ctx, &urlName, `
+			SELECT url_name FROM playout.channel WHERE channel_id = $1;`, l.TargetID.Int64)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return "", ErrShortLinkNotFound
+			}
+			return "", fmt.Errorf("failed to get channel: %w", err)
+		}
+
+		return m.siteURL + "/live/" + url.PathEscape(urlName), nil
+	case ShortLinkURL:
+		return l.TargetURL.String, nil
+	}
+
+	return "", fmt.Errorf("unknown short link target type %q", l.TargetType)
+}
+
+func (m *Store) validateShortLink(ctx context.Context, l *ShortLink) error {
+	l.Code = strings.ToLower(strings.TrimSpace(l.Code))
+
+	if l.Code != "" && !shortLinkCode.MatchString(l.Code) {
+		return fmt.Errorf("%w: code must be 3 to 32 letters, numbers or dashes", ErrShortLinkInvalid)
+	}
+
+	if l.ExpiresAt.Valid && l.ExpiresAt.Time.Before(time.Now()) {
+		return fmt.Errorf("%w: expiry is in the past", ErrShortLinkInvalid)
+	}
+
+	if l.TargetType == ShortLinkURL {
+		u, err := url.Parse(strings.TrimSpace(l.TargetURL.String))
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("%w: target url must be a full http or https URL", ErrShortLinkInvalid)
+		}
+
+		l.TargetURL = null.StringFrom(u.String())
+		l.TargetID = null.Int{}
+
+		return nil
+	}
+
+	var table, column string
+
+	switch l.TargetType {
+	case ShortLinkVideo:
+		table, column = "video.items", "video_id"
+	case ShortLinkSeries:
+		table, column = "video.series", "series_id"
+	case ShortLinkPlaylist:
+		table, column = "video.playlists", "playlist_id"
+	case ShortLinkChannel:
+		table, column = "playout.channel", "channel_id"
+	default:
+		return fmt.Errorf("%w: target type must be video, series, playlist, channel or url", ErrShortLinkInvalid)
+	}
+
+	if !l.TargetID.Valid {
+		return fmt.Errorf("%w: target ID is required", ErrShortLinkInvalid)
+	}
+
+	var exists bool
+
+	err := m.db.GetContext(ctx, &exists,
+		fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM %s WHERE %s = $1);`, table, column), l.TargetID.Int64)
+	if err != nil {
+		return fmt.Errorf("failed to check short link target: %w", err)
+	}
+
+	if !exists {
+		return fmt.Errorf("%w: %s %d doesn't exist", ErrShortLinkInvalid, l.TargetType, l.TargetID.Int64)
+	}
+
+	l.TargetURL = null.String{}
+
+	return nil
+}
+
+// generateCode returns a random code from the code alphabet
+func generateCode() (string, error) {
+	code := make([]byte, generatedCodeLength)
+	alphabetSize := big.NewInt(int64(len(codeAlphabet)))
+
+	for i := range code {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate code: %w", err)
+		}
+		code[i] = codeAlphabet[n.Int64()]
+	}
+
+	return string(code), nil
+}
+
+// referrerHost reduces a referrer to its host, so the day buckets don't grow per page
+func referrerHost(referrer string) string {
+	u, err := url.Parse(referrer)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+func (m *Store) shortURL(code string) string {
+	return m.siteURL + "/s/" + code
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/ystv/web-api/services/misc"
 )
 
 type (
@@ -18,10 +20,11 @@ type (
 		Name     string `db:"name" json:"name"`
 		SeriesID int    `db:"series_id" json:"-"` // Here since needed
 	}
-	// BreadcrumbItem is either a video or a series
+	// BreadcrumbItem is either a video, a series or a redirect to elsewhere
 	BreadcrumbItem struct {
-		Video  *VideoItem `json:"video,omitempty"`
-		Series *Series    `json:"series,omitempty"`
+		Video    *VideoItem             `json:"video,omitempty"`
+		Series   *Series                `json:"series,omitempty"`
+		Redirect *misc.ResolvedRedirect `json:"redirect,omitempty"`
 	}
 )
 
@@ -86,6 +89,18 @@ func (s *Store) GetSeriesBreadcrumb(ctx context.Context, seriesID int) ([]Breadc
 // Find returns either a series or video for a given path
 // TODO be consistent with creator's find in terms of variables
 func (s *Store) Find(ctx context.Context, path string) (BreadcrumbItem, error) {
+	// Redirects take priority, so old links can be moved even if they still resolve
+	redirect, err := s.redirects.ResolveRedirect(ctx, path)
+	switch {
+	case err == nil:
+		if !redirect.External {
+			redirect.Target = s.siteURL + "/" + redirect.Target
+		}
+		return BreadcrumbItem{Redirect: &redirect}, nil
+	case !errors.Is(err, misc.ErrRedirectNotFound):
+		return BreadcrumbItem{}, fmt.Errorf("failed to resolve redirect: %w", err)
+	}
+
 	// Check to see if it's just a video ID
 	videoID, err := strconv.Atoi(path)
 	if err == nil {
@@ -129,7 +144,7 @@ func (s *Store) Find(ctx context.Context, path string) (BreadcrumbItem, error) {
 					if err != nil {
 						return BreadcrumbItem{}, fmt.Errorf("failed to get video: %w", err)
 					}
-					return BreadcrumbItem{foundVideo, nil}, nil
+					return BreadcrumbItem{Video: foundVideo}, nil
 				}
 			}
 		} else {
@@ -138,5 +153,5 @@ func (s *Store) Find(ctx context.Context, path string) (BreadcrumbItem, error) {
 	}
 
 	// Found series
-	return BreadcrumbItem{nil, &series}, nil
+	return BreadcrumbItem{Series: &series}, nil
 }
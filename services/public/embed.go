@@ -0,0 +1,296 @@
+package public
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type (
+	// OEmbed is an oEmbed response, https://oembed.com/#section2.3
+	OEmbed struct {
+		Type            string `json:"type"`
+		Version         string `json:"version"`
+		Title           string `json:"title"`
+		AuthorName      string `json:"author_name"`
+		AuthorURL       string `json:"author_url"`
+		ProviderName    string `json:"provider_name"`
+		ProviderURL     string `json:"provider_url"`
+		CacheAge        int    `json:"cache_age"`
+		ThumbnailURL    string `json:"thumbnail_url,omitempty"`
+		ThumbnailWidth  int    `json:"thumbnail_width,omitempty"`
+		ThumbnailHeight int    `json:"thumbnail_height,omitempty"`
+		HTML            string `json:"html"`
+		Width           int    `json:"width"`
+		Height          int    `json:"height"`
+	}
+
+	// PageMeta is the OpenGraph and Twitter card metadata of a page, the
+	// frontend emits each tag as a <meta> in the head
+	PageMeta struct {
+		Title       string    `json:"title"`
+		Description string    `json:"description"`
+		URL         string    `json:"url"`
+		Image       string    `json:"image,omitempty"`
+		Tags        []MetaTag `json:"tags"`
+	}
+
+	// MetaTag is an individual <meta> tag, OpenGraph uses property
+	// and Twitter uses name, but both are accepted as either
+	MetaTag struct {
+		Property string `json:"property"`
+		Content  string `json:"content"`
+	}
+)
+
+const (
+	providerName = "YSTV"
+
+	// embedWidth and embedHeight are the default player size, kept 16:9
+	embedWidth  = 640
+	embedHeight = 360
+
+	// oEmbedCacheAge is how long consumers should cache an embed, in seconds
+	oEmbedCacheAge = 3600
+
+	// metaDescriptionLength is the number of characters descriptions are cut to,
+	// anything longer is truncated by the sites anyway
+	metaDescriptionLength = 200
+)
+
+var ErrUnsupportedURL = errors.New("url is not a YSTV page")
+
+// GetOEmbed resolves a public site URL to a video or series and returns its embed,
+// the player is scaled down to fit maxWidth and maxHeight if they are set
+func (s *Store) GetOEmbed(ctx context.Context, rawURL string, maxWidth, maxHeight int) (OEmbed, error) {
+	item, err := s.resolveSiteURL(ctx, rawURL)
+	if err != nil {
+		return OEmbed{}, err
+	}
+
+	// Follow a single redirect to the page's new home
+	if item.Redirect != nil && !item.Redirect.External {
+		item, err = s.resolveSiteURL(ctx, item.Redirect.Target)
+		if err != nil {
+			return OEmbed{}, err
+		}
+	}
+
+	width, height := embedSize(maxWidth, maxHeight)
+
+	o := OEmbed{
+		Version:      "1.0",
+		AuthorName:   providerName,
+		AuthorURL:    s.siteURL,
+		ProviderName: providerName,
+		ProviderURL:  s.siteURL,
+		CacheAge:     oEmbedCacheAge,
+		Width:        width,
+		Height:       height,
+	}
+
+	var src, thumbnail string
+
+	switch {
+	case item.Video != nil:
+		o.Type = "video"
+		o.Title = item.Video.Name
+		thumbnail = item.Video.Thumbnail
+		src = fmt.Sprintf("%s/embed/%d", s.siteURL, item.Video.VideoID)
+	case item.Series != nil:
+		// There isn't a single video to play, so the series player is a rich embed
+		o.Type = "rich"
+		o.Title = item.Series.SeriesName
+		thumbnail = item.Series.Thumbnail
+		src = fmt.Sprintf("%s/embed/series/%d", s.siteURL, item.Series.SeriesID)
+	default:
+		return OEmbed{}, ErrUnsupportedURL
+	}
+
+	// Thumbnail sizes aren't stored, so they're left out rather than guessed
+	o.ThumbnailURL = thumbnail
+
+	o.HTML = fmt.Sprintf(`<iframe src="%s" width="%d" height="%d" frameborder="0" allow="autoplay; fullscreen; picture-in-picture" allowfullscreen title="%s"></iframe>`,
+		html.EscapeString(src), width, height, html.EscapeString(o.Title))
+
+	return o, nil
+}
+
+// resolveSiteURL turns a public site URL into a video or series. The new
+// /watch/video/{id} and /watch/series/{id} style is checked before falling
+// back to the path resolver used for the old series/series/video URLs.
+func (s *Store) resolveSiteURL(ctx context.Context, rawURL string) (BreadcrumbItem, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return BreadcrumbItem{}, ErrUnsupportedURL
+	}
+
+	site, err := url.Parse(s.siteURL)
+	if err != nil {
+		return BreadcrumbItem{}, fmt.Errorf("failed to parse site url: %w", err)
+	}
+
+	if strings.TrimPrefix(u.Hostname(), "www.") != site.Hostname() {
+		return BreadcrumbItem{}, ErrUnsupportedURL
+	}
+
+	path := strings.Trim(u.Path, "/")
+	path = strings.TrimPrefix(path, "watch/")
+
+	if path == "" {
+		return BreadcrumbItem{}, ErrUnsupportedURL
+	}
+
+	if id, ok := strings.CutPrefix(path, "video/"); ok {
+		// Find treats a bare number as a video ID
+		path = id
+	}
+
+	if raw, ok := strings.CutPrefix(path, "series/"); ok {
+		if id, err := strconv.Atoi(raw); err == nil {
+			series, err := s.GetSeriesFullMeta(ctx, id)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return BreadcrumbItem{}, ErrSeriesNotFound
+				}
+				return BreadcrumbItem{}, err
+			}
+			return BreadcrumbItem{Series: &series}, nil
+		}
+	}
+
+	return s.Find(ctx, path)
+}
+
+// GetVideoPageMeta returns the OpenGraph and Twitter card tags of a public video
+func (s *Store) GetVideoPageMeta(ctx context.Context, videoID int) (PageMeta, error) {
+	v, err := s.GetVideo(ctx, videoID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return PageMeta{}, ErrVideoNotFound
+		}
+		return PageMeta{}, err
+	}
+
+	m := newPageMeta(v.Name, v.Description, s.videoLink(v.VideoID), v.Thumbnail)
+	player := fmt.Sprintf("%s/embed/%d", s.siteURL, v.VideoID)
+
+	m.add("og:type", "video.other")
+	m.add("og:video", player)
+	m.add("og:video:type", "text/html")
+	m.add("og:video:width", strconv.Itoa(embedWidth))
+	m.add("og:video:height", strconv.Itoa(embedHeight))
+	m.add("video:duration", strconv.Itoa(v.Duration))
+	m.add("video:release_date", v.BroadcastDate.Format(time.RFC3339))
+	m.add("twitter:card", "player")
+	m.add("twitter:player", player)
+	m.add("twitter:player:width", strconv.Itoa(embedWidth))
+	m.add("twitter:player:height", strconv.Itoa(embedHeight))
+
+	return m, nil
+}
+
+// GetSeriesPageMeta returns the OpenGraph and Twitter card tags of a public series
+func (s *Store) GetSeriesPageMeta(ctx context.Context, seriesID int) (PageMeta, error) {
+	series, err := s.GetSeriesMeta(ctx, seriesID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return PageMeta{}, ErrSeriesNotFound
+		}
+		return PageMeta{}, fmt.Errorf("failed to get series meta: %w", err)
+	}
+
+	m := newPageMeta(series.SeriesName, series.Description,
+		fmt.Sprintf("%s/watch/series/%d", s.siteURL, series.SeriesID), series.Thumbnail)
+	m.add("og:type", "website")
+	m.addSummaryCard()
+
+	return m, nil
+}
+
+// GetPlaylistPageMeta returns the OpenGraph and Twitter card tags of a public playlist
+func (s *Store) GetPlaylistPageMeta(ctx context.Context, playlistID int) (PageMeta, error) {
+	p, err := s.getPublicPlaylistMeta(ctx, playlistID)
+	if err != nil {
+		return PageMeta{}, err
+	}
+
+	m := newPageMeta(p.Name, p.Description,
+		fmt.Sprintf("%s/watch/playlist/%d", s.siteURL, p.PlaylistID), p.Thumbnail)
+	m.add("og:type", "website")
+	m.addSummaryCard()
+
+	return m, nil
+}
+
+func newPageMeta(title, description, link, image string) PageMeta {
+	m := PageMeta{
+		Title:       title,
+		Description: truncate(description, metaDescriptionLength),
+		URL:         link,
+		Image:       image,
+	}
+
+	m.add("og:site_name", providerName)
+	m.add("og:title", m.Title)
+	m.add("og:description", m.Description)
+	m.add("og:url", m.URL)
+	m.add("twitter:title", m.Title)
+	m.add("twitter:description", m.Description)
+
+	if image != "" {
+		m.add("og:image", image)
+		m.add("twitter:image", image)
+	}
+
+	return m
+}
+
+func (m *PageMeta) add(property, content string) {
+	m.Tags = append(m.Tags, MetaTag{Property: property, Content: content})
+}
+
+// addSummaryCard uses the large image card when there is an image to show
+func (m *PageMeta) addSummaryCard() {
+	if m.Image != "" {
+		m.add("twitter:card", "summary_large_image")
+		return
+	}
+
+	m.add("twitter:card", "summary")
+}
+
+// embedSize returns the largest 16:9 player that fits within the maximums,
+// a maximum of 0 is unset
+func embedSize(maxWidth, maxHeight int) (int, int) {
+	width, height := embedWidth, embedHeight
+
+	if maxWidth > 0 && width > maxWidth {
+		width, height = maxWidth, maxWidth*9/16
+	}
+
+	if maxHeight > 0 && height > maxHeight {
+		width, height = maxHeight*16/9, maxHeight
+	}
+
+	return width, height
+}
+
+// truncate cuts s to at most n characters, ending with an ellipsis when cut
+func truncate(s string, n int) string {
+	s = strings.TrimSpace(s)
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+
+	r := []rune(s)
+
+	return strings.TrimSpace(string(r[:n-1])) + "…"
+}
@@ -0,0 +1,413 @@
+package public
+
+import (
+	"context"
+	"database/sql"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/ystv/web-api/utils"
+)
+
+type (
+	// Feed is a syndication feed of a series or playlist, it is rendered
+	// as RSS or Atom, and as a podcast when it has an encode format
+	Feed struct {
+		Title       string
+		Description string
+		Link        string
+		Image       string
+		Updated     time.Time
+		// Format is the encode format used for enclosures, nil for a plain feed
+		Format *FeedFormat
+		Items  []FeedItem
+	}
+
+	// FeedFormat is the encode format of the files attached to a podcast feed
+	FeedFormat struct {
+		FormatID int    `db:"format_id"`
+		Name     string `db:"name"`
+		MimeType string `db:"mime_type"`
+	}
+
+	// FeedItem is a video in a feed
+	FeedItem struct {
+		VideoMeta
+		// FileURI and FileSize (in KB) are only set on podcast feeds
+		FileURI  string `db:"uri"`
+		FileSize int64  `db:"size"`
+		// FileURL is the CDN URL of FileURI
+		FileURL string `db:"-"`
+		// Link is the video's page on the site
+		Link string `db:"-"`
+	}
+)
+
+const (
+	// feedSize is the maximum number of videos in a feed, feed readers only
+	// care about recent items
+	feedSize = 100
+)
+
+var (
+	ErrPlaylistNotFound = errors.New("playlist not found")
+	ErrFormatNotFound   = errors.New("encode format not found")
+)
+
+// GetSeriesFeed returns the most recent public videos in a series and its child series.
+// When formatID is set only videos with a public file of that format are included.
+func (s *Store) GetSeriesFeed(ctx context.Context, seriesID int, formatID *int) (Feed, error) {
+	series, err := s.GetSeriesMeta(ctx, seriesID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Feed{}, ErrSeriesNotFound
+		}
+		return Feed{}, fmt.Errorf("failed to get series meta: %w", err)
+	}
+
+	f := Feed{
+		Title:       series.SeriesName,
+		Description: series.Description,
+		Link:        fmt.Sprintf("%s/watch/series/%d", s.siteURL, series.SeriesID),
+		Image:       series.Thumbnail,
+	}
+
+	builder := feedItemsBuilder(formatID).
+		Where(`item.series_id IN (
+			SELECT node.series_id
+			FROM video.series node, video.series parent
+			WHERE parent.series_id = ?
+			AND node.lft BETWEEN parent.lft AND parent.rgt
+			AND node.status = 'public')`, seriesID).
+		OrderBy("item.broadcast_date DESC", "item.video_id DESC")
+
+	return s.fillFeed(ctx, f, builder, formatID)
+}
+
+// GetPlaylistFeed returns the public videos of a playlist, in playlist order.
+// When formatID is set only videos with a public file of that format are included.
+func (s *Store) GetPlaylistFeed(ctx context.Context, playlistID int, formatID *int) (Feed, error) {
+	p, err := s.getPublicPlaylistMeta(ctx, playlistID)
+	if err != nil {
+		return Feed{}, err
+	}
+
+	f := Feed{
+		Title:       p.Name,
+		Description: p.Description,
+		Link:        fmt.Sprintf("%s/watch/playlist/%d", s.siteURL, p.PlaylistID),
+		Image:       p.Thumbnail,
+	}
+
+	builder := feedItemsBuilder(formatID).
+		InnerJoin("video.playlist_items vid_list ON vid_list.video_item_id = item.video_id").
+		Where(sq.Eq{"vid_list.playlist_id": playlistID}).
+		OrderBy("vid_list.position")
+
+	return s.fillFeed(ctx, f, builder, formatID)
+}
+
+// getPublicPlaylistMeta returns the metadata of a playlist without its videos,
+// only if it is public
+func (s *Store) getPublicPlaylistMeta(ctx context.Context, playlistID int) (Playlist, error) {
+	var p Playlist
+
+	//nolint:musttag
+	err := s.db.GetContext(ctx, &p, `
+		SELECT playlist_id, name, description, thumbnail
+		FROM video.playlists
+		WHERE playlist_id = $1
+		AND status = 'public'
+		AND deleted_at IS NULL;`, playlistID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Playlist{}, ErrPlaylistNotFound
+		}
+		return Playlist{}, fmt.Errorf("failed to get playlist meta: %w", err)
+	}
+
+	return p, nil
+}
+
+// feedItemsBuilder selects the public videos of a feed, joining the files
+// of the encode format for podcasts
+func feedItemsBuilder(formatID *int) sq.SelectBuilder {
+	builder := utils.PSQL().Select("item.video_id", "item.series_id", "item.name", "item.url",
+		"item.description", "item.thumbnail", "item.broadcast_date", "item.views", "item.duration").
+		From("video.items item").
+		Where(sq.Eq{"item.status": "public", "item.deleted_at": nil}).
+		Limit(feedSize)
+
+	if formatID == nil {
+		return builder.Columns("'' AS uri", "0 AS size")
+	}
+
+	return builder.Columns("file.uri", "file.size").
+		InnerJoin("video.files file ON file.video_id = item.video_id").
+		Where(sq.Eq{"file.format_id": *formatID, "file.status": "public"})
+}
+
+func (s *Store) fillFeed(ctx context.Context, f Feed, builder sq.SelectBuilder, formatID *int) (Feed, error) {
+	if formatID != nil {
+		f.Format = &FeedFormat{}
+
+		err := s.db.GetContext(ctx, f.Format, `
+			SELECT format_id, name, mime_type
+			FROM video.encode_formats
+			WHERE format_id = $1;`, *formatID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return Feed{}, ErrFormatNotFound
+			}
+			return Feed{}, fmt.Errorf("failed to get encode format: %w", err)
+		}
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		panic(fmt.Errorf("failed to build sql for feed: %w", err))
+	}
+
+	err = s.db.SelectContext(ctx, &f.Items, sql, args...)
+	if err != nil {
+		return Feed{}, fmt.Errorf("failed to get feed videos: %w", err)
+	}
+
+	for i, item := range f.Items {
+		if item.BroadcastDate.After(f.Updated) {
+			f.Updated = item.BroadcastDate
+		}
+
+		f.Items[i].Link = s.videoLink(item.VideoID)
+
+		if item.FileURI != "" {
+			f.Items[i].FileURL = s.fileURL(item.FileURI)
+		}
+	}
+
+	if f.Updated.IsZero() {
+		f.Updated = time.Now()
+	}
+
+	return f, nil
+}
+
+// fileURL turns a "bucket/key" file URI into a URL on the CDN
+func (s *Store) fileURL(uri string) string {
+	endpoint := strings.TrimSuffix(s.cdnEndpoint, "/")
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+
+	return endpoint + "/" + strings.TrimPrefix(uri, "/")
+}
+
+func (s *Store) videoLink(videoID int) string {
+	return fmt.Sprintf("%s/watch/video/%d", s.siteURL, videoID)
+}
+
+type (
+	rssDocument struct {
+		XMLName     xml.Name   `xml:"rss"`
+		Version     string     `xml:"version,attr"`
+		XMLNSAtom   string     `xml:"xmlns:atom,attr"`
+		XMLNSITunes string     `xml:"xmlns:itunes,attr,omitempty"`
+		Channel     rssChannel `xml:"channel"`
+	}
+
+	rssChannel struct {
+		Title          string          `xml:"title"`
+		Link           string          `xml:"link"`
+		Description    string          `xml:"description"`
+		Language       string          `xml:"language"`
+		LastBuildDate  string          `xml:"lastBuildDate"`
+		AtomLink       atomLink        `xml:"atom:link"`
+		Image          *rssImage       `xml:"image,omitempty"`
+		ITunesAuthor   string          `xml:"itunes:author,omitempty"`
+		ITunesSummary  string          `xml:"itunes:summary,omitempty"`
+		ITunesExplicit string          `xml:"itunes:explicit,omitempty"`
+		ITunesImage    *iTunesImage    `xml:"itunes:image,omitempty"`
+		ITunesCategory *iTunesCategory `xml:"itunes:category,omitempty"`
+		ITunesOwner    *iTunesOwner    `xml:"itunes:owner,omitempty"`
+		Items          []rssItem       `xml:"item"`
+	}
+
+	rssImage struct {
+		URL   string `xml:"url"`
+		Title string `xml:"title"`
+		Link  string `xml:"link"`
+	}
+
+	rssItem struct {
+		Title          string        `xml:"title"`
+		Link           string        `xml:"link"`
+		Description    string        `xml:"description"`
+		GUID           rssGUID       `xml:"guid"`
+		PubDate        string        `xml:"pubDate"`
+		Enclosure      *rssEnclosure `xml:"enclosure,omitempty"`
+		ITunesDuration int           `xml:"itunes:duration,omitempty"`
+		ITunesSummary  string        `xml:"itunes:summary,omitempty"`
+		ITunesImage    *iTunesImage  `xml:"itunes:image,omitempty"`
+	}
+
+	rssGUID struct {
+		Value       string `xml:",chardata"`
+		IsPermaLink bool   `xml:"isPermaLink,attr"`
+	}
+
+	rssEnclosure struct {
+		URL    string `xml:"url,attr"`
+		Length int64  `xml:"length,attr"`
+		Type   string `xml:"type,attr"`
+	}
+
+	iTunesImage struct {
+		Href string `xml:"href,attr"`
+	}
+
+	iTunesCategory struct {
+		Text string `xml:"text,attr"`
+	}
+
+	iTunesOwner struct {
+		Name  string `xml:"itunes:name"`
+		Email string `xml:"itunes:email"`
+	}
+
+	atomDocument struct {
+		XMLName xml.Name    `xml:"feed"`
+		XMLNS   string      `xml:"xmlns,attr"`
+		ID      string      `xml:"id"`
+		Title   string      `xml:"title"`
+		Updated string      `xml:"updated"`
+		Links   []atomLink  `xml:"link"`
+		Logo    string      `xml:"logo,omitempty"`
+		Author  atomAuthor  `xml:"author"`
+		Entries []atomEntry `xml:"entry"`
+	}
+
+	atomLink struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr,omitempty"`
+		Type string `xml:"type,attr,omitempty"`
+	}
+
+	atomAuthor struct {
+		Name string `xml:"name"`
+	}
+
+	atomEntry struct {
+		ID        string   `xml:"id"`
+		Title     string   `xml:"title"`
+		Updated   string   `xml:"updated"`
+		Published string   `xml:"published"`
+		Link      atomLink `xml:"link"`
+		Summary   string   `xml:"summary"`
+	}
+)
+
+// RSS renders the feed as RSS 2.0, self is the URL of the feed. Feeds with
+// an encode format are rendered as a podcast with iTunes tags and enclosures.
+func (f Feed) RSS(self string) ([]byte, error) {
+	doc := rssDocument{
+		Version:   "2.0",
+		XMLNSAtom: "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:         f.Title,
+			Link:          f.Link,
+			Description:   f.Description,
+			Language:      "en-gb",
+			LastBuildDate: f.Updated.Format(time.RFC1123Z),
+			AtomLink:      atomLink{Href: self, Rel: "self", Type: "application/rss+xml"},
+			Items:         make([]rssItem, 0, len(f.Items)),
+		},
+	}
+
+	if f.Image != "" {
+		doc.Channel.Image = &rssImage{URL: f.Image, Title: f.Title, Link: f.Link}
+	}
+
+	podcast := f.Format != nil
+	if podcast {
+		doc.XMLNSITunes = "http://www.itunes.com/dtds/podcast-1.0.dtd"
+		doc.Channel.ITunesAuthor = "YSTV"
+		doc.Channel.ITunesSummary = f.Description
+		doc.Channel.ITunesExplicit = "false"
+		doc.Channel.ITunesCategory = &iTunesCategory{Text: "TV & Film"}
+		doc.Channel.ITunesOwner = &iTunesOwner{Name: "YSTV", Email: "computing@ystv.co.uk"}
+		if f.Image != "" {
+			doc.Channel.ITunesImage = &iTunesImage{Href: f.Image}
+		}
+	}
+
+	for _, v := range f.Items {
+		item := rssItem{
+			Title:       v.Name,
+			Link:        v.Link,
+			Description: v.Description,
+			GUID:        rssGUID{Value: v.Link, IsPermaLink: true},
+			PubDate:     v.BroadcastDate.Format(time.RFC1123Z),
+		}
+
+		if podcast {
+			item.Enclosure = &rssEnclosure{
+				URL:    v.FileURL,
+				Length: v.FileSize * 1024,
+				Type:   f.Format.MimeType,
+			}
+			item.ITunesDuration = v.Duration
+			item.ITunesSummary = v.Description
+			if v.Thumbnail != "" {
+				item.ITunesImage = &iTunesImage{Href: v.Thumbnail}
+			}
+		}
+
+		doc.Channel.Items = append(doc.Channel.Items, item)
+	}
+
+	return marshalFeed(doc)
+}
+
+// Atom renders the feed as Atom 1.0, self is the URL of the feed
+func (f Feed) Atom(self string) ([]byte, error) {
+	doc := atomDocument{
+		XMLNS:   "http://www.w3.org/2005/Atom",
+		ID:      f.Link,
+		Title:   f.Title,
+		Updated: f.Updated.Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: self, Rel: "self", Type: "application/atom+xml"},
+			{Href: f.Link, Rel: "alternate", Type: "text/html"},
+		},
+		Logo:    f.Image,
+		Author:  atomAuthor{Name: "YSTV"},
+		Entries: make([]atomEntry, 0, len(f.Items)),
+	}
+
+	for _, v := range f.Items {
+		doc.Entries = append(doc.Entries, atomEntry{
+			ID:        v.Link,
+			Title:     v.Name,
+			Updated:   v.BroadcastDate.Format(time.RFC3339),
+			Published: v.BroadcastDate.Format(time.RFC3339),
+			Link:      atomLink{Href: v.Link, Rel: "alternate", Type: "text/html"},
+			Summary:   v.Description,
+		})
+	}
+
+	return marshalFeed(doc)
+}
+
+func marshalFeed(doc interface{}) ([]byte, error) {
+	b, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal feed: %w", err)
+	}
+
+	return append([]byte(xml.Header), b...), nil
+}
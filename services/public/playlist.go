@@ -4,6 +4,10 @@ import (
 	"context"
 	"fmt"
 	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/ystv/web-api/utils"
 )
 
 // Playlist is a list of videos
@@ -46,6 +50,35 @@ func (s *Store) GetPlaylist(ctx context.Context, playlistID int) (Playlist, erro
 	return p, nil
 }
 
+// ListPlaylistVideos returns a page of the videos in a playlist, in playlist order
+func (s *Store) ListPlaylistVideos(ctx context.Context, playlistID int, cursor *utils.Cursor, size int) (utils.CursorPage[VideoMeta], error) {
+	var v []VideoMeta
+
+	where, order := utils.Keyset(cursor, positionKey("vid_list.position"), "item.video_id", false)
+
+	builder := utils.PSQL().Select("item.video_id", "item.series_id", "item.name", "item.url", "item.description",
+		"item.thumbnail", "item.broadcast_date", "item.views", "item.duration",
+		positionKey("vid_list.position")+" AS position").
+		From("video.playlist_items vid_list").
+		InnerJoin("video.items item ON vid_list.video_item_id = item.video_id").
+		Where(sq.Eq{"vid_list.playlist_id": playlistID, "item.status": "public"}).
+		Where(where).
+		OrderBy(order...).
+		Limit(uint64(size + 1))
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		panic(fmt.Errorf("failed to build sql for ListPlaylistVideos: %w", err))
+	}
+
+	err = s.db.SelectContext(ctx, &v, sql, args...)
+	if err != nil {
+		return utils.CursorPage[VideoMeta]{}, fmt.Errorf("failed to get playlist videos: %w", err)
+	}
+
+	return utils.NewCursorPage(v, size, cursor, videoPositionKey), nil
+}
+
 // GetPlaylistPopular returns a playlist of the most popular videos
 func (s *Store) GetPlaylistPopular(ctx context.Context, fromPeriod time.Time) (Playlist, error) {
 	p := Playlist{
@@ -2,9 +2,14 @@ package public
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/jmoiron/sqlx"
+
+	"github.com/ystv/web-api/services/misc"
+	"github.com/ystv/web-api/services/search"
+	"github.com/ystv/web-api/utils"
 )
 
 type (
@@ -16,13 +21,18 @@ type (
 		TeamRepo
 		StreamRepo
 		CustomSettingsRepo
+		FeedRepo
+		EmbedRepo
+		SitemapRepo
 	}
 
 	// VideoRepo represents all video interactions
 	VideoRepo interface {
-		ListVideos(ctx context.Context, offset int, page int) (*[]VideoMeta, error)
+		ListVideos(ctx context.Context, cursor *utils.Cursor, size int) (utils.CursorPage[VideoMeta], error)
 		GetVideo(ctx context.Context, videoID int) (*VideoItem, error)
 		VideoOfSeries(ctx context.Context, seriesID int) ([]VideoMeta, error)
+		ListVideosOfSeries(ctx context.Context, seriesID int, cursor *utils.Cursor, size int) (utils.CursorPage[VideoMeta], error)
+		ListRelatedVideos(ctx context.Context, videoID int) ([]RelatedVideo, error)
 	}
 	// SeriesRepo represents all series interactions
 	SeriesRepo interface {
@@ -32,11 +42,12 @@ type (
 		GetSeriesImmediateChildrenSeries(ctx context.Context, seriesID int) ([]SeriesMeta, error)
 		GetSeriesFromPath(ctx context.Context, path string) (Series, error)
 		GetSeriesByYear(ctx context.Context, year int) (Series, error)
-		Search(ctx context.Context, query string) (Series, error)
+		Search(ctx context.Context, params search.Params) (search.Results, error)
 	}
 	// PlaylistRepo represents all playlist interactions
 	PlaylistRepo interface {
 		GetPlaylist(ctx context.Context, playlistID int) (Playlist, error)
+		ListPlaylistVideos(ctx context.Context, playlistID int, cursor *utils.Cursor, size int) (utils.CursorPage[VideoMeta], error)
 		GetPlaylistPopular(ctx context.Context, fromPeriod time.Time) (Playlist, error)
 		GetPlaylistPopularByAllTime(ctx context.Context) (Playlist, error)
 		GetPlaylistPopularByPastYear(ctx context.Context) (Playlist, error)
@@ -65,6 +76,27 @@ type (
 	StreamRepo interface {
 		ListChannels(ctx context.Context) ([]Channel, error)
 		GetChannel(ctx context.Context, urlName string) (Channel, error)
+		GetNowNext(ctx context.Context, urlName string) (NowNext, error)
+		GetGuide(ctx context.Context) ([]byte, error)
+		GetChannelGuide(ctx context.Context, urlName string) ([]byte, error)
+	}
+	// FeedRepo represents all syndication feed interactions
+	FeedRepo interface {
+		GetSeriesFeed(ctx context.Context, seriesID int, formatID *int) (Feed, error)
+		GetPlaylistFeed(ctx context.Context, playlistID int, formatID *int) (Feed, error)
+	}
+	// EmbedRepo represents all oEmbed and page metadata interactions
+	EmbedRepo interface {
+		GetOEmbed(ctx context.Context, rawURL string, maxWidth, maxHeight int) (OEmbed, error)
+		GetVideoPageMeta(ctx context.Context, videoID int) (PageMeta, error)
+		GetSeriesPageMeta(ctx context.Context, seriesID int) (PageMeta, error)
+		GetPlaylistPageMeta(ctx context.Context, playlistID int) (PageMeta, error)
+	}
+	// SitemapRepo represents all sitemap interactions
+	SitemapRepo interface {
+		GetSitemapIndex(ctx context.Context, base string) ([]byte, error)
+		GetSeriesSitemap(ctx context.Context) ([]byte, error)
+		GetVideoSitemap(ctx context.Context, chunk int) ([]byte, error)
 	}
 	CustomSettingsRepo interface {
 		GetCustomSettingPublic(ctx context.Context, settingID string) (CustomSetting, error)
@@ -72,14 +104,25 @@ type (
 	// Store encapsulates our dependency
 	Store struct {
 		db          *sqlx.DB
+		search      search.Repo
+		redirects   misc.RedirectRepo
+		related     *relatedCache
+		sitemap     *sitemapCache
 		cdnEndpoint string
+		// siteURL is the public website, links in feeds, guides and embeds point to it
+		siteURL string
 	}
 )
 
 // NewStore creates our data store
-func NewStore(db *sqlx.DB, cdnEndpoint string) Repos {
+func NewStore(db *sqlx.DB, cdnEndpoint, siteURL string) Repos {
 	return &Store{
 		db:          db,
+		search:      search.NewStore(db),
+		redirects:   misc.NewStore(db, siteURL),
+		related:     newRelatedCache(),
+		sitemap:     newSitemapCache(),
 		cdnEndpoint: cdnEndpoint,
+		siteURL:     strings.TrimSuffix(siteURL, "/"),
 	}
 }
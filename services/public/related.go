@@ -0,0 +1,235 @@
+package public
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ystv/web-api/utils"
+)
+
+type (
+	// RelatedVideo is a video recommended to watch after another
+	RelatedVideo struct {
+		VideoMeta
+		// Score is the weighted relevance to the original video, higher is better
+		Score float64 `db:"score" json:"score"`
+	}
+
+	// RelatedWeights tunes how related videos are scored, it is stored as the
+	// "related_videos" custom setting so it can be changed without a deployment
+	RelatedWeights struct {
+		// Series is added when the candidate is in the same series, half when
+		// it's elsewhere in the parent series
+		Series float64 `json:"series"`
+		// Tags is added for each tag shared with the original video
+		Tags float64 `json:"tags"`
+		// Text is multiplied by the text rank of the candidate against the
+		// original's name and tags
+		Text float64 `json:"text"`
+		// CoWatch is multiplied by ln(1 + sessions) where sessions is the number
+		// of viewers who watched both videos in the same session
+		CoWatch float64 `json:"coWatch"`
+		// SessionHours is how close together two hits need to be to count as the same session
+		SessionHours int `json:"sessionHours"`
+		// Limit is the maximum number of related videos returned
+		Limit int `json:"limit"`
+		// CacheSeconds is how long the related videos of a video are cached
+		CacheSeconds int `json:"cacheSeconds"`
+	}
+
+	relatedCacheItem struct {
+		videoID int
+		videos  []RelatedVideo
+		expires time.Time
+	}
+
+	// relatedCache stores the related videos per video, since scoring
+	// every public video is too expensive to do on each request. It holds
+	// at most relatedCacheSize videos, dropping the least recently used.
+	relatedCache struct {
+		mu    sync.Mutex
+		order *list.List
+		items map[int]*list.Element
+	}
+)
+
+// relatedCacheSize is how many videos' related videos are cached at once
+const relatedCacheSize = 1000
+
+// RelatedWeightsSettingID is the custom setting that stores the RelatedWeights
+const RelatedWeightsSettingID = "related_videos"
+
+// DefaultRelatedWeights are used when the custom setting hasn't been created
+var DefaultRelatedWeights = RelatedWeights{
+	Series:       3,
+	Tags:         1,
+	Text:         4,
+	CoWatch:      2,
+	SessionHours: 3,
+	Limit:        12,
+	CacheSeconds: 3600,
+}
+
+func newRelatedCache() *relatedCache {
+	return &relatedCache{order: list.New(), items: make(map[int]*list.Element)}
+}
+
+func (c *relatedCache) get(videoID int) ([]RelatedVideo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[videoID]
+	if !ok {
+		return nil, false
+	}
+
+	item := e.Value.(relatedCacheItem)
+	if time.Now().After(item.expires) {
+		c.order.Remove(e)
+		delete(c.items, videoID)
+		return nil, false
+	}
+
+	c.order.MoveToFront(e)
+
+	return item.videos, true
+}
+
+func (c *relatedCache) set(videoID int, videos []RelatedVideo, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item := relatedCacheItem{videoID: videoID, videos: videos, expires: time.Now().Add(ttl)}
+
+	if e, ok := c.items[videoID]; ok {
+		e.Value = item
+		c.order.MoveToFront(e)
+		return
+	}
+
+	c.items[videoID] = c.order.PushFront(item)
+
+	for c.order.Len() > relatedCacheSize {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(relatedCacheItem).videoID)
+	}
+}
+
+// GetRelatedWeights returns the related video weights custom setting, or the
+// defaults if it hasn't been set
+func (s *Store) GetRelatedWeights(ctx context.Context) (RelatedWeights, error) {
+	var raw []byte
+
+	err := s.db.GetContext(ctx, &raw, `
+		SELECT value
+		FROM web_api.custom_settings
+		WHERE setting_id = $1;`, RelatedWeightsSettingID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return DefaultRelatedWeights, nil
+		}
+		return RelatedWeights{}, fmt.Errorf("failed to get related weights: %w", err)
+	}
+
+	// Anything left out of the setting keeps its default
+	w := DefaultRelatedWeights
+
+	err = json.Unmarshal(raw, &w)
+	if err != nil {
+		return RelatedWeights{}, fmt.Errorf("failed to parse related weights: %w", err)
+	}
+
+	return w, nil
+}
+
+// ListRelatedVideos returns public videos to watch after the given video, scored on
+// being in the same series, sharing tags, similar names and being watched together
+func (s *Store) ListRelatedVideos(ctx context.Context, videoID int) ([]RelatedVideo, error) {
+	if v, ok := s.related.get(videoID); ok {
+		return v, nil
+	}
+
+	var exists bool
+
+	err := s.db.GetContext(ctx, &exists, `
+		SELECT EXISTS(SELECT 1 FROM video.items WHERE video_id = $1 AND status = 'public' AND deleted_at IS NULL);`, videoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get video: %w", err)
+	}
+
+	if !exists {
+		return nil, ErrVideoNotFound
+	}
+
+	w, err := s.GetRelatedWeights(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var v []RelatedVideo
+
+	err = s.db.SelectContext(ctx, &v, `
+		WITH source AS (
+			SELECT item.video_id, item.name, item.tags, series.lft, series.rgt,
+				COALESCE(parent.lft, series.lft) AS parent_lft, COALESCE(parent.rgt, series.rgt) AS parent_rgt
+			FROM video.items item
+			INNER JOIN video.series series ON item.series_id = series.series_id
+			LEFT JOIN LATERAL (
+				SELECT ancestor.lft, ancestor.rgt
+				FROM video.series ancestor
+				WHERE ancestor.lft < series.lft AND ancestor.rgt > series.rgt
+				ORDER BY ancestor.lft DESC
+				LIMIT 1
+			) parent ON true
+			WHERE item.video_id = $1
+		), text_query AS (
+			SELECT replace(plainto_tsquery('english',
+				source.name || ' ' || array_to_string(source.tags, ' '))::text, '&', '|')::tsquery AS query
+			FROM source
+		), co_watch AS (
+			SELECT other.video_id, COUNT(DISTINCT (other.ip_address, other.client_info)) AS sessions
+			FROM video.hits mine
+			INNER JOIN video.hits other ON other.ip_address = mine.ip_address
+				AND other.client_info = mine.client_info
+				AND other.video_id <> mine.video_id
+				AND other.start_time BETWEEN mine.start_time - make_interval(hours => $6)
+					AND mine.start_time + make_interval(hours => $6)
+			WHERE mine.video_id = $1
+			GROUP BY other.video_id
+		)
+		SELECT item.video_id, item.series_id, item.name, item.url, item.description, item.thumbnail,
+			item.broadcast_date, item.views, item.duration,
+			$2 * (CASE
+					WHEN series.lft BETWEEN source.lft AND source.rgt THEN 1
+					WHEN series.lft BETWEEN source.parent_lft AND source.parent_rgt THEN 0.5
+					ELSE 0 END)
+			+ $3 * cardinality(ARRAY(SELECT unnest(item.tags) INTERSECT SELECT unnest(source.tags)))
+			+ $4 * COALESCE(ts_rank(item.search_document, text_query.query), 0)
+			+ $5 * ln(1 + COALESCE(co_watch.sessions, 0)) AS score
+		FROM video.items item
+		INNER JOIN video.series series ON item.series_id = series.series_id
+		CROSS JOIN source
+		CROSS JOIN text_query
+		LEFT JOIN co_watch ON co_watch.video_id = item.video_id
+		WHERE item.status = 'public'
+		AND item.deleted_at IS NULL
+		AND item.video_id <> source.video_id
+		ORDER BY score DESC, item.broadcast_date DESC
+		LIMIT $7;`, videoID, w.Series, w.Tags, w.Text, w.CoWatch, w.SessionHours, w.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get related videos: %w", err)
+	}
+
+	v = utils.NonNil(v)
+
+	s.related.set(videoID, v, time.Duration(w.CacheSeconds)*time.Second)
+
+	return v, nil
+}
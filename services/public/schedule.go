@@ -0,0 +1,270 @@
+package public
+
+import (
+	"context"
+	"database/sql"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+type (
+	// Programme is an item on a channel's schedule. Videos and events are only
+	// linked when they're public.
+	Programme struct {
+		ChannelURLName string    `db:"url_name" json:"-"`
+		Type           string    `db:"item_type" json:"type"` // "video" or "event" or "text"
+		Title          string    `db:"title" json:"title"`
+		Description    string    `db:"description" json:"description"`
+		VideoID        null.Int  `db:"video_id" json:"videoID,omitempty"`
+		EventID        null.Int  `db:"event_id" json:"eventID,omitempty"`
+		Thumbnail      string    `db:"thumbnail" json:"thumbnail,omitempty"`
+		ScheduledStart time.Time `db:"scheduled_start" json:"scheduledStart"`
+		ScheduledEnd   time.Time `db:"scheduled_end" json:"scheduledEnd"`
+	}
+
+	// NowNext is what is on a channel now and what is on next, either can be
+	// nil when nothing is scheduled
+	NowNext struct {
+		URLName string     `json:"urlName"`
+		Now     *Programme `json:"now"`
+		Next    *Programme `json:"next"`
+	}
+)
+
+const (
+	// guideHistory and guideLength are how far back and forward the XMLTV guide goes
+	guideHistory = 24 * time.Hour
+	guideLength  = 7 * 24 * time.Hour
+
+	xmltvTime = "20060102150405 -0700"
+)
+
+var ErrChannelNotFound = errors.New("channel not found")
+
+// programmeSelect selects the programmes of channels, with the video or event
+// filling in a title and description that weren't set. Videos that aren't
+// public and private events are only shown by the schedule's own title, or as
+// a generic programme.
+const programmeSelect = `
+	SELECT ch.url_name, sched.item_type,
+		COALESCE(NULLIF(sched.title, ''), public_item.name, public_event.name, 'Programme') AS title,
+		COALESCE(NULLIF(sched.description, ''), public_item.description, public_event.description, '') AS description,
+		public_item.video_id, COALESCE(public_item.thumbnail, '') AS thumbnail, public_event.event_id,
+		sched.scheduled_start, sched.scheduled_end
+	FROM playout.schedule_items sched
+	INNER JOIN playout.channel ch ON sched.channel_id = ch.channel_id
+	LEFT JOIN video.items public_item ON sched.video_id = public_item.video_id
+		AND public_item.status = 'public' AND public_item.deleted_at IS NULL
+	LEFT JOIN event.events public_event ON sched.event_id = public_event.event_id
+		AND NOT public_event.is_private`
+
+// GetNowNext gets what is on a public or unlisted channel now and next
+func (s *Store) GetNowNext(ctx context.Context, urlName string) (NowNext, error) {
+	err := s.checkChannel(ctx, urlName)
+	if err != nil {
+		return NowNext{}, err
+	}
+
+	now := time.Now()
+
+	var progs []Programme
+
+	err = s.db.SelectContext(ctx, &progs, programmeSelect+`
+		WHERE ch.url_name = $1 AND sched.scheduled_end > $2
+		ORDER BY sched.scheduled_start
+		LIMIT 2;`, urlName, now)
+	if err != nil {
+		return NowNext{}, fmt.Errorf("failed to get programmes: %w", err)
+	}
+
+	nn := NowNext{URLName: urlName}
+
+	if len(progs) > 0 && !progs[0].ScheduledStart.After(now) {
+		nn.Now = &progs[0]
+		progs = progs[1:]
+	}
+
+	if len(progs) > 0 {
+		nn.Next = &progs[0]
+	}
+
+	return nn, nil
+}
+
+// GetGuide renders the XMLTV guide of every public channel
+func (s *Store) GetGuide(ctx context.Context) ([]byte, error) {
+	var chs []Channel
+
+	err := s.db.SelectContext(ctx, &chs, `
+		SELECT url_name, name, description, thumbnail, output_type, output_url,
+		status, location, scheduled_start, scheduled_end
+		FROM playout.channel
+		WHERE visibility = 'public'
+		ORDER BY url_name;`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channels: %w", err)
+	}
+
+	now := time.Now()
+
+	var progs []Programme
+
+	err = s.db.SelectContext(ctx, &progs, programmeSelect+`
+		WHERE ch.visibility = 'public' AND sched.scheduled_end > $1 AND sched.scheduled_start < $2
+		ORDER BY ch.url_name, sched.scheduled_start;`, now.Add(-guideHistory), now.Add(guideLength))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get programmes: %w", err)
+	}
+
+	return s.renderGuide(chs, progs)
+}
+
+// GetChannelGuide renders the XMLTV guide of a public or unlisted channel
+func (s *Store) GetChannelGuide(ctx context.Context, urlName string) ([]byte, error) {
+	ch, err := s.GetChannel(ctx, urlName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrChannelNotFound
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+
+	var progs []Programme
+
+	err = s.db.SelectContext(ctx, &progs, programmeSelect+`
+		WHERE ch.url_name = $1 AND sched.scheduled_end > $2 AND sched.scheduled_start < $3
+		ORDER BY sched.scheduled_start;`, urlName, now.Add(-guideHistory), now.Add(guideLength))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get programmes: %w", err)
+	}
+
+	return s.renderGuide([]Channel{ch}, progs)
+}
+
+// checkChannel returns ErrChannelNotFound unless the channel is public or unlisted
+func (s *Store) checkChannel(ctx context.Context, urlName string) error {
+	var exists bool
+
+	err := s.db.GetContext(ctx, &exists, `
+		SELECT EXISTS(SELECT 1 FROM playout.channel
+		WHERE visibility IN ('public', 'unlisted') AND url_name = $1);`, urlName)
+	if err != nil {
+		return fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	if !exists {
+		return ErrChannelNotFound
+	}
+
+	return nil
+}
+
+type (
+	xmltvDocument struct {
+		XMLName       xml.Name         `xml:"tv"`
+		GeneratorName string           `xml:"generator-info-name,attr"`
+		SourceURL     string           `xml:"source-info-url,attr"`
+		Channels      []xmltvChannel   `xml:"channel"`
+		Programmes    []xmltvProgramme `xml:"programme"`
+	}
+
+	xmltvChannel struct {
+		ID          string     `xml:"id,attr"`
+		DisplayName xmltvText  `xml:"display-name"`
+		Icon        *xmltvIcon `xml:"icon,omitempty"`
+		URL         string     `xml:"url"`
+	}
+
+	xmltvProgramme struct {
+		Start    string     `xml:"start,attr"`
+		Stop     string     `xml:"stop,attr"`
+		Channel  string     `xml:"channel,attr"`
+		Title    xmltvText  `xml:"title"`
+		Desc     *xmltvText `xml:"desc,omitempty"`
+		Category xmltvText  `xml:"category"`
+		Icon     *xmltvIcon `xml:"icon,omitempty"`
+		URL      string     `xml:"url,omitempty"`
+	}
+
+	xmltvText struct {
+		Lang  string `xml:"lang,attr"`
+		Value string `xml:",chardata"`
+	}
+
+	xmltvIcon struct {
+		Src string `xml:"src,attr"`
+	}
+)
+
+// renderGuide renders channels and their programmes as an XMLTV document
+func (s *Store) renderGuide(chs []Channel, progs []Programme) ([]byte, error) {
+	doc := xmltvDocument{
+		GeneratorName: "web-api",
+		SourceURL:     s.siteURL,
+		Channels:      make([]xmltvChannel, 0, len(chs)),
+		Programmes:    make([]xmltvProgramme, 0, len(progs)),
+	}
+
+	for _, ch := range chs {
+		c := xmltvChannel{
+			ID:          s.xmltvChannelID(ch.URLName),
+			DisplayName: xmltvText{Lang: "en", Value: ch.Name},
+			URL:         s.siteURL + "/live/" + url.PathEscape(ch.URLName),
+		}
+
+		if ch.Thumbnail != "" {
+			c.Icon = &xmltvIcon{Src: ch.Thumbnail}
+		}
+
+		doc.Channels = append(doc.Channels, c)
+	}
+
+	for _, prog := range progs {
+		p := xmltvProgramme{
+			Start:    prog.ScheduledStart.Format(xmltvTime),
+			Stop:     prog.ScheduledEnd.Format(xmltvTime),
+			Channel:  s.xmltvChannelID(prog.ChannelURLName),
+			Title:    xmltvText{Lang: "en", Value: prog.Title},
+			Category: xmltvText{Lang: "en", Value: prog.Type},
+		}
+
+		if prog.Description != "" {
+			p.Desc = &xmltvText{Lang: "en", Value: prog.Description}
+		}
+
+		if prog.Thumbnail != "" {
+			p.Icon = &xmltvIcon{Src: prog.Thumbnail}
+		}
+
+		if prog.VideoID.Valid {
+			p.URL = s.videoLink(int(prog.VideoID.Int64))
+		}
+
+		doc.Programmes = append(doc.Programmes, p)
+	}
+
+	b, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render guide: %w", err)
+	}
+
+	return append([]byte(xml.Header+`<!DOCTYPE tv SYSTEM "xmltv.dtd">`+"\n"), b...), nil
+}
+
+// xmltvChannelID makes a channel ID that is unique between guides, as XMLTV recommends,
+// by putting it under the site's host
+func (s *Store) xmltvChannelID(urlName string) string {
+	site, err := url.Parse(s.siteURL)
+	if err != nil || site.Hostname() == "" {
+		return urlName
+	}
+
+	return urlName + "." + site.Hostname()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ystv/web-api/services/search"
 	"github.com/ystv/web-api/utils"
 )
 
@@ -166,55 +167,9 @@ func (s *Store) GetSeriesByYear(ctx context.Context, year int) (Series, error) {
 	return series, nil
 }
 
-// Search performs a full-text search on video library
-//
-// Uses postgres full-text search, video and series tables to try to make some sense
-func (s *Store) Search(ctx context.Context, query string) (Series, error) {
-	var series Series
+// Search performs a ranked full-text search on the public video library
+func (s *Store) Search(ctx context.Context, params search.Params) (search.Results, error) {
+	params.PublicOnly = true
 
-	err := s.db.SelectContext(ctx, &series.ChildVideos,
-		`SELECT
-			video_id,
-			series_id,
-			name,
-			url,
-			description,
-			thumbnail,
-		 	broadcast_date,
-		 	views,
-			duration
-		FROM (
-			SELECT
-		  		video.video_id,
-		  		video.series_id,
-		  		video.name,
-		  		video.url,
-		  		video.description,
-		  		video.thumbnail,
-		  		video.views,
-		  		video.duration,
-		  		video.tags,
-		  		video.broadcast_date,
-				
-				to_tsvector(video.name) || ' ' ||
-				to_tsvector(video.description) || ' ' ||
-				to_tsvector(unnest(video.tags)) || ' ' ||
-				to_tsvector(CAST(video.broadcast_date AS text)) || ' ' ||
-				to_tsvector(unnest(array_agg(series.name))) || ' ' ||
-				to_tsvector(unnest(array_agg(series.description)))
-		  		AS document
-			FROM video.items video
-			INNER JOIN video.series series ON video.series_id = series.series_id
-			WHERE video.status = 'public'
-			GROUP BY video.video_id) p_search,
-
-			ts_rank_cd(p_search.document, replace(plainto_tsquery($1)::text, '&', '|')::tsquery) rank
-
-	WHERE p_search.document @@ replace(plainto_tsquery($1)::text, '&', '|')::tsquery
-	ORDER BY rank DESC;`, query)
-	if err != nil {
-		return Series{}, fmt.Errorf("failed to search videos: %w", err)
-	}
-
-	return series, nil
+	return s.search.Search(ctx, params)
 }
@@ -0,0 +1,356 @@
+package public
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// The sitemap is split into an index, one sitemap of series and chunks of videos.
+// Videos are chunked on ID ranges so a video always stays in the same chunk, and
+// each chunk has a cheap fingerprint. Only chunks whose fingerprint has changed
+// since they were last rendered are regenerated.
+//
+// Videos are only listed when they are public, not deleted, in a public series
+// and not under embargo, that being a broadcast date in the future.
+
+type (
+	// sitemapState is the fingerprint of a chunk of the sitemap, if any URL
+	// is added, removed or modified it will change
+	sitemapState struct {
+		Chunk        int       `db:"chunk"`
+		Count        int       `db:"count"`
+		IDSum        int64     `db:"id_sum"`
+		LastModified time.Time `db:"last_modified"`
+	}
+
+	sitemapChunk struct {
+		state sitemapState
+		xml   []byte
+	}
+
+	// sitemapCache holds the rendered sitemaps between requests
+	sitemapCache struct {
+		mu      sync.Mutex
+		checked time.Time
+		series  sitemapState
+		videos  []sitemapState
+		// rendered is keyed by video chunk, with the series sitemap at seriesSitemapChunk
+		rendered map[int]sitemapChunk
+	}
+
+	sitemapVideo struct {
+		VideoID       int            `db:"video_id"`
+		Name          string         `db:"name"`
+		URL           string         `db:"url"`
+		Description   string         `db:"description"`
+		Thumbnail     string         `db:"thumbnail"`
+		Duration      int            `db:"duration"`
+		BroadcastDate time.Time      `db:"broadcast_date"`
+		Tags          pq.StringArray `db:"tags"`
+		Path          string         `db:"path"`
+		LastModified  time.Time      `db:"last_modified"`
+	}
+
+	sitemapSeries struct {
+		Path         string    `db:"path"`
+		LastModified time.Time `db:"last_modified"`
+	}
+)
+
+const (
+	// sitemapChunkSize is the range of video IDs in each chunk, well under
+	// the 50,000 URL limit of a sitemap
+	sitemapChunkSize = 1000
+
+	// sitemapRefreshInterval is how often the fingerprints are checked, so
+	// crawlers hitting every sitemap at once only cause a single check
+	sitemapRefreshInterval = 10 * time.Minute
+
+	seriesSitemapChunk = -1
+
+	// videoDescriptionLength is the maximum length of a video sitemap description
+	videoDescriptionLength = 2048
+	// maxVideoDuration is the longest duration allowed in a video sitemap, 8 hours
+	maxVideoDuration = 28800
+	// maxVideoTags is the maximum number of tags allowed in a video sitemap
+	maxVideoTags = 32
+)
+
+var ErrSitemapNotFound = errors.New("sitemap not found")
+
+// visibleVideosSQL is shared by the fingerprint and chunk queries, so they
+// always agree on which videos are in the sitemap
+const visibleVideosSQL = `
+	FROM video.items item
+	INNER JOIN video.series_paths series_path ON series_path.series_id = item.series_id
+	WHERE item.status = 'public'
+	AND item.deleted_at IS NULL
+	AND item.broadcast_date <= NOW()
+	AND series_path.status = 'public'`
+
+const lastModifiedSQL = `GREATEST(COALESCE(item.updated_at, item.created_at), item.broadcast_date)`
+
+func newSitemapCache() *sitemapCache {
+	return &sitemapCache{rendered: make(map[int]sitemapChunk)}
+}
+
+func (a sitemapState) equal(b sitemapState) bool {
+	return a.Chunk == b.Chunk && a.Count == b.Count && a.IDSum == b.IDSum &&
+		a.LastModified.Equal(b.LastModified)
+}
+
+// refreshSitemap updates the fingerprints if they haven't been checked recently,
+// the cache must be locked
+func (s *Store) refreshSitemap(ctx context.Context) error {
+	c := s.sitemap
+
+	if time.Since(c.checked) < sitemapRefreshInterval {
+		return nil
+	}
+
+	var series sitemapState
+
+	err := s.db.GetContext(ctx, &series, `
+		SELECT 0 AS chunk, COUNT(*) AS count, COALESCE(SUM(series.series_id), 0) AS id_sum,
+			COALESCE(MAX(COALESCE(series.updated_at, series.created_at)), 'epoch') AS last_modified
+		FROM video.series series
+		WHERE series.status = 'public';`)
+	if err != nil {
+		return fmt.Errorf("failed to get series sitemap state: %w", err)
+	}
+
+	var videos []sitemapState
+
+	err = s.db.SelectContext(ctx, &videos, `
+		SELECT item.video_id / $1 AS chunk, COUNT(*) AS count, SUM(item.video_id) AS id_sum,
+			MAX(`+lastModifiedSQL+`) AS last_modified`+
+		visibleVideosSQL+`
+		GROUP BY chunk
+		ORDER BY chunk;`, sitemapChunkSize)
+	if err != nil {
+		return fmt.Errorf("failed to get video sitemap states: %w", err)
+	}
+
+	// Series paths are part of every video URL, so if the series have changed
+	// all the rendered video chunks are stale
+	if !series.equal(c.series) {
+		clear(c.rendered)
+	}
+
+	c.series = series
+	c.videos = videos
+	c.checked = time.Now()
+
+	return nil
+}
+
+// GetSitemapIndex returns the sitemap index, linking to each child sitemap
+// under base, which is the absolute URL of the sitemap directory
+func (s *Store) GetSitemapIndex(ctx context.Context, base string) ([]byte, error) {
+	s.sitemap.mu.Lock()
+	defer s.sitemap.mu.Unlock()
+
+	err := s.refreshSitemap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	index := sitemapIndex{
+		XMLNS:    sitemapNS,
+		Sitemaps: make([]sitemapIndexEntry, 0, len(s.sitemap.videos)+1),
+	}
+
+	index.Sitemaps = append(index.Sitemaps, sitemapIndexEntry{
+		Loc:     base + "/series.xml",
+		LastMod: s.sitemap.series.LastModified.Format(time.RFC3339),
+	})
+
+	for _, chunk := range s.sitemap.videos {
+		index.Sitemaps = append(index.Sitemaps, sitemapIndexEntry{
+			Loc:     fmt.Sprintf("%s/videos/%d.xml", base, chunk.Chunk),
+			LastMod: chunk.LastModified.Format(time.RFC3339),
+		})
+	}
+
+	return marshalFeed(index)
+}
+
+// GetSeriesSitemap returns the sitemap of every public series
+func (s *Store) GetSeriesSitemap(ctx context.Context) ([]byte, error) {
+	s.sitemap.mu.Lock()
+	defer s.sitemap.mu.Unlock()
+
+	err := s.refreshSitemap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached, ok := s.sitemap.rendered[seriesSitemapChunk]; ok && cached.state.equal(s.sitemap.series) {
+		return cached.xml, nil
+	}
+
+	var series []sitemapSeries
+
+	err = s.db.SelectContext(ctx, &series, `
+		SELECT series_path.path, COALESCE(series.updated_at, series.created_at) AS last_modified
+		FROM video.series series
+		INNER JOIN video.series_paths series_path ON series_path.series_id = series.series_id
+		WHERE series.status = 'public'
+		AND series_path.path <> ''
+		ORDER BY series.lft;`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sitemap series: %w", err)
+	}
+
+	set := urlSet{XMLNS: sitemapNS, URLs: make([]sitemapURL, 0, len(series))}
+
+	for _, sp := range series {
+		set.URLs = append(set.URLs, sitemapURL{
+			Loc:     s.siteURL + "/watch/" + sp.Path,
+			LastMod: sp.LastModified.Format(time.RFC3339),
+		})
+	}
+
+	b, err := marshalFeed(set)
+	if err != nil {
+		return nil, err
+	}
+
+	s.sitemap.rendered[seriesSitemapChunk] = sitemapChunk{state: s.sitemap.series, xml: b}
+
+	return b, nil
+}
+
+// GetVideoSitemap returns a chunk of the video sitemap, with video extensions
+func (s *Store) GetVideoSitemap(ctx context.Context, chunk int) ([]byte, error) {
+	s.sitemap.mu.Lock()
+	defer s.sitemap.mu.Unlock()
+
+	err := s.refreshSitemap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var state *sitemapState
+
+	for i := range s.sitemap.videos {
+		if s.sitemap.videos[i].Chunk == chunk {
+			state = &s.sitemap.videos[i]
+			break
+		}
+	}
+
+	if state == nil {
+		return nil, ErrSitemapNotFound
+	}
+
+	if cached, ok := s.sitemap.rendered[chunk]; ok && cached.state.equal(*state) {
+		return cached.xml, nil
+	}
+
+	var videos []sitemapVideo
+
+	err = s.db.SelectContext(ctx, &videos, `
+		SELECT item.video_id, item.name, item.url, item.description, item.thumbnail, item.duration,
+			item.broadcast_date, item.tags, series_path.path, `+lastModifiedSQL+` AS last_modified`+
+		visibleVideosSQL+`
+		AND item.video_id >= $1 AND item.video_id < $2
+		ORDER BY item.video_id;`, chunk*sitemapChunkSize, (chunk+1)*sitemapChunkSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sitemap videos: %w", err)
+	}
+
+	set := urlSet{XMLNS: sitemapNS, XMLNSVideo: sitemapVideoNS, URLs: make([]sitemapURL, 0, len(videos))}
+
+	for _, v := range videos {
+		u := sitemapURL{
+			Loc:     s.siteURL + "/watch/" + strings.TrimPrefix(v.Path+"/"+v.URL, "/"),
+			LastMod: v.LastModified.Format(time.RFC3339),
+		}
+
+		// Google ignores a video without a thumbnail, title and description
+		if v.Thumbnail != "" && v.Name != "" {
+			ext := &sitemapVideoExt{
+				ThumbnailLoc:    v.Thumbnail,
+				Title:           v.Name,
+				Description:     truncate(v.Description, videoDescriptionLength),
+				PlayerLoc:       fmt.Sprintf("%s/embed/%d", s.siteURL, v.VideoID),
+				PublicationDate: v.BroadcastDate.Format(time.RFC3339),
+			}
+
+			if ext.Description == "" {
+				ext.Description = v.Name
+			}
+
+			if v.Duration > 0 {
+				ext.Duration = min(v.Duration, maxVideoDuration)
+			}
+
+			ext.Tags = v.Tags
+			if len(ext.Tags) > maxVideoTags {
+				ext.Tags = ext.Tags[:maxVideoTags]
+			}
+
+			u.Video = ext
+		}
+
+		set.URLs = append(set.URLs, u)
+	}
+
+	b, err := marshalFeed(set)
+	if err != nil {
+		return nil, err
+	}
+
+	s.sitemap.rendered[chunk] = sitemapChunk{state: *state, xml: b}
+
+	return b, nil
+}
+
+const (
+	sitemapNS      = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	sitemapVideoNS = "http://www.google.com/schemas/sitemap-video/1.1"
+)
+
+type (
+	sitemapIndex struct {
+		XMLName  xml.Name            `xml:"sitemapindex"`
+		XMLNS    string              `xml:"xmlns,attr"`
+		Sitemaps []sitemapIndexEntry `xml:"sitemap"`
+	}
+
+	sitemapIndexEntry struct {
+		Loc     string `xml:"loc"`
+		LastMod string `xml:"lastmod,omitempty"`
+	}
+
+	urlSet struct {
+		XMLName    xml.Name     `xml:"urlset"`
+		XMLNS      string       `xml:"xmlns,attr"`
+		XMLNSVideo string       `xml:"xmlns:video,attr,omitempty"`
+		URLs       []sitemapURL `xml:"url"`
+	}
+
+	sitemapURL struct {
+		Loc     string           `xml:"loc"`
+		LastMod string           `xml:"lastmod,omitempty"`
+		Video   *sitemapVideoExt `xml:"video:video,omitempty"`
+	}
+
+	sitemapVideoExt struct {
+		ThumbnailLoc    string   `xml:"video:thumbnail_loc"`
+		Title           string   `xml:"video:title"`
+		Description     string   `xml:"video:description"`
+		PlayerLoc       string   `xml:"video:player_loc"`
+		Duration        int      `xml:"video:duration,omitempty"`
+		PublicationDate string   `xml:"video:publication_date"`
+		Tags            []string `xml:"video:tag"`
+	}
+)
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	sq "github.com/Masterminds/squirrel"
+
 	"github.com/ystv/web-api/utils"
 )
 
@@ -33,27 +35,50 @@ type (
 		BroadcastDate time.Time `db:"broadcast_date" json:"broadcastDate"`
 		Views         int       `db:"views" json:"views"`
 		Duration      int       `db:"duration" json:"duration"`
+		// Position is only used as a cursor key when listing series and playlists
+		Position int `db:"position" json:"-"`
 	}
 )
 
-// ListVideos returns all video metadata
-func (s *Store) ListVideos(ctx context.Context, offset int, page int) (*[]VideoMeta, error) {
+// positionKey is the sort key of a nullable position column, unpositioned
+// videos are sorted last like they would be by ORDER BY
+func positionKey(column string) string {
+	return "COALESCE(" + column + ", 32767)"
+}
+
+func videoMetaKey(v VideoMeta) (string, int) {
+	return utils.TimeKey(v.BroadcastDate), v.VideoID
+}
+
+func videoPositionKey(v VideoMeta) (string, int) {
+	return utils.IntKey(v.Position), v.VideoID
+}
+
+// ListVideos returns a page of public video metadata, newest first
+func (s *Store) ListVideos(ctx context.Context, cursor *utils.Cursor, size int) (utils.CursorPage[VideoMeta], error) {
 	var v []VideoMeta
 
-	// TODO Change pagination method
-	// TODO Do a double check on if we need to convert broadcast date
-	err := s.db.SelectContext(ctx, &v,
-		`SELECT video_id, series_id, name, url, description, thumbnail,
-		broadcast_date,	views, duration
-		FROM video.items
-		WHERE status = 'public'
-		ORDER BY broadcast_date DESC
-		OFFSET $1 LIMIT $2;`, page, offset)
+	where, order := utils.Keyset(cursor, "broadcast_date", "video_id", true)
+
+	builder := utils.PSQL().Select("video_id", "series_id", "name", "url", "description", "thumbnail",
+		"broadcast_date", "views", "duration").
+		From("video.items").
+		Where(sq.Eq{"status": "public"}).
+		Where(where).
+		OrderBy(order...).
+		Limit(uint64(size + 1))
+
+	sql, args, err := builder.ToSql()
 	if err != nil {
-		return nil, err
+		panic(fmt.Errorf("failed to build sql for ListVideos: %w", err))
 	}
 
-	return &v, nil
+	err = s.db.SelectContext(ctx, &v, sql, args...)
+	if err != nil {
+		return utils.CursorPage[VideoMeta]{}, err
+	}
+
+	return utils.NewCursorPage(v, size, cursor, videoMetaKey), nil
 }
 
 // GetVideo returns a VideoItem, including the files, based on a given VideoItem ID.
@@ -87,6 +112,33 @@ func (s *Store) GetVideo(ctx context.Context, videoID int) (*VideoItem, error) {
 	return &v, nil
 }
 
+// ListVideosOfSeries returns a page of the videos belonging to a series, in series order
+func (s *Store) ListVideosOfSeries(ctx context.Context, seriesID int, cursor *utils.Cursor, size int) (utils.CursorPage[VideoMeta], error) {
+	var v []VideoMeta
+
+	where, order := utils.Keyset(cursor, positionKey("series_position"), "video_id", false)
+
+	builder := utils.PSQL().Select("video_id", "series_id", "name", "url", "description", "thumbnail",
+		"broadcast_date", "views", "duration", positionKey("series_position")+" AS position").
+		From("video.items").
+		Where(sq.Eq{"series_id": seriesID, "status": "public"}).
+		Where(where).
+		OrderBy(order...).
+		Limit(uint64(size + 1))
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		panic(fmt.Errorf("failed to build sql for ListVideosOfSeries: %w", err))
+	}
+
+	err = s.db.SelectContext(ctx, &v, sql, args...)
+	if err != nil {
+		return utils.CursorPage[VideoMeta]{}, err
+	}
+
+	return utils.NewCursorPage(v, size, cursor, videoPositionKey), nil
+}
+
 // VideoOfSeries returns all the videos belonging to a series
 func (s *Store) VideoOfSeries(ctx context.Context, seriesID int) ([]VideoMeta, error) {
 	var v []VideoMeta
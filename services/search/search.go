@@ -0,0 +1,179 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/ystv/web-api/utils"
+)
+
+// The search package is shared by the public and creator services, so the
+// ranking and filtering of the video library only lives in one place.
+//
+// Searching uses the video.items.search_document column which is maintained
+// by triggers in the database, weighted name > tags > series > description.
+
+type (
+	// Repo represents all search interactions
+	Repo interface {
+		Search(ctx context.Context, params Params) (Results, error)
+	}
+
+	// Params are the options of a search
+	Params struct {
+		// Query is the web search style query, supports "quoted phrases", OR and -exclusion
+		Query string `json:"query"`
+		// Page is the page number, starting at 1
+		Page int `json:"page,omitempty"`
+		// Size is the number of results per page, defaults to 20 and is capped at 100
+		Size int `json:"size,omitempty"`
+		// SeriesID limits the results to the series and any of its child series
+		SeriesID *int `json:"seriesID,omitempty"`
+		// Year limits the results to videos broadcast in that year
+		Year *int `json:"year,omitempty"`
+		// MinDuration limits the results to videos at least this many seconds long
+		MinDuration *int `json:"minDuration,omitempty"`
+		// MaxDuration limits the results to videos at most this many seconds long
+		MaxDuration *int `json:"maxDuration,omitempty"`
+		// PublicOnly hides any video which isn't public, always set by the public service
+		PublicOnly bool `json:"-"`
+	}
+
+	// Results is a page of ranked search results
+	Results struct {
+		Results   []Result `json:"results"`
+		Page      int      `json:"page"`
+		Size      int      `json:"size"`
+		FullCount int      `json:"fullCount"`
+	}
+
+	// Result is an individual video that has matched the search
+	Result struct {
+		VideoID       int            `db:"video_id" json:"id"`
+		SeriesID      int            `db:"series_id" json:"seriesID"`
+		Name          string         `db:"name" json:"name"`
+		URL           string         `db:"url" json:"url"`
+		Description   string         `db:"description" json:"description"`
+		Thumbnail     string         `db:"thumbnail" json:"thumbnail"`
+		BroadcastDate time.Time      `db:"broadcast_date" json:"broadcastDate"`
+		Views         int            `db:"views" json:"views"`
+		Duration      int            `db:"duration" json:"duration"`
+		Tags          pq.StringArray `db:"tags" json:"tags"`
+		Status        string         `db:"status" json:"status"`
+		// Rank is the ts_rank of the video against the query, higher is better
+		Rank float64 `db:"rank" json:"rank"`
+		// Headline is a snippet of the description with the matches wrapped in <b></b>
+		Headline string `db:"headline" json:"headline"`
+	}
+
+	// Store encapsulates our dependency
+	Store struct {
+		db *sqlx.DB
+	}
+)
+
+const (
+	defaultSize = 20
+	maxSize     = 100
+
+	headlineOptions = "MaxFragments=2, MaxWords=30, MinWords=10, StartSel=<b>, StopSel=</b>"
+)
+
+var ErrEmptyQuery = errors.New("search query is empty")
+
+// NewStore creates our data store
+func NewStore(db *sqlx.DB) Repo {
+	return &Store{db: db}
+}
+
+// Search performs a ranked full-text search on the video library
+func (s *Store) Search(ctx context.Context, params Params) (Results, error) {
+	params.Query = strings.TrimSpace(params.Query)
+	if len(params.Query) == 0 {
+		return Results{}, ErrEmptyQuery
+	}
+
+	if params.Size <= 0 {
+		params.Size = defaultSize
+	} else if params.Size > maxSize {
+		params.Size = maxSize
+	}
+
+	if params.Page < 1 {
+		params.Page = 1
+	}
+
+	builder := utils.PSQL().Select("item.video_id", "item.series_id", "item.name", "item.url",
+		"item.description", "item.thumbnail", "item.broadcast_date", "item.views", "item.duration",
+		"item.tags", "item.status", "ts_rank(item.search_document, query) AS rank",
+		"ts_headline('english', item.description, query, '"+headlineOptions+"') AS headline",
+		"count(*) OVER() AS full_count").
+		From("video.items item").
+		JoinClause("CROSS JOIN websearch_to_tsquery('english', ?) query", params.Query).
+		Where("item.search_document @@ query").
+		Where(sq.Eq{"item.deleted_at": nil}).
+		OrderBy("rank DESC", "item.broadcast_date DESC").
+		Limit(uint64(params.Size)).
+		Offset(uint64((params.Page - 1) * params.Size))
+
+	if params.PublicOnly {
+		builder = builder.Where(sq.Eq{"item.status": "public"})
+	}
+
+	if params.SeriesID != nil {
+		builder = builder.Where(`item.series_id IN (
+			SELECT node.series_id
+			FROM video.series node, video.series parent
+			WHERE parent.series_id = ?
+			AND node.lft BETWEEN parent.lft AND parent.rgt)`, *params.SeriesID)
+	}
+
+	if params.Year != nil {
+		builder = builder.Where("EXTRACT(YEAR FROM item.broadcast_date) = ?", *params.Year)
+	}
+
+	if params.MinDuration != nil {
+		builder = builder.Where(sq.GtOrEq{"item.duration": *params.MinDuration})
+	}
+
+	if params.MaxDuration != nil {
+		builder = builder.Where(sq.LtOrEq{"item.duration": *params.MaxDuration})
+	}
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		panic(fmt.Errorf("failed to build sql for Search: %w", err))
+	}
+
+	type resultWithCount struct {
+		Result
+		FullCount int `db:"full_count"`
+	}
+
+	var rows []resultWithCount
+
+	err = s.db.SelectContext(ctx, &rows, sql, args...)
+	if err != nil {
+		return Results{}, fmt.Errorf("failed to search videos: %w", err)
+	}
+
+	res := Results{
+		Results: make([]Result, 0, len(rows)),
+		Page:    params.Page,
+		Size:    params.Size,
+	}
+
+	for _, row := range rows {
+		res.FullCount = row.FullCount
+		res.Results = append(res.Results, row.Result)
+	}
+
+	return res, nil
+}
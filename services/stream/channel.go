@@ -0,0 +1,174 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/ystv/web-api/utils"
+)
+
+// ChannelStatusNotification is the Postgres notification channel that every
+// channel status transition is sent on, as a JSON ChannelEvent
+const ChannelStatusNotification = "playout_channel_status"
+
+// liveLeadTime is how long before its scheduled start a channel goes live when
+// its endpoint publishes, so holding slides count as the channel being on air
+const liveLeadTime = 30 * time.Minute
+
+const (
+	ChannelEventPublish   = "publish"
+	ChannelEventUnpublish = "unpublish"
+	ChannelEventSchedule  = "schedule"
+)
+
+// ChannelEvent is an automatic change of a channel's status
+type ChannelEvent struct {
+	EventID    int       `db:"event_id" json:"eventId"`
+	ChannelID  int       `db:"channel_id" json:"channelId"`
+	URLName    string    `db:"url_name" json:"urlName"`
+	FromStatus string    `db:"from_status" json:"fromStatus"`
+	ToStatus   string    `db:"to_status" json:"toStatus"`
+	Reason     string    `db:"reason" json:"reason"`
+	EndpointID null.Int  `db:"endpoint_id" json:"endpointId"`
+	CreatedAt  time.Time `db:"created_at" json:"createdAt"`
+}
+
+// SetChannelsPublished puts the scheduled channels fed by an endpoint live when it
+// publishes. Channels more than liveLeadTime from their start are left alone, the
+// scheduler puts them live at their start if the endpoint is still publishing.
+func (s *Store) SetChannelsPublished(ctx context.Context, endpointID int) ([]ChannelEvent, error) {
+	return s.transitionChannels(ctx, ChannelEventPublish, "live", `
+		endpoint_id = $1 AND status = 'scheduled' AND scheduled_end > NOW()
+		AND scheduled_start <= NOW() + make_interval(secs => $2)`,
+		endpointID, liveLeadTime.Seconds())
+}
+
+// SetChannelsUnpublished takes the channels fed by an endpoint off air when it stops.
+// After their scheduled end they're finished, before it they're scheduled again as
+// the stream is expected to come back.
+func (s *Store) SetChannelsUnpublished(ctx context.Context, application, name string) ([]ChannelEvent, error) {
+	endpoint := `endpoint_id = (SELECT endpoint_id FROM web_api.stream_endpoints
+		WHERE application = $1 AND name = $2) AND status = 'live'`
+
+	finished, err := s.transitionChannels(ctx, ChannelEventUnpublish, "finished",
+		endpoint+` AND scheduled_end <= NOW()`, application, name)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled, err := s.transitionChannels(ctx, ChannelEventUnpublish, "scheduled",
+		endpoint+` AND scheduled_end > NOW()`, application, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(finished, scheduled...), nil
+}
+
+// UpdateChannelSchedules moves channels fed by an endpoint on at their start and
+// off at their end. Channels whose endpoint is publishing go live once they start,
+// and channels past their end without it are finished.
+func (s *Store) UpdateChannelSchedules(ctx context.Context) ([]ChannelEvent, error) {
+	live, err := s.transitionChannels(ctx, ChannelEventSchedule, "live", `
+		status = 'scheduled' AND scheduled_start <= NOW() AND scheduled_end > NOW()
+		AND endpoint_id IN (SELECT endpoint_id FROM web_api.stream_endpoints WHERE active)`)
+	if err != nil {
+		return nil, err
+	}
+
+	finished, err := s.transitionChannels(ctx, ChannelEventSchedule, "finished", `
+		status IN ('scheduled', 'live') AND scheduled_end <= NOW()
+		AND endpoint_id IN (SELECT endpoint_id FROM web_api.stream_endpoints WHERE NOT active)`)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(live, finished...), nil
+}
+
+// RunChannelScheduler updates channel schedules every interval until the context is done
+func (s *Store) RunChannelScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		events, err := s.UpdateChannelSchedules(ctx)
+		if err != nil {
+			log.Printf("channel scheduler failed: %+v", err)
+		}
+
+		for _, e := range events {
+			log.Printf("channel scheduler: %s %s -> %s", e.URLName, e.FromStatus, e.ToStatus)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// transitionChannels sets the status of the channels matching where, recording
+// an event for each and notifying listeners once the change is committed
+func (s *Store) transitionChannels(ctx context.Context, reason, to, where string, args ...interface{}) ([]ChannelEvent, error) {
+	var events []ChannelEvent
+
+	args = append(args, to)
+
+	err := utils.Transact(s.db, func(tx *sqlx.Tx) error {
+		// Locking the rows first, so the old status is the one being replaced
+		err := tx.SelectContext(ctx, &events, fmt.Sprintf(`
+			WITH changed AS (
+				SELECT channel_id, status
+				FROM playout.channel
+				WHERE %s
+				FOR UPDATE
+			)
+			UPDATE playout.channel ch SET status = $%d
+			FROM changed
+			WHERE ch.channel_id = changed.channel_id
+			RETURNING ch.channel_id, ch.url_name, changed.status AS from_status,
+				ch.status AS to_status, ch.endpoint_id;`, where, len(args)), args...)
+		if err != nil {
+			return fmt.Errorf("failed to update channel status: %w", err)
+		}
+
+		for i := range events {
+			events[i].Reason = reason
+
+			err = tx.QueryRowxContext(ctx, `
+				INSERT INTO playout.channel_events (channel_id, from_status, to_status, reason, endpoint_id)
+				VALUES ($1, $2, $3, $4, $5)
+				RETURNING event_id, created_at;`, events[i].ChannelID, events[i].FromStatus,
+				events[i].ToStatus, reason, events[i].EndpointID).
+				Scan(&events[i].EventID, &events[i].CreatedAt)
+			if err != nil {
+				return fmt.Errorf("failed to record channel event: %w", err)
+			}
+
+			payload, err := json.Marshal(events[i])
+			if err != nil {
+				return fmt.Errorf("failed to encode channel event: %w", err)
+			}
+
+			_, err = tx.ExecContext(ctx, `SELECT pg_notify($1, $2);`, ChannelStatusNotification, string(payload))
+			if err != nil {
+				return fmt.Errorf("failed to notify channel event: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
@@ -0,0 +1,106 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ystv/web-api/utils"
+)
+
+// ExpiryReport lists the endpoints the janitor changed
+type ExpiryReport struct {
+	// Deactivated are expired endpoints that were still marked as publishing
+	Deactivated []int `json:"deactivated"`
+	// Removed are expired endpoints that were set to auto remove
+	Removed []int `json:"removed"`
+}
+
+// ValidAt is true when the endpoint is inside its validity window at t,
+// an unset start or end leaves that side of the window open
+func (e EndpointDB) ValidAt(t time.Time) bool {
+	if e.StartValid.Valid && t.Before(e.StartValid.Time) {
+		return false
+	}
+
+	if e.EndValid.Valid && !t.Before(e.EndValid.Time) {
+		return false
+	}
+
+	return true
+}
+
+// ExpiresWithin is true when the endpoint is valid at t and stops being valid within d
+func (e EndpointDB) ExpiresWithin(t time.Time, d time.Duration) bool {
+	return e.ValidAt(t) && e.EndValid.Valid && e.EndValid.Time.Before(t.Add(d))
+}
+
+// ExpireEndpoints marks endpoints past their end valid time inactive, taking the
+// channels they feed off air, and deletes those that are set to auto remove
+func (s *Store) ExpireEndpoints(ctx context.Context) (ExpiryReport, error) {
+	var deactivated []struct {
+		EndpointID  int    `db:"endpoint_id"`
+		Application string `db:"application"`
+		Name        string `db:"name"`
+	}
+
+	err := s.db.SelectContext(ctx, &deactivated, `
+		UPDATE web_api.stream_endpoints SET active = false
+		WHERE active AND end_valid <= NOW()
+		RETURNING endpoint_id, application, name;`)
+	if err != nil {
+		return ExpiryReport{}, fmt.Errorf("failed to deactivate expired endpoints: %w", err)
+	}
+
+	report := ExpiryReport{Deactivated: make([]int, 0, len(deactivated))}
+
+	for _, e := range deactivated {
+		report.Deactivated = append(report.Deactivated, e.EndpointID)
+
+		err = s.EndSession(ctx, e.Application, e.Name, SessionEndExpired)
+		if err != nil {
+			return ExpiryReport{}, fmt.Errorf("failed to end session of endpoint %d: %w", e.EndpointID, err)
+		}
+
+		_, err = s.SetChannelsUnpublished(ctx, e.Application, e.Name)
+		if err != nil {
+			return ExpiryReport{}, fmt.Errorf("failed to take channels of endpoint %d off air: %w", e.EndpointID, err)
+		}
+	}
+
+	err = s.db.SelectContext(ctx, &report.Removed, `
+		DELETE FROM web_api.stream_endpoints
+		WHERE auto_remove AND end_valid <= NOW()
+		RETURNING endpoint_id;`)
+	if err != nil {
+		return ExpiryReport{}, fmt.Errorf("failed to remove expired endpoints: %w", err)
+	}
+
+	report.Removed = utils.NonNil(report.Removed)
+
+	return report, nil
+}
+
+// RunEndpointJanitor expires endpoints every interval until the context is done
+func (s *Store) RunEndpointJanitor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := s.ExpireEndpoints(ctx)
+		if err != nil {
+			log.Printf("endpoint janitor failed: %+v", err)
+		}
+
+		if len(report.Deactivated) > 0 || len(report.Removed) > 0 {
+			log.Printf("endpoint janitor: deactivated %v, removed %v", report.Deactivated, report.Removed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
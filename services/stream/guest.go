@@ -0,0 +1,213 @@
+package stream
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// maxGuestValidity is the longest a guest key can be valid for
+const maxGuestValidity = 14 * 24 * time.Hour
+
+// ErrInvalidGuest is returned when a guest endpoint request can't be used
+var ErrInvalidGuest = errors.New("invalid guest endpoint")
+
+type (
+	// IngestConfig is where the media servers accept streams, used to tell guests where to stream to
+	IngestConfig struct {
+		// RTMPURL is the RTMP server, without the application, e.g. rtmp://stream.example.com
+		RTMPURL string
+		// SRTURL is the SRT server, e.g. srt://stream.example.com:10080
+		SRTURL string
+	}
+
+	// GuestEndpointNew requests a temporary stream endpoint for an external collaborator
+	GuestEndpointNew struct {
+		// Application defines which RTMP application the guest streams to
+		Application string `json:"application"`
+		// Name is the unique name given in an application, generated when left unset
+		Name string `json:"name,omitempty"`
+		// Guest is who the key is for, e.g. another station or a society
+		Guest string `json:"guest"`
+		// StartValid defines when the key starts working, defaults to now, RFC3339
+		StartValid *time.Time `json:"startValid,omitempty"`
+		// EndValid defines when the key stops working and the endpoint is removed, RFC3339
+		EndValid time.Time `json:"endValid"`
+		// Email is where the instructions are sent, optional
+		Email *string `json:"email,omitempty"`
+	}
+
+	// GuestKey is a guest endpoint with the instructions to stream to it, the
+	// key is only ever returned here
+	GuestKey struct {
+		EndpointID  int        `json:"endpointId"`
+		Application string     `json:"application"`
+		Name        string     `json:"name"`
+		Guest       string     `json:"guest"`
+		Pwd         string     `json:"pwd"`
+		StartValid  *time.Time `json:"startValid,omitempty"`
+		EndValid    time.Time  `json:"endValid"`
+		// RTMPServer and RTMPStreamKey are the values streaming software asks for
+		RTMPServer    string `json:"rtmpServer,omitempty"`
+		RTMPStreamKey string `json:"rtmpStreamKey,omitempty"`
+		SRTURL        string `json:"srtUrl,omitempty"`
+		// Instructions is a plain text sheet that can be passed on to the guest
+		Instructions string `json:"instructions"`
+		// Emailed indicates the instructions were sent to the requested email
+		Emailed bool `json:"emailed"`
+	}
+)
+
+var guestInstructions = template.Must(template.New("guest").Parse(`Streaming to YSTV{{if .Guest}} for {{.Guest}}{{end}}
+
+{{if .StartValid}}Your key works from {{.StartValid.Format "Monday 2 January 2006 15:04 MST"}}{{else}}Your key works from now{{end}} until {{.EndValid.Format "Monday 2 January 2006 15:04 MST"}}.
+Outside of those times your stream will be refused.
+{{if .RTMPServer}}
+RTMP, for OBS, vMix and most hardware encoders
+  Server:     {{.RTMPServer}}
+  Stream key: {{.RTMPStreamKey}}
+{{end}}{{if .SRTURL}}
+SRT
+  URL: {{.SRTURL}}
+{{end}}
+Keep the stream key private, anyone with it can stream to us.
+Only one stream can use the key at a time.
+`))
+
+// AddGuestEndpoint creates a time-boxed endpoint with a generated key, which is
+// removed once it stops being valid. The key is returned with the instructions.
+func (s *Store) AddGuestEndpoint(ctx context.Context, guest GuestEndpointNew, ingest IngestConfig) (GuestKey, error) {
+	now := time.Now()
+
+	switch {
+	case guest.Application == "":
+		return GuestKey{}, fmt.Errorf("%w: application must be set", ErrInvalidGuest)
+	case guest.EndValid.IsZero():
+		return GuestKey{}, fmt.Errorf("%w: end valid must be set", ErrInvalidGuest)
+	case !guest.EndValid.After(now):
+		return GuestKey{}, fmt.Errorf("%w: end valid must be after now", ErrInvalidGuest)
+	case guest.StartValid != nil && !guest.EndValid.After(*guest.StartValid):
+		return GuestKey{}, fmt.Errorf("%w: end valid must be after start valid", ErrInvalidGuest)
+	}
+
+	from := now
+	if guest.StartValid != nil && guest.StartValid.After(now) {
+		from = *guest.StartValid
+	}
+
+	if guest.EndValid.Sub(from) > maxGuestValidity {
+		return GuestKey{}, fmt.Errorf("%w: guest keys can be valid for at most %s", ErrInvalidGuest, maxGuestValidity)
+	}
+
+	if guest.Name == "" {
+		suffix := make([]byte, 4)
+
+		_, err := rand.Read(suffix)
+		if err != nil {
+			return GuestKey{}, fmt.Errorf("failed to generate name: %w", err)
+		}
+
+		guest.Name = "guest-" + hex.EncodeToString(suffix)
+	}
+
+	pwd, err := generateKey()
+	if err != nil {
+		return GuestKey{}, err
+	}
+
+	notes := "Guest key"
+	if guest.Guest != "" {
+		notes += " for " + guest.Guest
+	}
+
+	endpoint, err := s.AddEndpoint(ctx, EndpointAddEditDTO{
+		Application: guest.Application,
+		Name:        guest.Name,
+		Pwd:         &pwd,
+		StartValid:  guest.StartValid,
+		EndValid:    &guest.EndValid,
+		Notes:       &notes,
+		AutoRemove:  true,
+	})
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation" {
+			return GuestKey{}, fmt.Errorf("%w: %s/%s is already in use", ErrInvalidGuest, guest.Application, guest.Name)
+		}
+		return GuestKey{}, err
+	}
+
+	key := GuestKey{
+		EndpointID:  endpoint.EndpointID,
+		Application: endpoint.Application,
+		Name:        endpoint.Name,
+		Guest:       guest.Guest,
+		Pwd:         pwd,
+		StartValid:  guest.StartValid,
+		EndValid:    guest.EndValid,
+	}
+
+	query := url.Values{"pwd": {pwd}}.Encode()
+
+	if ingest.RTMPURL != "" {
+		key.RTMPServer = strings.TrimSuffix(ingest.RTMPURL, "/") + "/" + endpoint.Application
+		key.RTMPStreamKey = endpoint.Name + "?" + query
+	}
+
+	if ingest.SRTURL != "" {
+		// SRS reads the application, name and key from the stream ID
+		streamID := "#!::r=" + endpoint.Application + "/" + endpoint.Name + "?" + query + ",m=publish"
+		key.SRTURL = ingest.SRTURL + "?streamid=" + url.QueryEscape(streamID)
+	}
+
+	var sheet bytes.Buffer
+
+	err = guestInstructions.Execute(&sheet, key)
+	if err != nil {
+		return GuestKey{}, fmt.Errorf("failed to write instructions: %w", err)
+	}
+
+	key.Instructions = sheet.String()
+
+	return key, nil
+}
+
+// RevokeEndpoint stops an endpoint working straight away. It's blocked and
+// expired, so anything it feeds is taken off air and, when it is set to auto
+// remove, it is deleted.
+func (s *Store) RevokeEndpoint(ctx context.Context, endpointID int) error {
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE web_api.stream_endpoints SET
+			blocked = true,
+			start_valid = CASE WHEN start_valid < NOW() THEN start_valid END,
+			end_valid = NOW()
+		WHERE endpoint_id = $1;`, endpointID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke endpoint: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to revoke endpoint: %w", err)
+	}
+
+	if rows < 1 {
+		return ErrEndpointNotFound
+	}
+
+	_, err = s.ExpireEndpoints(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to expire revoked endpoint: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package stream
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/guregu/null.v4"
+)
+
+const (
+	// MaskedKey is shown in place of a stream key, which can't be read back once set
+	MaskedKey = "********"
+
+	// generatedKeyBytes is the amount of randomness in a rotated key
+	generatedKeyBytes = 24
+
+	// maxKeyLength is the longest key bcrypt can hash
+	maxKeyLength = 72
+)
+
+var (
+	// ErrEndpointNotFound is returned when a stream endpoint doesn't exist
+	ErrEndpointNotFound = errors.New("stream endpoint not found")
+	// ErrInvalidKey is returned when a stream key can't be stored
+	ErrInvalidKey = errors.New("invalid stream key")
+	// ErrKeyMismatch is returned when a stream key doesn't match the endpoint's
+	ErrKeyMismatch = errors.New("stream key doesn't match")
+)
+
+type (
+	// RotatedKey is a newly generated stream key, it is only returned the once
+	RotatedKey struct {
+		EndpointID int    `json:"endpointId"`
+		Pwd        string `json:"pwd"`
+	}
+
+	// KeyMigrationReport is the result of hashing the plaintext stream keys
+	KeyMigrationReport struct {
+		Hashed []int `json:"hashed"`
+		// Failed are endpoints whose key couldn't be hashed, they keep working from plaintext
+		Failed []int `json:"failed"`
+	}
+)
+
+// HasKey indicates a stream key is set on the endpoint
+func (e EndpointDB) HasKey() bool {
+	return e.PwdHash.Valid || e.Pwd.Valid
+}
+
+// CheckKey checks a stream key against the endpoint's. Endpoints without a key
+// accept nothing.
+func (e EndpointDB) CheckKey(pwd string) error {
+	switch {
+	case e.PwdHash.Valid:
+		err := bcrypt.CompareHashAndPassword([]byte(e.PwdHash.String), []byte(pwd))
+		if err != nil {
+			return ErrKeyMismatch
+		}
+	case e.Pwd.Valid:
+		// Keys from before hashing which haven't been migrated yet
+		if subtle.ConstantTimeCompare([]byte(e.Pwd.String), []byte(pwd)) != 1 {
+			return ErrKeyMismatch
+		}
+	default:
+		return ErrKeyMismatch
+	}
+
+	return nil
+}
+
+// hashKey salts and hashes a stream key for storing
+func hashKey(pwd string) (string, error) {
+	if len(pwd) > maxKeyLength {
+		return "", fmt.Errorf("%w: key must be at most %d bytes", ErrInvalidKey, maxKeyLength)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(pwd), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash key: %w", err)
+	}
+
+	return string(hash), nil
+}
+
+// hashKeyPtr hashes an optional stream key
+func hashKeyPtr(pwd *string) (null.String, error) {
+	if pwd == nil {
+		return null.String{}, nil
+	}
+
+	hash, err := hashKey(*pwd)
+	if err != nil {
+		return null.String{}, err
+	}
+
+	return null.StringFrom(hash), nil
+}
+
+// generateKey returns a random URL safe stream key
+func generateKey() (string, error) {
+	b := make([]byte, generatedKeyBytes)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// RotateEndpointKey replaces an endpoint's stream key with a random one. The
+// new key is only ever returned here, afterwards only its hash is kept.
+func (s *Store) RotateEndpointKey(ctx context.Context, endpointID int) (RotatedKey, error) {
+	pwd, err := generateKey()
+	if err != nil {
+		return RotatedKey{}, err
+	}
+
+	hash, err := hashKey(pwd)
+	if err != nil {
+		return RotatedKey{}, err
+	}
+
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE web_api.stream_endpoints SET pwd_hash = $1, pwd = NULL
+		WHERE endpoint_id = $2;`, hash, endpointID)
+	if err != nil {
+		return RotatedKey{}, fmt.Errorf("failed to rotate key: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return RotatedKey{}, fmt.Errorf("failed to rotate key: %w", err)
+	}
+
+	if rows < 1 {
+		return RotatedKey{}, ErrEndpointNotFound
+	}
+
+	return RotatedKey{EndpointID: endpointID, Pwd: pwd}, nil
+}
+
+// HashPlaintextKeys hashes the stream keys stored before keys were hashed,
+// clearing the plaintext. It is safe to run repeatedly.
+func (s *Store) HashPlaintextKeys(ctx context.Context) (KeyMigrationReport, error) {
+	var report KeyMigrationReport
+
+	var plaintext []struct {
+		EndpointID int    `db:"endpoint_id"`
+		Pwd        string `db:"pwd"`
+	}
+
+	err := s.db.SelectContext(ctx, &plaintext, `
+		SELECT endpoint_id, pwd
+		FROM web_api.stream_endpoints
+		WHERE pwd IS NOT NULL AND pwd_hash IS NULL;`)
+	if err != nil {
+		return report, fmt.Errorf("failed to get plaintext keys: %w", err)
+	}
+
+	for _, e := range plaintext {
+		hash, err := hashKey(e.Pwd)
+		if err != nil {
+			report.Failed = append(report.Failed, e.EndpointID)
+			continue
+		}
+
+		// Only replacing the key it was hashed from, in case it changed since
+		_, err = s.db.ExecContext(ctx, `
+			UPDATE web_api.stream_endpoints SET pwd_hash = $1, pwd = NULL
+			WHERE endpoint_id = $2 AND pwd = $3 AND pwd_hash IS NULL;`, hash, e.EndpointID, e.Pwd)
+		if err != nil {
+			return report, fmt.Errorf("failed to store hashed key of endpoint %d: %w", e.EndpointID, err)
+		}
+
+		report.Hashed = append(report.Hashed, e.EndpointID)
+	}
+
+	return report, nil
+}
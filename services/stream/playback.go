@@ -0,0 +1,211 @@
+package stream
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// PlaybackAudience is the audience of playback tokens, so they can't be
+	// mistaken for anything else signed with the same key
+	PlaybackAudience = "stream-playback"
+
+	// playbackTokenExpiry is how long a playback token can start playing for,
+	// it is only checked when playback starts
+	playbackTokenExpiry = 5 * time.Minute
+
+	// DefaultServiceTokenValidity and maxServiceTokenValidity are how long service
+	// tokens work for, they're left in the config of relays and restreams
+	DefaultServiceTokenValidity = 30 * 24 * time.Hour
+	maxServiceTokenValidity     = 365 * 24 * time.Hour
+)
+
+var (
+	// ErrChannelNotFound is returned when a channel doesn't exist or isn't fed by a stream endpoint
+	ErrChannelNotFound = errors.New("channel not found")
+	// ErrPlaybackDenied is returned when a stream can't be played without a valid token
+	ErrPlaybackDenied = errors.New("playback denied")
+	// ErrInvalidValidity is returned when a service token would work for too long
+	ErrInvalidValidity = errors.New("invalid token validity")
+)
+
+type (
+	// PlaybackClaims are the claims of a playback token, bound to a channel and
+	// the endpoint that feeds it. Service tokens are bound to the endpoint's
+	// stream instead of a channel.
+	PlaybackClaims struct {
+		Channel    string `json:"channel,omitempty"`
+		Stream     string `json:"stream,omitempty"`
+		EndpointID int    `json:"endpoint"`
+		jwt.RegisteredClaims
+	}
+
+	// PlaybackToken is a signed token to play a channel's stream, passed to the
+	// media server as the token query parameter
+	PlaybackToken struct {
+		Channel     string    `json:"channel,omitempty"`
+		Application string    `json:"application"`
+		Name        string    `json:"name"`
+		Token       string    `json:"token"`
+		ExpiresAt   time.Time `json:"expiresAt"`
+	}
+
+	// playbackChannel is a channel fed by a stream endpoint
+	playbackChannel struct {
+		URLName     string `db:"url_name"`
+		Visibility  string `db:"visibility"`
+		EndpointID  int    `db:"endpoint_id"`
+		Application string `db:"application"`
+		Name        string `db:"name"`
+	}
+)
+
+// IssuePlaybackToken signs a short-lived token for a user to play the stream feeding a channel
+func (s *Store) IssuePlaybackToken(ctx context.Context, key []byte, urlName string, userID int) (PlaybackToken, error) {
+	var ch playbackChannel
+
+	err := s.db.GetContext(ctx, &ch, `
+		SELECT channel.url_name, channel.visibility, endpoint.endpoint_id, endpoint.application, endpoint.name
+		FROM playout.channel channel
+		INNER JOIN web_api.stream_endpoints endpoint ON channel.endpoint_id = endpoint.endpoint_id
+		WHERE channel.url_name = $1;`, urlName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return PlaybackToken{}, ErrChannelNotFound
+		}
+		return PlaybackToken{}, fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	return signPlaybackToken(key, PlaybackClaims{
+		Channel:    ch.URLName,
+		EndpointID: ch.EndpointID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject: strconv.Itoa(userID),
+		},
+	}, ch.Application, ch.Name, playbackTokenExpiry)
+}
+
+// IssueServicePlaybackToken signs a token to play an endpoint's stream whatever
+// channels it feeds, for relays and restreams that pull it. Zero validity is
+// the default.
+func (s *Store) IssueServicePlaybackToken(ctx context.Context, key []byte, endpointID int, validity time.Duration) (PlaybackToken, error) {
+	if validity == 0 {
+		validity = DefaultServiceTokenValidity
+	}
+
+	if validity < 0 || validity > maxServiceTokenValidity {
+		return PlaybackToken{}, fmt.Errorf("%w: must be up to %s", ErrInvalidValidity, maxServiceTokenValidity)
+	}
+
+	var endpoint struct {
+		Application string `db:"application"`
+		Name        string `db:"name"`
+	}
+
+	err := s.db.GetContext(ctx, &endpoint, `
+		SELECT application, name
+		FROM web_api.stream_endpoints
+		WHERE endpoint_id = $1;`, endpointID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return PlaybackToken{}, ErrEndpointNotFound
+		}
+		return PlaybackToken{}, fmt.Errorf("failed to get endpoint: %w", err)
+	}
+
+	return signPlaybackToken(key, PlaybackClaims{
+		Stream:     endpoint.Application + "/" + endpoint.Name,
+		EndpointID: endpointID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject: "service",
+		},
+	}, endpoint.Application, endpoint.Name, validity)
+}
+
+// signPlaybackToken fills in the audience and times of a playback token and signs it
+func signPlaybackToken(key []byte, claims PlaybackClaims, application, name string, validity time.Duration) (PlaybackToken, error) {
+	now := time.Now()
+	expiresAt := now.Add(validity)
+
+	claims.Audience = jwt.ClaimStrings{PlaybackAudience}
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.ExpiresAt = jwt.NewNumericDate(expiresAt)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(key)
+	if err != nil {
+		return PlaybackToken{}, fmt.Errorf("failed to sign playback token: %w", err)
+	}
+
+	return PlaybackToken{
+		Channel:     claims.Channel,
+		Application: application,
+		Name:        name,
+		Token:       token,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// AuthorisePlay checks a stream can be played. Only streams feeding channels
+// that are all internal need a token, either a playback token for one of those
+// channels or a service token for the stream. Anything else, including streams
+// that aren't endpoints or don't feed a channel, can be played by anyone.
+func (s *Store) AuthorisePlay(ctx context.Context, key []byte, application, name, token string) error {
+	var channels []playbackChannel
+
+	err := s.db.SelectContext(ctx, &channels, `
+		SELECT channel.url_name, channel.visibility, endpoint.endpoint_id, endpoint.application, endpoint.name
+		FROM web_api.stream_endpoints endpoint
+		INNER JOIN playout.channel channel ON channel.endpoint_id = endpoint.endpoint_id
+		WHERE endpoint.application = $1 AND endpoint.name = $2;`, application, name)
+	if err != nil {
+		return fmt.Errorf("failed to get channels of stream: %w", err)
+	}
+
+	if len(channels) == 0 || slices.ContainsFunc(channels, func(ch playbackChannel) bool {
+		return ch.Visibility == "public" || ch.Visibility == "unlisted"
+	}) {
+		return nil
+	}
+
+	if token == "" {
+		return fmt.Errorf("%w: %s/%s needs a playback token", ErrPlaybackDenied, application, name)
+	}
+
+	claims := &PlaybackClaims{}
+
+	_, err = jwt.ParseWithClaims(token, claims, func(_ *jwt.Token) (interface{}, error) {
+		return key, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithAudience(PlaybackAudience),
+		jwt.WithExpirationRequired())
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrPlaybackDenied, err)
+	}
+
+	if claims.EndpointID != channels[0].EndpointID {
+		return fmt.Errorf("%w: token is for another endpoint than %s/%s", ErrPlaybackDenied, application, name)
+	}
+
+	// Service tokens are good for the stream, anything else only for the channel it was issued for
+	if claims.Stream != "" {
+		if claims.Stream != application+"/"+name {
+			return fmt.Errorf("%w: token is for stream %s, not %s/%s", ErrPlaybackDenied, claims.Stream, application, name)
+		}
+		return nil
+	}
+
+	if !slices.ContainsFunc(channels, func(ch playbackChannel) bool {
+		return ch.URLName == claims.Channel
+	}) {
+		return fmt.Errorf("%w: token is for channel %s, which %s/%s doesn't feed", ErrPlaybackDenied, claims.Channel, application, name)
+	}
+
+	return nil
+}
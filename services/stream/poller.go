@@ -0,0 +1,437 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"gopkg.in/guregu/null.v4"
+)
+
+const (
+	SessionEndReconciled = "reconciled"
+
+	// reconcileGrace stops an endpoint that has just published being taken off
+	// air before the media servers have started reporting it
+	reconcileGrace = time.Minute
+
+	// metricRetention is how long polled stream metrics are kept
+	metricRetention = 7 * 24 * time.Hour
+
+	// srsStreamCount is how many streams are asked for from SRS, it only returns 10 by default
+	srsStreamCount = "1000"
+)
+
+type (
+	// PollerConfig sets where the media servers report what is live
+	PollerConfig struct {
+		// NginxStatURLs are nginx-rtmp stat pages, served by rtmp_stat all
+		NginxStatURLs []string
+		// SRSAPIURLs are SRS HTTP API stream lists, /api/v1/streams
+		SRSAPIURLs []string
+		Interval   time.Duration
+	}
+
+	// LiveStream is a stream a media server reports as publishing
+	LiveStream struct {
+		Application string  `json:"application"`
+		Name        string  `json:"name"`
+		Server      string  `json:"server"`
+		BitrateKbps int     `json:"bitrateKbps"`
+		Width       int     `json:"width"`
+		Height      int     `json:"height"`
+		FrameRate   float64 `json:"frameRate"`
+		VideoCodec  string  `json:"videoCodec"`
+		AudioCodec  string  `json:"audioCodec"`
+		// Clients is the number of viewers, not including the publisher
+		Clients int `json:"clients"`
+	}
+
+	// PollReport is what a poll of the media servers found and changed
+	PollReport struct {
+		Live []LiveStream `json:"live"`
+		// Activated are endpoints that were live without being marked active
+		Activated []int `json:"activated"`
+		// Deactivated are endpoints marked active that weren't live, they're
+		// only changed when every media server could be polled
+		Deactivated []int `json:"deactivated"`
+		// Refused are endpoints that were live but blocked or outside their
+		// validity window, so weren't activated
+		Refused []int   `json:"refused"`
+		Errors  []error `json:"-"`
+	}
+
+	// Metric is a sample of a live stream
+	Metric struct {
+		MetricID    int       `json:"metricId" db:"metric_id"`
+		SessionID   *int      `json:"sessionId" db:"session_id"`
+		Server      string    `json:"server" db:"server"`
+		RecordedAt  time.Time `json:"recordedAt" db:"recorded_at"`
+		BitrateKbps int       `json:"bitrateKbps" db:"bitrate_kbps"`
+		Width       int       `json:"width" db:"width"`
+		Height      int       `json:"height" db:"height"`
+		FrameRate   float64   `json:"frameRate" db:"frame_rate"`
+		VideoCodec  string    `json:"videoCodec" db:"video_codec"`
+		AudioCodec  string    `json:"audioCodec" db:"audio_codec"`
+		Clients     int       `json:"clients" db:"clients"`
+	}
+
+	nginxStat struct {
+		XMLName xml.Name `xml:"rtmp"`
+		Servers []struct {
+			Applications []struct {
+				Name    string        `xml:"name"`
+				Streams []nginxStream `xml:"live>stream"`
+			} `xml:"application"`
+		} `xml:"server"`
+	}
+
+	nginxStream struct {
+		Name string `xml:"name"`
+		// BWIn is in bits per second
+		BWIn       int64     `xml:"bw_in"`
+		NClients   int       `xml:"nclients"`
+		Publishing *struct{} `xml:"publishing"`
+		Video      struct {
+			Width     int     `xml:"width"`
+			Height    int     `xml:"height"`
+			FrameRate float64 `xml:"frame_rate"`
+			Codec     string  `xml:"codec"`
+		} `xml:"meta>video"`
+		Audio struct {
+			Codec string `xml:"codec"`
+		} `xml:"meta>audio"`
+	}
+
+	srsStreams struct {
+		Code    int `json:"code"`
+		Streams []struct {
+			Name    string `json:"name"`
+			App     string `json:"app"`
+			Clients int    `json:"clients"`
+			Kbps    struct {
+				Recv30s int `json:"recv_30s"`
+			} `json:"kbps"`
+			Publish struct {
+				Active bool `json:"active"`
+			} `json:"publish"`
+			Video *struct {
+				Codec  string `json:"codec"`
+				Width  int    `json:"width"`
+				Height int    `json:"height"`
+			} `json:"video"`
+			Audio *struct {
+				Codec string `json:"codec"`
+			} `json:"audio"`
+		} `json:"streams"`
+	}
+)
+
+var pollClient = &http.Client{Timeout: 10 * time.Second}
+
+// Poll asks the media servers what is live, marks endpoints active or inactive
+// to match, and records a metric of every live endpoint
+func (s *Store) Poll(ctx context.Context, conf PollerConfig) (PollReport, error) {
+	var report PollReport
+
+	for _, u := range conf.NginxStatURLs {
+		live, err := fetchNginxStat(ctx, u)
+		if err != nil {
+			report.Errors = append(report.Errors, err)
+			continue
+		}
+		report.Live = append(report.Live, live...)
+	}
+
+	for _, u := range conf.SRSAPIURLs {
+		live, err := fetchSRSStreams(ctx, u)
+		if err != nil {
+			report.Errors = append(report.Errors, err)
+			continue
+		}
+		report.Live = append(report.Live, live...)
+	}
+
+	var endpoints []struct {
+		EndpointID  int       `db:"endpoint_id"`
+		Application string    `db:"application"`
+		Name        string    `db:"name"`
+		Active      bool      `db:"active"`
+		Blocked     bool      `db:"blocked"`
+		StartValid  null.Time `db:"start_valid"`
+		EndValid    null.Time `db:"end_valid"`
+		SessionID   *int      `db:"session_id"`
+		// Recent is set when the open session started within the grace period
+		Recent bool `db:"recent"`
+	}
+
+	err := s.db.SelectContext(ctx, &endpoints, `
+		SELECT endpoint.endpoint_id, endpoint.application, endpoint.name, endpoint.active,
+			endpoint.blocked, endpoint.start_valid, endpoint.end_valid, session.session_id, COALESCE(session.started_at > NOW() - make_interval(secs => $1), false) AS recent
+		FROM web_api.stream_endpoints endpoint
+		LEFT JOIN LATERAL (
+			SELECT session_id, started_at
+			FROM web_api.stream_sessions
+			WHERE endpoint_id = endpoint.endpoint_id AND ended_at IS NULL
+			ORDER BY started_at DESC
+			LIMIT 1
+		) session ON true;`, reconcileGrace.Seconds())
+	if err != nil {
+		return report, fmt.Errorf("failed to get endpoints: %w", err)
+	}
+
+	live := make(map[[2]string]LiveStream, len(report.Live))
+	for _, l := range report.Live {
+		live[[2]string{l.Application, l.Name}] = l
+	}
+
+	now := time.Now()
+
+	for _, e := range endpoints {
+		l, isLive := live[[2]string{e.Application, e.Name}]
+
+		// Blocked and expired endpoints are refused on publish, so they aren't
+		// brought back just because the encoder is still connected
+		allowed := !e.Blocked && EndpointDB{StartValid: e.StartValid, EndValid: e.EndValid}.ValidAt(now)
+
+		switch {
+		case isLive && !e.Active && !allowed:
+			report.Refused = append(report.Refused, e.EndpointID)
+		case isLive && !e.Active:
+			_, err = s.db.ExecContext(ctx, `
+				UPDATE web_api.stream_endpoints SET active = true WHERE endpoint_id = $1;`, e.EndpointID)
+			if err != nil {
+				return report, fmt.Errorf("failed to activate endpoint %d: %w", e.EndpointID, err)
+			}
+
+			sessionID, err := s.StartSession(ctx, SessionStart{
+				EndpointID:  e.EndpointID,
+				Application: e.Application,
+				Name:        e.Name,
+				Server:      l.Server,
+			})
+			if err != nil {
+				return report, err
+			}
+			e.SessionID = &sessionID
+
+			_, err = s.SetChannelsPublished(ctx, e.EndpointID)
+			if err != nil {
+				return report, err
+			}
+
+			report.Activated = append(report.Activated, e.EndpointID)
+		case !isLive && e.Active && !e.Recent && len(report.Errors) == 0:
+			_, err = s.db.ExecContext(ctx, `
+				UPDATE web_api.stream_endpoints SET active = false WHERE endpoint_id = $1;`, e.EndpointID)
+			if err != nil {
+				return report, fmt.Errorf("failed to deactivate endpoint %d: %w", e.EndpointID, err)
+			}
+
+			err = s.EndSession(ctx, e.Application, e.Name, SessionEndReconciled)
+			if err != nil {
+				return report, err
+			}
+
+			_, err = s.SetChannelsUnpublished(ctx, e.Application, e.Name)
+			if err != nil {
+				return report, err
+			}
+
+			report.Deactivated = append(report.Deactivated, e.EndpointID)
+		}
+
+		if !isLive {
+			continue
+		}
+
+		_, err = s.db.ExecContext(ctx, `
+			INSERT INTO web_api.stream_metrics (endpoint_id, session_id, server, bitrate_kbps,
+				width, height, frame_rate, video_codec, audio_codec, clients)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);`, e.EndpointID, e.SessionID, l.Server,
+			l.BitrateKbps, l.Width, l.Height, l.FrameRate, l.VideoCodec, l.AudioCodec, l.Clients)
+		if err != nil {
+			return report, fmt.Errorf("failed to record metric of endpoint %d: %w", e.EndpointID, err)
+		}
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		DELETE FROM web_api.stream_metrics
+		WHERE recorded_at < NOW() - make_interval(secs => $1);`, metricRetention.Seconds())
+	if err != nil {
+		return report, fmt.Errorf("failed to delete old metrics: %w", err)
+	}
+
+	return report, nil
+}
+
+// RunPoller polls the media servers every interval until the context is done
+func (s *Store) RunPoller(ctx context.Context, conf PollerConfig) {
+	ticker := time.NewTicker(conf.Interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := s.Poll(ctx, conf)
+		if err != nil {
+			log.Printf("stream poller failed: %+v", err)
+		}
+
+		if len(report.Errors) > 0 {
+			log.Printf("stream poller couldn't reach every media server, not deactivating: %+v", errors.Join(report.Errors...))
+		}
+
+		if len(report.Activated) > 0 || len(report.Deactivated) > 0 {
+			log.Printf("stream poller: activated %v, deactivated %v", report.Activated, report.Deactivated)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ListMetrics lists the metrics of an endpoint recorded since a time, oldest first
+func (s *Store) ListMetrics(ctx context.Context, endpointID int, since time.Time) ([]Metric, error) {
+	var m []Metric
+
+	err := s.db.SelectContext(ctx, &m, `
+		SELECT metric_id, session_id, server, recorded_at, bitrate_kbps, width, height,
+			frame_rate, video_codec, audio_codec, clients
+		FROM web_api.stream_metrics
+		WHERE endpoint_id = $1 AND recorded_at >= $2
+		ORDER BY recorded_at;`, endpointID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metrics: %w", err)
+	}
+
+	return m, nil
+}
+
+// fetchNginxStat reads the publishing streams from an nginx-rtmp stat page
+func fetchNginxStat(ctx context.Context, u string) ([]LiveStream, error) {
+	res, err := pollGet(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var stat nginxStat
+
+	err = xml.NewDecoder(res.Body).Decode(&stat)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode nginx-rtmp stat from %s: %w", u, err)
+	}
+
+	var live []LiveStream
+
+	for _, server := range stat.Servers {
+		for _, app := range server.Applications {
+			for _, st := range app.Streams {
+				if st.Publishing == nil {
+					continue
+				}
+
+				live = append(live, LiveStream{
+					Application: app.Name,
+					Name:        st.Name,
+					Server:      SessionServerNginx,
+					BitrateKbps: int(st.BWIn / 1000),
+					Width:       st.Video.Width,
+					Height:      st.Video.Height,
+					FrameRate:   st.Video.FrameRate,
+					VideoCodec:  st.Video.Codec,
+					AudioCodec:  st.Audio.Codec,
+					Clients:     max(st.NClients-1, 0),
+				})
+			}
+		}
+	}
+
+	return live, nil
+}
+
+// fetchSRSStreams reads the publishing streams from the SRS HTTP API
+func fetchSRSStreams(ctx context.Context, u string) ([]LiveStream, error) {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SRS API URL %s: %w", u, err)
+	}
+
+	q := parsed.Query()
+	if !q.Has("count") {
+		q.Set("count", srsStreamCount)
+		parsed.RawQuery = q.Encode()
+	}
+
+	res, err := pollGet(ctx, parsed.String())
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var streams srsStreams
+
+	err = json.NewDecoder(res.Body).Decode(&streams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode SRS streams from %s: %w", u, err)
+	}
+
+	if streams.Code != 0 {
+		return nil, fmt.Errorf("SRS streams from %s returned code %d", u, streams.Code)
+	}
+
+	var live []LiveStream
+
+	for _, st := range streams.Streams {
+		if !st.Publish.Active {
+			continue
+		}
+
+		l := LiveStream{
+			Application: st.App,
+			Name:        st.Name,
+			Server:      SessionServerSRS,
+			BitrateKbps: st.Kbps.Recv30s,
+			Clients:     max(st.Clients-1, 0),
+		}
+
+		if st.Video != nil {
+			l.Width, l.Height, l.VideoCodec = st.Video.Width, st.Video.Height, st.Video.Codec
+		}
+
+		if st.Audio != nil {
+			l.AudioCodec = st.Audio.Codec
+		}
+
+		live = append(live, l)
+	}
+
+	return live, nil
+}
+
+func pollGet(ctx context.Context, u string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request to %s: %w", u, err)
+	}
+
+	res, err := pollClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to poll %s: %w", u, err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, fmt.Errorf("failed to poll %s: status %d", u, res.StatusCode)
+	}
+
+	return res, nil
+}
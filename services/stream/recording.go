@@ -0,0 +1,101 @@
+package stream
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRecording is returned when a recording isn't somewhere it can be registered from
+var ErrInvalidRecording = errors.New("invalid recording")
+
+// nonURLName is everything that can't be in a video's URL name
+var nonURLName = regexp.MustCompile(`[^a-z0-9]+`)
+
+type (
+	// RecordingConfig is where media servers write recordings and what they become.
+	// Root is the directory the recordings bucket is mounted at on the media
+	// servers, so a recording's path below it is its key.
+	RecordingConfig struct {
+		Root     string
+		Bucket   string
+		SeriesID int
+		// PresetID is applied to the draft videos, they aren't encoded without one
+		PresetID *int
+		// Secret is shared with the media servers, which pass it to the recording hook
+		Secret string
+	}
+
+	// RecordingSource is the stream a recording was made from, named after the
+	// channel it fed when there is one
+	RecordingSource struct {
+		Title     string
+		URLName   string
+		StartedAt time.Time
+	}
+)
+
+// Enabled is true when recordings are made into videos
+func (c RecordingConfig) Enabled() bool {
+	return c.Bucket != "" && c.SeriesID != 0 && c.Secret != ""
+}
+
+// Authorised is true when the recording hook was given the shared secret
+func (c RecordingConfig) Authorised(secret string) bool {
+	return c.Secret != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(c.Secret)) == 1
+}
+
+// URI maps a recording's path on a media server to its "bucket/key"
+func (c RecordingConfig) URI(path string) (string, error) {
+	key, err := filepath.Rel(filepath.Clean(c.Root), filepath.Clean(path))
+	if err != nil || key == "." || strings.HasPrefix(key, "..") {
+		return "", fmt.Errorf("%w: \"%s\" isn't in %s", ErrInvalidRecording, path, c.Root)
+	}
+
+	return c.Bucket + "/" + filepath.ToSlash(key), nil
+}
+
+// GetRecordingSource finds the most recent session of a stream, which is the one
+// a recording finishing now was made from. A stream without a session is taken
+// to have started now.
+func (s *Store) GetRecordingSource(ctx context.Context, application, name string) (RecordingSource, error) {
+	source := RecordingSource{
+		Title:     application + "/" + name,
+		StartedAt: time.Now(),
+	}
+
+	var session struct {
+		StartedAt time.Time `db:"started_at"`
+		Channel   string    `db:"channel"`
+	}
+
+	err := s.db.GetContext(ctx, &session, `
+		SELECT session.started_at, COALESCE(MIN(channel.name), '') AS channel
+		FROM web_api.stream_sessions session
+		LEFT JOIN playout.channel channel ON channel.endpoint_id = session.endpoint_id
+		WHERE session.application = $1 AND session.name = $2
+		GROUP BY session.session_id
+		ORDER BY session.started_at DESC
+		LIMIT 1;`, application, name)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return RecordingSource{}, fmt.Errorf("failed to get session of recording: %w", err)
+	}
+
+	if err == nil {
+		source.StartedAt = session.StartedAt
+		if session.Channel != "" {
+			source.Title = session.Channel
+		}
+	}
+
+	source.Title = fmt.Sprintf("%s %s", source.Title, source.StartedAt.Format("2006-01-02 15:04"))
+	source.URLName = strings.Trim(nonURLName.ReplaceAllString(strings.ToLower(source.Title), "-"), "-")
+
+	return source, nil
+}
@@ -0,0 +1,428 @@
+package stream
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/ystv/web-api/services/creator/encode"
+)
+
+// maxRestreamFieldLength is the longest name and command the streams tables hold
+const maxRestreamFieldLength = 256
+
+var (
+	// ErrRestreamNotFound is returned when an incoming stream, provider, quality or mapping doesn't exist
+	ErrRestreamNotFound = errors.New("restream item not found")
+	// ErrInvalidRestream is returned when an incoming stream, provider, quality or mapping is invalid
+	ErrInvalidRestream = errors.New("invalid restream item")
+	// ErrRestreamInUse is returned when deleting something a mapping still uses
+	ErrRestreamInUse = errors.New("restream item is used by a mapping")
+)
+
+type (
+	// Incoming is a stream coming into the media servers that can be restreamed,
+	// its name is the application and stream, e.g. live/studio
+	Incoming struct {
+		IncomingID int    `json:"incomingId" db:"i_id"`
+		Name       string `json:"name" db:"i_name"`
+	}
+
+	// Provider is where a transcode is pushed to, such as YouTube or Twitch
+	Provider struct {
+		ProviderID int `json:"providerId" db:"p_id"`
+		// User is the account the stream goes out on
+		User string `json:"user" db:"p_user"`
+		// Host is the RTMP URL to push to, without the key
+		Host string `json:"host" db:"p_host"`
+		// Key is the provider's stream key, it's masked when listed
+		Key string `json:"key" db:"p_key"`
+	}
+
+	// Quality is a named set of ffmpeg output options
+	Quality struct {
+		QualityID int    `json:"qualityId" db:"q_id"`
+		Name      string `json:"name" db:"q_name"`
+		Command   string `json:"command" db:"q_cmd"`
+	}
+
+	// TranscodeMapping sends an incoming stream at a quality to a provider
+	TranscodeMapping struct {
+		MappingID  int `json:"mappingId" db:"m_id"`
+		IncomingID int `json:"incomingId" db:"m_incoming_id"`
+		ProviderID int `json:"providerId" db:"m_provider_id"`
+		QualityID  int `json:"qualityId" db:"m_quality_id"`
+		// Stream, Transcoder and Quality name the mapping, as the "All transcodes" view
+		Stream      string `json:"stream" db:"stream"`
+		Transcoder  string `json:"transcoder" db:"transcoder"`
+		QualityName string `json:"quality" db:"quality"`
+	}
+
+	// TranscodeMappingAddEdit sets what a mapping connects
+	TranscodeMappingAddEdit struct {
+		IncomingID int `json:"incomingId"`
+		ProviderID int `json:"providerId"`
+		QualityID  int `json:"qualityId"`
+	}
+)
+
+// ListIncoming lists the incoming streams
+func (s *Store) ListIncoming(ctx context.Context) ([]Incoming, error) {
+	var i []Incoming
+
+	err := s.db.SelectContext(ctx, &i, `
+		SELECT i_id, COALESCE(i_name, '') AS i_name
+		FROM streams.incoming
+		ORDER BY i_name;`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incoming streams: %w", err)
+	}
+
+	return i, nil
+}
+
+// AddIncoming adds an incoming stream
+func (s *Store) AddIncoming(ctx context.Context, i Incoming) (Incoming, error) {
+	err := validateIncoming(i)
+	if err != nil {
+		return Incoming{}, err
+	}
+
+	err = s.db.GetContext(ctx, &i.IncomingID, `
+		INSERT INTO streams.incoming (i_name)
+		VALUES ($1)
+		RETURNING i_id;`, i.Name)
+	if err != nil {
+		return Incoming{}, fmt.Errorf("failed to add incoming stream: %w", err)
+	}
+
+	return i, nil
+}
+
+// EditIncoming renames an incoming stream
+func (s *Store) EditIncoming(ctx context.Context, i Incoming) (Incoming, error) {
+	err := validateIncoming(i)
+	if err != nil {
+		return Incoming{}, err
+	}
+
+	err = s.execOne(ctx, `
+		UPDATE streams.incoming SET i_name = $1
+		WHERE i_id = $2;`, i.Name, i.IncomingID)
+	if err != nil {
+		return Incoming{}, fmt.Errorf("failed to edit incoming stream: %w", err)
+	}
+
+	return i, nil
+}
+
+// DeleteIncoming deletes an incoming stream, it can't be used by a mapping
+func (s *Store) DeleteIncoming(ctx context.Context, incomingID int) error {
+	err := s.execOne(ctx, `DELETE FROM streams.incoming WHERE i_id = $1;`, incomingID)
+	if err != nil {
+		return fmt.Errorf("failed to delete incoming stream: %w", err)
+	}
+
+	return nil
+}
+
+// ListProviders lists the providers, with their keys masked
+func (s *Store) ListProviders(ctx context.Context) ([]Provider, error) {
+	var p []Provider
+
+	err := s.db.SelectContext(ctx, &p, `
+		SELECT p_id, COALESCE(p_user, '') AS p_user, COALESCE(p_host, '') AS p_host,
+			CASE WHEN COALESCE(p_key, '') = '' THEN '' ELSE $1 END AS p_key
+		FROM streams.provider
+		ORDER BY p_host, p_user;`, MaskedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get providers: %w", err)
+	}
+
+	return p, nil
+}
+
+// AddProvider adds a provider
+func (s *Store) AddProvider(ctx context.Context, p Provider) (Provider, error) {
+	err := validateProvider(p)
+	if err != nil {
+		return Provider{}, err
+	}
+
+	err = s.db.GetContext(ctx, &p.ProviderID, `
+		INSERT INTO streams.provider (p_user, p_host, p_key)
+		VALUES ($1, $2, $3)
+		RETURNING p_id;`, p.User, p.Host, p.Key)
+	if err != nil {
+		return Provider{}, fmt.Errorf("failed to add provider: %w", err)
+	}
+
+	return maskProvider(p), nil
+}
+
+// EditProvider edits a provider, the key is kept when it's left empty or masked
+func (s *Store) EditProvider(ctx context.Context, p Provider) (Provider, error) {
+	err := validateProvider(p)
+	if err != nil {
+		return Provider{}, err
+	}
+
+	if p.Key == MaskedKey {
+		p.Key = ""
+	}
+
+	err = s.db.GetContext(ctx, &p, `
+		UPDATE streams.provider SET p_user = $1, p_host = $2, p_key = COALESCE(NULLIF($3, ''), p_key)
+		WHERE p_id = $4
+		RETURNING p_id, p_user, p_host, COALESCE(p_key, '') AS p_key;`, p.User, p.Host, p.Key, p.ProviderID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Provider{}, ErrRestreamNotFound
+		}
+		return Provider{}, fmt.Errorf("failed to edit provider: %w", err)
+	}
+
+	return maskProvider(p), nil
+}
+
+// DeleteProvider deletes a provider, it can't be used by a mapping
+func (s *Store) DeleteProvider(ctx context.Context, providerID int) error {
+	err := s.execOne(ctx, `DELETE FROM streams.provider WHERE p_id = $1;`, providerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete provider: %w", err)
+	}
+
+	return nil
+}
+
+// ListQualities lists the qualities
+func (s *Store) ListQualities(ctx context.Context) ([]Quality, error) {
+	var q []Quality
+
+	err := s.db.SelectContext(ctx, &q, `
+		SELECT q_id, COALESCE(q_name, '') AS q_name, COALESCE(q_cmd, '') AS q_cmd
+		FROM streams.qualities
+		ORDER BY q_id;`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get qualities: %w", err)
+	}
+
+	return q, nil
+}
+
+// AddQuality adds a quality
+func (s *Store) AddQuality(ctx context.Context, q Quality) (Quality, error) {
+	err := validateQuality(q)
+	if err != nil {
+		return Quality{}, err
+	}
+
+	err = s.db.GetContext(ctx, &q.QualityID, `
+		INSERT INTO streams.qualities (q_name, q_cmd)
+		VALUES ($1, $2)
+		RETURNING q_id;`, q.Name, q.Command)
+	if err != nil {
+		return Quality{}, fmt.Errorf("failed to add quality: %w", err)
+	}
+
+	return q, nil
+}
+
+// EditQuality edits a quality
+func (s *Store) EditQuality(ctx context.Context, q Quality) (Quality, error) {
+	err := validateQuality(q)
+	if err != nil {
+		return Quality{}, err
+	}
+
+	err = s.execOne(ctx, `
+		UPDATE streams.qualities SET q_name = $1, q_cmd = $2
+		WHERE q_id = $3;`, q.Name, q.Command, q.QualityID)
+	if err != nil {
+		return Quality{}, fmt.Errorf("failed to edit quality: %w", err)
+	}
+
+	return q, nil
+}
+
+// DeleteQuality deletes a quality, it can't be used by a mapping
+func (s *Store) DeleteQuality(ctx context.Context, qualityID int) error {
+	err := s.execOne(ctx, `DELETE FROM streams.qualities WHERE q_id = $1;`, qualityID)
+	if err != nil {
+		return fmt.Errorf("failed to delete quality: %w", err)
+	}
+
+	return nil
+}
+
+// ListTranscodeMappings lists the mappings, optionally only those of an incoming stream
+func (s *Store) ListTranscodeMappings(ctx context.Context, incomingID *int) ([]TranscodeMapping, error) {
+	var m []TranscodeMapping
+
+	err := s.db.SelectContext(ctx, &m, `
+		SELECT map.m_id, map.m_incoming_id, map.m_provider_id, map.m_quality_id,
+			COALESCE(incoming.i_name, '') AS stream, COALESCE(provider.p_host, '') AS transcoder,
+			COALESCE(quality.q_name, '') AS quality
+		FROM streams.transcode_map map
+		INNER JOIN streams.incoming incoming ON map.m_incoming_id = incoming.i_id
+		INNER JOIN streams.qualities quality ON map.m_quality_id = quality.q_id
+		INNER JOIN streams.provider provider ON map.m_provider_id = provider.p_id
+		WHERE $1::integer IS NULL OR map.m_incoming_id = $1
+		ORDER BY incoming.i_name, quality.q_id;`, incomingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transcode mappings: %w", err)
+	}
+
+	return m, nil
+}
+
+// AddTranscodeMapping maps an incoming stream at a quality to a provider
+func (s *Store) AddTranscodeMapping(ctx context.Context, m TranscodeMappingAddEdit) (TranscodeMapping, error) {
+	var mappingID int
+
+	err := s.db.GetContext(ctx, &mappingID, `
+		INSERT INTO streams.transcode_map (m_incoming_id, m_provider_id, m_quality_id)
+		VALUES ($1, $2, $3)
+		RETURNING m_id;`, m.IncomingID, m.ProviderID, m.QualityID)
+	if err != nil {
+		return TranscodeMapping{}, fmt.Errorf("failed to add transcode mapping: %w", restreamError(err))
+	}
+
+	return s.getTranscodeMapping(ctx, mappingID)
+}
+
+// EditTranscodeMapping changes what a mapping connects
+func (s *Store) EditTranscodeMapping(ctx context.Context, mappingID int, m TranscodeMappingAddEdit) (TranscodeMapping, error) {
+	err := s.execOne(ctx, `
+		UPDATE streams.transcode_map SET m_incoming_id = $1, m_provider_id = $2, m_quality_id = $3
+		WHERE m_id = $4;`, m.IncomingID, m.ProviderID, m.QualityID, mappingID)
+	if err != nil {
+		return TranscodeMapping{}, fmt.Errorf("failed to edit transcode mapping: %w", err)
+	}
+
+	return s.getTranscodeMapping(ctx, mappingID)
+}
+
+// DeleteTranscodeMapping deletes a mapping
+func (s *Store) DeleteTranscodeMapping(ctx context.Context, mappingID int) error {
+	err := s.execOne(ctx, `DELETE FROM streams.transcode_map WHERE m_id = $1;`, mappingID)
+	if err != nil {
+		return fmt.Errorf("failed to delete transcode mapping: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store) getTranscodeMapping(ctx context.Context, mappingID int) (TranscodeMapping, error) {
+	var m TranscodeMapping
+
+	err := s.db.GetContext(ctx, &m, `
+		SELECT map.m_id, map.m_incoming_id, map.m_provider_id, map.m_quality_id,
+			COALESCE(incoming.i_name, '') AS stream, COALESCE(provider.p_host, '') AS transcoder,
+			COALESCE(quality.q_name, '') AS quality
+		FROM streams.transcode_map map
+		INNER JOIN streams.incoming incoming ON map.m_incoming_id = incoming.i_id
+		INNER JOIN streams.qualities quality ON map.m_quality_id = quality.q_id
+		INNER JOIN streams.provider provider ON map.m_provider_id = provider.p_id
+		WHERE map.m_id = $1;`, mappingID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return TranscodeMapping{}, ErrRestreamNotFound
+		}
+		return TranscodeMapping{}, fmt.Errorf("failed to get transcode mapping: %w", err)
+	}
+
+	return m, nil
+}
+
+// execOne runs a statement that should change exactly one row
+func (s *Store) execOne(ctx context.Context, query string, args ...interface{}) error {
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return restreamError(err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows < 1 {
+		return ErrRestreamNotFound
+	}
+
+	return nil
+}
+
+// restreamError turns the streams tables' foreign key errors into ours
+func restreamError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || pqErr.Code.Name() != "foreign_key_violation" {
+		return err
+	}
+
+	// Deleting something still referenced, or referencing something that doesn't exist
+	if strings.HasPrefix(pqErr.Message, "update or delete") {
+		return fmt.Errorf("%w: %s", ErrRestreamInUse, pqErr.Detail)
+	}
+
+	return fmt.Errorf("%w: %s", ErrInvalidRestream, pqErr.Detail)
+}
+
+func validateIncoming(i Incoming) error {
+	switch {
+	case i.Name == "":
+		return fmt.Errorf("%w: name must be set", ErrInvalidRestream)
+	case len(i.Name) > maxRestreamFieldLength:
+		return fmt.Errorf("%w: name must be at most %d characters", ErrInvalidRestream, maxRestreamFieldLength)
+	case !strings.Contains(strings.Trim(i.Name, "/"), "/"):
+		return fmt.Errorf("%w: name must be the application and stream, e.g. live/studio", ErrInvalidRestream)
+	case strings.ContainsAny(i.Name, srsUnsafe):
+		return fmt.Errorf("%w: name can't have spaces, quotes or any of ;{}#\\", ErrInvalidRestream)
+	}
+
+	return nil
+}
+
+func validateProvider(p Provider) error {
+	switch {
+	case p.Host == "":
+		return fmt.Errorf("%w: host must be set", ErrInvalidRestream)
+	case !strings.HasPrefix(p.Host, "rtmp://") && !strings.HasPrefix(p.Host, "rtmps://"):
+		return fmt.Errorf("%w: host must be an rtmp:// or rtmps:// URL", ErrInvalidRestream)
+	case strings.ContainsAny(p.Host, srsUnsafe) || strings.ContainsAny(p.Key, srsUnsafe):
+		return fmt.Errorf("%w: host and key can't have spaces, quotes or any of ;{}#\\", ErrInvalidRestream)
+	}
+
+	return nil
+}
+
+func validateQuality(q Quality) error {
+	switch {
+	case q.Name == "":
+		return fmt.Errorf("%w: name must be set", ErrInvalidRestream)
+	case len(q.Name) > maxRestreamFieldLength || len(q.Command) > maxRestreamFieldLength:
+		return fmt.Errorf("%w: name and command must be at most %d characters", ErrInvalidRestream, maxRestreamFieldLength)
+	case strings.ContainsAny(q.Name, "\r\n"):
+		return fmt.Errorf("%w: name must be one line", ErrInvalidRestream)
+	}
+
+	_, err := encode.ParseArguments(q.Command)
+	if err != nil {
+		return fmt.Errorf("%w: command: %w", ErrInvalidRestream, err)
+	}
+
+	return nil
+}
+
+func maskProvider(p Provider) Provider {
+	if p.Key != "" {
+		p.Key = MaskedKey
+	}
+
+	return p
+}
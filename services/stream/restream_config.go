@@ -0,0 +1,332 @@
+package stream
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ystv/web-api/services/creator/encode"
+)
+
+const (
+	RestreamFormatFFmpeg = "ffmpeg"
+	RestreamFormatSRS    = "srs"
+
+	// srsUnsafe are the characters that end or open SRS directives and blocks, or
+	// split their arguments, so values holding them can't go in an SRS config
+	srsUnsafe = ";{}#\"'\\ \t\r\n"
+)
+
+// restreamTarget is a mapping with everything needed to push it
+type restreamTarget struct {
+	MappingID int    `db:"m_id"`
+	Host      string `db:"p_host"`
+	Key       string `db:"p_key"`
+	Quality   string `db:"q_name"`
+	Command   string `db:"q_cmd"`
+}
+
+// srsEngineOptions are ffmpeg options SRS transcode engines have their own directive for
+var srsEngineOptions = map[string]string{
+	"c:v": "vcodec", "vcodec": "vcodec", "codec:v": "vcodec",
+	"b:v": "vbitrate", "r": "vfps", "preset": "vpreset", "profile:v": "vprofile",
+	"c:a": "acodec", "acodec": "acodec", "codec:a": "acodec",
+	"b:a": "abitrate", "ar": "asample_rate", "ac": "achannels", "f": "oformat",
+}
+
+// RenderRestreamConfig renders what a media server needs to restream an incoming
+// stream to every provider it's mapped to. The ffmpeg format is a shell script
+// running one ffmpeg with an output per mapping, the srs format is a transcode
+// section for the SRS config. When the incoming stream is an endpoint, the ffmpeg
+// source carries a service playback token signed with playbackKey, so it can be
+// pulled whichever channels it feeds.
+func (s *Store) RenderRestreamConfig(ctx context.Context, incomingID int, format string, ingest IngestConfig, playbackKey []byte) (string, error) {
+	if format != RestreamFormatFFmpeg && format != RestreamFormatSRS {
+		return "", fmt.Errorf("%w: format must be ffmpeg or srs", ErrInvalidRestream)
+	}
+
+	var name string
+
+	err := s.db.GetContext(ctx, &name, `
+		SELECT COALESCE(i_name, '')
+		FROM streams.incoming
+		WHERE i_id = $1;`, incomingID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrRestreamNotFound
+		}
+		return "", fmt.Errorf("failed to get incoming stream: %w", err)
+	}
+
+	var targets []restreamTarget
+
+	err = s.db.SelectContext(ctx, &targets, `
+		SELECT map.m_id, COALESCE(provider.p_host, '') AS p_host, COALESCE(provider.p_key, '') AS p_key,
+			COALESCE(quality.q_name, '') AS q_name, COALESCE(quality.q_cmd, '') AS q_cmd
+		FROM streams.transcode_map map
+		INNER JOIN streams.qualities quality ON map.m_quality_id = quality.q_id
+		INNER JOIN streams.provider provider ON map.m_provider_id = provider.p_id
+		WHERE map.m_incoming_id = $1
+		ORDER BY quality.q_id, map.m_id;`, incomingID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get transcode mappings: %w", err)
+	}
+
+	var b strings.Builder
+
+	if format == RestreamFormatSRS {
+		err = renderSRSTranscode(&b, name, targets)
+	} else {
+		var token string
+
+		token, err = s.restreamSourceToken(ctx, name, playbackKey)
+		if err != nil {
+			return "", err
+		}
+
+		err = renderFFmpegRestream(&b, name, token, targets, ingest)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+// restreamSourceToken signs a service playback token for the endpoint an incoming
+// stream is, it's empty when the stream isn't an endpoint
+func (s *Store) restreamSourceToken(ctx context.Context, name string, playbackKey []byte) (string, error) {
+	application, streamName, _ := strings.Cut(strings.Trim(name, "/"), "/")
+
+	var endpointID int
+
+	err := s.db.GetContext(ctx, &endpointID, `
+		SELECT endpoint_id
+		FROM web_api.stream_endpoints
+		WHERE application = $1 AND name = $2;`, application, streamName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get endpoint of incoming stream: %w", err)
+	}
+
+	token, err := s.IssueServicePlaybackToken(ctx, playbackKey, endpointID, 0)
+	if err != nil {
+		return "", fmt.Errorf("failed to issue source token: %w", err)
+	}
+
+	return token.Token, nil
+}
+
+func renderFFmpegRestream(b *strings.Builder, name, token string, targets []restreamTarget, ingest IngestConfig) error {
+	source := ingest.RTMPURL
+	if source == "" {
+		source = "rtmp://localhost"
+	}
+	source = strings.TrimSuffix(source, "/") + "/" + strings.Trim(name, "/")
+	if token != "" {
+		source += "?token=" + url.QueryEscape(token)
+	}
+
+	fmt.Fprintf(b, "#!/bin/sh\n# Restreams %s to %d mapping(s), generated by web-api\n", configComment(name), len(targets))
+
+	if len(targets) == 0 {
+		b.WriteString("# Nothing is mapped, so there's nothing to run\nexit 0\n")
+		return nil
+	}
+
+	fmt.Fprintf(b, "exec ffmpeg -hide_banner -loglevel warning \\\n\t-i %s", encode.QuoteArgument(source))
+
+	for _, t := range targets {
+		args, err := encode.ParseArguments(t.Command)
+		if err != nil {
+			return fmt.Errorf("%w: quality \"%s\": %w", ErrInvalidRestream, t.Quality, err)
+		}
+
+		b.WriteString(" \\\n\t")
+
+		for _, arg := range args {
+			b.WriteString(encode.QuoteArgument(arg) + " ")
+		}
+
+		if !containsOption(args, "f") {
+			b.WriteString("-f flv ")
+		}
+
+		b.WriteString(encode.QuoteArgument(t.destination()))
+	}
+
+	b.WriteString("\n")
+
+	return nil
+}
+
+func renderSRSTranscode(b *strings.Builder, name string, targets []restreamTarget) error {
+	if err := checkSRSValues("incoming stream", strings.Trim(name, "/")); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(b, "# Restreams %s to %d mapping(s), generated by web-api\n", configComment(name), len(targets))
+	fmt.Fprintf(b, "vhost __defaultVhost__ {\n    transcode %s {\n", strings.Trim(name, "/"))
+	fmt.Fprintf(b, "        enabled     %s;\n        ffmpeg      ./objs/ffmpeg/bin/ffmpeg;\n", onOff(len(targets) > 0))
+
+	for _, t := range targets {
+		args, err := encode.ParseArguments(t.Command)
+		if err != nil {
+			return fmt.Errorf("%w: quality \"%s\": %w", ErrInvalidRestream, t.Quality, err)
+		}
+
+		directives := map[string]string{"vcodec": "copy", "acodec": "copy", "oformat": "flv"}
+		var vparams, aparams, skipped []string
+
+		for i := 0; i < len(args); i++ {
+			option := strings.TrimPrefix(args[i], "-")
+
+			value := ""
+			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				i++
+				value = args[i]
+			}
+
+			if err := checkSRSValues(fmt.Sprintf("quality \"%s\"", configComment(t.Quality)), option, value); err != nil {
+				return err
+			}
+
+			if directive, ok := srsEngineOptions[option]; ok && value != "" {
+				if directive == "vbitrate" || directive == "abitrate" {
+					value = strconv.Itoa(kbps(value))
+				}
+				directives[directive] = value
+				continue
+			}
+
+			if option == "s" && value != "" {
+				width, height, ok := strings.Cut(value, "x")
+				if ok {
+					directives["vwidth"], directives["vheight"] = width, height
+					continue
+				}
+			}
+
+			switch {
+			case option == "an":
+				// SRS drops the audio or video with a codec of an or vn
+				directives["acodec"] = "an"
+			case option == "vn":
+				directives["vcodec"] = "vn"
+			case value == "":
+				// Engine params are always a name and a value
+				skipped = append(skipped, args[i])
+			case strings.HasSuffix(option, ":a"):
+				aparams = append(aparams, fmt.Sprintf("%s %s;", strings.TrimSuffix(option, ":a"), value))
+			default:
+				vparams = append(vparams, fmt.Sprintf("%s %s;", strings.TrimSuffix(option, ":v"), value))
+			}
+		}
+
+		if err := checkSRSValues(fmt.Sprintf("mapping %d destination", t.MappingID), t.destination()); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(b, "        # %s\n        engine mapping_%d {\n            enabled     on;\n", configComment(t.Quality), t.MappingID)
+
+		for _, directive := range []string{"vcodec", "vbitrate", "vfps", "vwidth", "vheight", "vprofile", "vpreset",
+			"acodec", "abitrate", "asample_rate", "achannels", "oformat"} {
+			if value, ok := directives[directive]; ok {
+				fmt.Fprintf(b, "            %-11s %s;\n", directive, value)
+			}
+		}
+
+		writeSRSParams(b, "vparams", vparams)
+		writeSRSParams(b, "aparams", aparams)
+
+		if len(skipped) > 0 {
+			fmt.Fprintf(b, "            # not supported by SRS engines: %s\n", configComment(strings.Join(skipped, " ")))
+		}
+
+		fmt.Fprintf(b, "            output      %s;\n        }\n", t.destination())
+	}
+
+	b.WriteString("    }\n}\n")
+
+	return nil
+}
+
+func writeSRSParams(b *strings.Builder, block string, params []string) {
+	if len(params) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "            %s {\n", block)
+
+	for _, p := range params {
+		fmt.Fprintf(b, "                %s\n", p)
+	}
+
+	b.WriteString("            }\n")
+}
+
+// checkSRSValues refuses values that would end or open a directive in an SRS config
+func checkSRSValues(field string, values ...string) error {
+	for _, value := range values {
+		if strings.ContainsAny(value, srsUnsafe) {
+			return fmt.Errorf("%w: %s can't be put in an SRS config, it has spaces, quotes or one of ;{}#\\", ErrInvalidRestream, field)
+		}
+	}
+
+	return nil
+}
+
+// configComment puts text on one line, so it can't end the comment it's written in
+func configComment(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// destination is the provider URL with the key on the end
+func (t restreamTarget) destination() string {
+	if t.Key == "" {
+		return t.Host
+	}
+
+	return strings.TrimSuffix(t.Host, "/") + "/" + t.Key
+}
+
+// containsOption is true when an ffmpeg option is in the arguments
+func containsOption(args []string, option string) bool {
+	for _, arg := range args {
+		if arg == "-"+option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// kbps converts an ffmpeg bitrate, like 6000k or 6M, to kbps
+func kbps(value string) int {
+	multiplier := 0.001
+
+	switch {
+	case strings.HasSuffix(value, "k"), strings.HasSuffix(value, "K"):
+		multiplier, value = 1, value[:len(value)-1]
+	case strings.HasSuffix(value, "M"):
+		multiplier, value = 1000, value[:len(value)-1]
+	}
+
+	f, _ := strconv.ParseFloat(value, 64)
+
+	return int(f * multiplier)
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+
+	return "off"
+}
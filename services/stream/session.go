@@ -0,0 +1,136 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/ystv/web-api/utils"
+)
+
+const (
+	SessionServerSRS   = "srs"
+	SessionServerNginx = "nginx-rtmp"
+
+	SessionEndUnpublish  = "unpublish"
+	SessionEndExpired    = "expired"
+	SessionEndSuperseded = "superseded"
+)
+
+type (
+	// Session is a period of an endpoint publishing
+	Session struct {
+		SessionID   int         `json:"sessionId" db:"session_id"`
+		EndpointID  null.Int    `json:"endpointId" db:"endpoint_id"`
+		Application string      `json:"application" db:"application"`
+		Name        string      `json:"name" db:"name"`
+		ClientIP    null.String `json:"clientIp" db:"client_ip"`
+		// Server is the media server the stream was published to, srs or nginx-rtmp
+		Server    string      `json:"server" db:"server"`
+		StartedAt time.Time   `json:"startedAt" db:"started_at"`
+		EndedAt   null.Time   `json:"endedAt" db:"ended_at"`
+		EndReason null.String `json:"endReason" db:"end_reason"`
+		// Duration is in seconds, up to now for sessions that haven't ended
+		Duration int64 `json:"duration" db:"duration"`
+	}
+
+	// SessionStart is what is known about a session when it is published
+	SessionStart struct {
+		EndpointID  int
+		Application string
+		Name        string
+		ClientIP    string
+		Server      string
+	}
+)
+
+// StartSession records an endpoint starting to publish. Any session the endpoint
+// didn't end is closed first, as it can only publish once at a time.
+func (s *Store) StartSession(ctx context.Context, start SessionStart) (int, error) {
+	var clientIP null.String
+	if net.ParseIP(start.ClientIP) != nil {
+		clientIP = null.StringFrom(start.ClientIP)
+	}
+
+	var sessionID int
+
+	err := utils.Transact(s.db, func(tx *sqlx.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE web_api.stream_sessions SET ended_at = NOW(), end_reason = $1
+			WHERE endpoint_id = $2 AND ended_at IS NULL;`, SessionEndSuperseded, start.EndpointID)
+		if err != nil {
+			return fmt.Errorf("failed to close previous session: %w", err)
+		}
+
+		err = tx.GetContext(ctx, &sessionID, `
+			INSERT INTO web_api.stream_sessions (endpoint_id, application, name, client_ip, server)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING session_id;`, start.EndpointID, start.Application, start.Name, clientIP, start.Server)
+		if err != nil {
+			return fmt.Errorf("failed to insert session: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return sessionID, nil
+}
+
+// EndSession records an endpoint's open session ending
+func (s *Store) EndSession(ctx context.Context, application, name, reason string) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE web_api.stream_sessions SET ended_at = NOW(), end_reason = $1
+		WHERE application = $2 AND name = $3 AND ended_at IS NULL;`, reason, application, name)
+	if err != nil {
+		return fmt.Errorf("failed to end session: %w", err)
+	}
+
+	return nil
+}
+
+// ListSessions returns a page of an endpoint's sessions, newest first
+func (s *Store) ListSessions(ctx context.Context, endpointID int, cursor *utils.Cursor, size int) (utils.CursorPage[Session], error) {
+	return s.listSessions(ctx, sq.Eq{"endpoint_id": endpointID}, cursor, size)
+}
+
+// ListRecentSessions returns a page of every endpoint's sessions, newest first
+func (s *Store) ListRecentSessions(ctx context.Context, cursor *utils.Cursor, size int) (utils.CursorPage[Session], error) {
+	return s.listSessions(ctx, sq.And{}, cursor, size)
+}
+
+func (s *Store) listSessions(ctx context.Context, filter sq.Sqlizer, cursor *utils.Cursor, size int) (utils.CursorPage[Session], error) {
+	var sessions []Session
+
+	where, order := utils.Keyset(cursor, "started_at", "session_id", true)
+
+	builder := utils.PSQL().Select("session_id", "endpoint_id", "application", "name",
+		"host(client_ip) AS client_ip", "server", "started_at", "ended_at", "end_reason",
+		"EXTRACT(EPOCH FROM COALESCE(ended_at, NOW()) - started_at)::bigint AS duration").
+		From("web_api.stream_sessions").
+		Where(filter).
+		Where(where).
+		OrderBy(order...).
+		Limit(uint64(size + 1))
+
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		panic(fmt.Errorf("failed to build sql for listSessions: %w", err))
+	}
+
+	err = s.db.SelectContext(ctx, &sessions, sql, args...)
+	if err != nil {
+		return utils.CursorPage[Session]{}, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	return utils.NewCursorPage(sessions, size, cursor, func(session Session) (string, int) {
+		return utils.TimeKey(session.StartedAt), session.SessionID
+	}), nil
+}
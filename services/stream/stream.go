@@ -21,19 +21,65 @@ type (
 		GetEndpointByApplicationNamePwd(ctx context.Context, application, name, pwd string) (EndpointDB, error)
 
 		SetEndpointActiveByID(ctx context.Context, endpointID int) error
-		SetEndpointInactiveByApplicationNamePwd(ctx context.Context, application, name, pwd string) error
+		SetEndpointInactiveByApplicationName(ctx context.Context, application, name string) error
 
 		AddEndpoint(ctx context.Context, endpointNew EndpointAddEditDTO) (EndpointDB, error)
 		EditEndpoint(ctx context.Context, endpointID int, endpointEdit EndpointAddEditDTO) (EndpointDB, error)
 		DeleteEndpoint(ctx context.Context, endpointID int) error
+
+		RotateEndpointKey(ctx context.Context, endpointID int) (RotatedKey, error)
+		AddGuestEndpoint(ctx context.Context, guest GuestEndpointNew, ingest IngestConfig) (GuestKey, error)
+		RevokeEndpoint(ctx context.Context, endpointID int) error
+
+		IssuePlaybackToken(ctx context.Context, key []byte, urlName string, userID int) (PlaybackToken, error)
+		IssueServicePlaybackToken(ctx context.Context, key []byte, endpointID int, validity time.Duration) (PlaybackToken, error)
+		AuthorisePlay(ctx context.Context, key []byte, application, name, token string) error
+
+		ListIncoming(ctx context.Context) ([]Incoming, error)
+		AddIncoming(ctx context.Context, i Incoming) (Incoming, error)
+		EditIncoming(ctx context.Context, i Incoming) (Incoming, error)
+		DeleteIncoming(ctx context.Context, incomingID int) error
+		ListProviders(ctx context.Context) ([]Provider, error)
+		AddProvider(ctx context.Context, p Provider) (Provider, error)
+		EditProvider(ctx context.Context, p Provider) (Provider, error)
+		DeleteProvider(ctx context.Context, providerID int) error
+		ListQualities(ctx context.Context) ([]Quality, error)
+		AddQuality(ctx context.Context, q Quality) (Quality, error)
+		EditQuality(ctx context.Context, q Quality) (Quality, error)
+		DeleteQuality(ctx context.Context, qualityID int) error
+		ListTranscodeMappings(ctx context.Context, incomingID *int) ([]TranscodeMapping, error)
+		AddTranscodeMapping(ctx context.Context, m TranscodeMappingAddEdit) (TranscodeMapping, error)
+		EditTranscodeMapping(ctx context.Context, mappingID int, m TranscodeMappingAddEdit) (TranscodeMapping, error)
+		DeleteTranscodeMapping(ctx context.Context, mappingID int) error
+		RenderRestreamConfig(ctx context.Context, incomingID int, format string, ingest IngestConfig, playbackKey []byte) (string, error)
+		HashPlaintextKeys(ctx context.Context) (KeyMigrationReport, error)
+
+		SetChannelsPublished(ctx context.Context, endpointID int) ([]ChannelEvent, error)
+		SetChannelsUnpublished(ctx context.Context, application, name string) ([]ChannelEvent, error)
+		UpdateChannelSchedules(ctx context.Context) ([]ChannelEvent, error)
+		RunChannelScheduler(ctx context.Context, interval time.Duration)
+
+		StartSession(ctx context.Context, start SessionStart) (int, error)
+		EndSession(ctx context.Context, application, name, reason string) error
+		ListSessions(ctx context.Context, endpointID int, cursor *utils.Cursor, size int) (utils.CursorPage[Session], error)
+		ListRecentSessions(ctx context.Context, cursor *utils.Cursor, size int) (utils.CursorPage[Session], error)
+		GetRecordingSource(ctx context.Context, application, name string) (RecordingSource, error)
+
+		Poll(ctx context.Context, conf PollerConfig) (PollReport, error)
+		RunPoller(ctx context.Context, conf PollerConfig)
+		ListMetrics(ctx context.Context, endpointID int, since time.Time) ([]Metric, error)
+
+		ExpireEndpoints(ctx context.Context) (ExpiryReport, error)
+		RunEndpointJanitor(ctx context.Context, interval time.Duration)
 	}
 
-	// EndpointDB stores a stream endpoint value
+	// EndpointDB stores a stream endpoint value, Pwd is a plaintext key from before keys were hashed
 	EndpointDB struct {
 		EndpointID  int         `json:"endpointId" db:"endpoint_id"`
 		Application string      `json:"application" db:"application"`
 		Name        string      `json:"name" db:"name"`
-		Pwd         null.String `json:"pwd" db:"pwd"`
+		Pwd         null.String `json:"-" db:"pwd"`
+		PwdHash     null.String `json:"-" db:"pwd_hash"`
 		StartValid  null.Time   `json:"startValid" db:"start_valid"`
 		EndValid    null.Time   `json:"endValid" db:"end_valid"`
 		Notes       null.String `json:"notes" db:"notes"`
@@ -49,7 +95,7 @@ type (
 		Application string `json:"application"`
 		// Name is the unique name given in an application
 		Name string `json:"name"`
-		// Pwd defines an extra layer of security for authentication
+		// Pwd is masked when a key is set, the key itself can't be read back
 		Pwd *string `json:"pwd,omitempty"`
 		// StartValid defines the optional start time that this endpoint becomes valid
 		StartValid *time.Time `json:"startValid,omitempty"`
@@ -63,6 +109,10 @@ type (
 		Blocked bool `json:"blocked"`
 		// AutoRemove indicates that this endpoint can be automatically removed when the end valid time comes, optional
 		AutoRemove bool `json:"autoRemove,omitempty"`
+		// Valid indicates the endpoint is inside its validity window, so it can publish
+		Valid bool `json:"valid"`
+		// ExpiresSoon indicates the endpoint stops being valid soon
+		ExpiresSoon bool `json:"expiresSoon,omitempty"`
 	}
 
 	// FindEndpoint used to find an endpoint
@@ -83,7 +133,8 @@ type (
 		Application string `json:"application"`
 		// Name is the unique name given in an application
 		Name string `json:"name"`
-		// Pwd defines an extra layer of security for authentication
+		// Pwd defines an extra layer of security for authentication, it is stored hashed.
+		// When editing leave it unset, or send back the masked key, to keep the current key.
 		Pwd *string `json:"pwd,omitempty"`
 		// StartValid defines the optional start time that this endpoint becomes valid, RFC3339
 		StartValid *time.Time `json:"startValid,omitempty"`
@@ -138,7 +189,6 @@ func (s *Store) FindEndpoint(ctx context.Context, findEndpoint FindEndpoint) (En
 			sq.And{
 				sq.Eq{"application": findEndpoint.Application},
 				sq.Eq{"name": findEndpoint.Name},
-				sq.Eq{"pwd": findEndpoint.Pwd},
 			},
 		})
 
@@ -152,6 +202,14 @@ func (s *Store) FindEndpoint(ctx context.Context, findEndpoint FindEndpoint) (En
 		return EndpointDB{}, fmt.Errorf("failed to find endpoint: %w", err)
 	}
 
+	// Keys are hashed, so can only be checked once the endpoint is found
+	if findEndpoint.Pwd != nil {
+		err = e.CheckKey(*findEndpoint.Pwd)
+		if err != nil {
+			return EndpointDB{}, fmt.Errorf("failed to find endpoint: %w", err)
+		}
+	}
+
 	findEndpoint.EndpointID = &e.EndpointID
 	findEndpoint.Application = &e.Application
 	findEndpoint.Name = &e.Name
@@ -187,7 +245,6 @@ func (s *Store) GetEndpointByApplicationNamePwd(ctx context.Context, app, name,
 		Where(sq.And{
 			sq.Eq{"application": app},
 			sq.Eq{"name": name},
-			sq.Eq{"pwd": pwd},
 		}).
 		Limit(1)
 
@@ -201,6 +258,11 @@ func (s *Store) GetEndpointByApplicationNamePwd(ctx context.Context, app, name,
 		return EndpointDB{}, fmt.Errorf("failed to get endpoint by application name pwd: %w", err)
 	}
 
+	err = e.CheckKey(pwd)
+	if err != nil {
+		return EndpointDB{}, fmt.Errorf("failed to get endpoint by application name pwd: %w", err)
+	}
+
 	return e, nil
 }
 
@@ -231,42 +293,48 @@ func (s *Store) SetEndpointActiveByID(ctx context.Context, endpointID int) error
 	return nil
 }
 
-func (s *Store) SetEndpointInactiveByApplicationNamePwd(ctx context.Context, application, name, pwd string) error {
+// SetEndpointInactiveByApplicationName marks an endpoint inactive. It's only
+// called by the media servers, which don't pass the key on unpublish.
+func (s *Store) SetEndpointInactiveByApplicationName(ctx context.Context, application, name string) error {
 	builder := utils.PSQL().Update("web_api.stream_endpoints").
 		Set("active", false).
 		Where(sq.And{
 			sq.Eq{"application": application},
 			sq.Eq{"name": name},
-			sq.Eq{"pwd": pwd},
 		})
 
 	sql, args, err := builder.ToSql()
 	if err != nil {
-		panic(fmt.Errorf("failed to build sql for SetEndpointInactiveByApplicationNamePwd: %w", err))
+		panic(fmt.Errorf("failed to build sql for SetEndpointInactiveByApplicationName: %w", err))
 	}
 
 	res, err := s.db.ExecContext(ctx, sql, args...)
 	if err != nil {
-		return fmt.Errorf("failed to set endpoint inactive by application name pwd: %w", err)
+		return fmt.Errorf("failed to set endpoint inactive by application name: %w", err)
 	}
 
 	rows, err := res.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to set endpoint inactive by application name pwd: %w", err)
+		return fmt.Errorf("failed to set endpoint inactive by application name: %w", err)
 	}
 
 	if rows < 1 {
-		return fmt.Errorf("failed to set endpoint inactive by application name pwd: invalid rows affected: %d", rows)
+		return fmt.Errorf("failed to set endpoint inactive by application name: invalid rows affected: %d", rows)
 	}
 
 	return nil
 }
 
 func (s *Store) AddEndpoint(ctx context.Context, endpointNew EndpointAddEditDTO) (EndpointDB, error) {
+	pwdHash, err := hashKeyPtr(endpointNew.Pwd)
+	if err != nil {
+		return EndpointDB{}, err
+	}
+
 	builder := utils.PSQL().Insert("web_api.stream_endpoints").
-		Columns("application", "name", "pwd", "start_valid", "end_valid", "notes", "active", "blocked",
+		Columns("application", "name", "pwd_hash", "start_valid", "end_valid", "notes", "active", "blocked",
 			"auto_remove").
-		Values(endpointNew.Application, endpointNew.Name, endpointNew.Pwd, endpointNew.StartValid, endpointNew.EndValid, endpointNew.Notes, false, endpointNew.Blocked, endpointNew.AutoRemove).
+		Values(endpointNew.Application, endpointNew.Name, pwdHash, endpointNew.StartValid, endpointNew.EndValid, endpointNew.Notes, false, endpointNew.Blocked, endpointNew.AutoRemove).
 		Suffix("RETURNING endpoint_id")
 
 	sql, args, err := builder.ToSql()
@@ -292,17 +360,30 @@ func (s *Store) AddEndpoint(ctx context.Context, endpointNew EndpointAddEditDTO)
 }
 
 func (s *Store) EditEndpoint(ctx context.Context, endpointID int, endpointEdit EndpointAddEditDTO) (EndpointDB, error) {
+	fields := map[string]interface{}{
+		"application": endpointEdit.Application,
+		"name":        endpointEdit.Name,
+		"start_valid": endpointEdit.StartValid,
+		"end_valid":   endpointEdit.EndValid,
+		"notes":       endpointEdit.Notes,
+		"blocked":     endpointEdit.Blocked,
+		"auto_remove": endpointEdit.AutoRemove,
+	}
+
+	// The key is masked when read, so it's only replaced when a new one is given,
+	// sending back the masked key keeps the current one
+	if endpointEdit.Pwd != nil && *endpointEdit.Pwd != MaskedKey {
+		pwdHash, err := hashKeyPtr(endpointEdit.Pwd)
+		if err != nil {
+			return EndpointDB{}, err
+		}
+
+		fields["pwd_hash"] = pwdHash
+		fields["pwd"] = nil
+	}
+
 	builder := utils.PSQL().Update("web_api.stream_endpoints").
-		SetMap(map[string]interface{}{
-			"application": endpointEdit.Application,
-			"name":        endpointEdit.Name,
-			"pwd":         endpointEdit.Pwd,
-			"start_valid": endpointEdit.StartValid,
-			"end_valid":   endpointEdit.EndValid,
-			"notes":       endpointEdit.Notes,
-			"blocked":     endpointEdit.Blocked,
-			"auto_remove": endpointEdit.AutoRemove,
-		}).
+		SetMap(fields).
 		Where(sq.Eq{"endpoint_id": endpointID})
 
 	sql, args, err := builder.ToSql()
@@ -1,5 +1,5 @@
-// Package swagger Code generated by swaggo/swag. DO NOT EDIT
-package swagger
+// Package swout Code generated by swaggo/swag. DO NOT EDIT
+package swout
 
 import "github.com/swaggo/swag"
 
@@ -584,6 +584,36 @@ const docTemplate = `{
                 }
             }
         },
+        "/v1/internal/creator/encode/export": {
+            "get": {
+                "description": "Exports every encode preset and format as a versioned document, formats are\nreferenced by name so it can be imported into another environment.",
+                "produces": [
+                    "application/json",
+                    "application/yaml"
+                ],
+                "tags": [
+                    "creator-encodes"
+                ],
+                "summary": "Export encode presets",
+                "operationId": "get-creator-encode-presets-export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "json or yaml, defaults to json",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/encode.PresetDocument"
+                        }
+                    }
+                }
+            }
+        },
         "/v1/internal/creator/encode/format": {
             "get": {
                 "description": "Lists all encode formats, these are instructions for the encoder to create the video",
@@ -635,7 +665,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "creates a new encode format.",
+                "description": "creates a new encode format. The arguments are checked for options that touch\nother files or shell syntax, and against the width, height and mime type.",
                 "consumes": [
                     "application/json"
                 ],
@@ -689,6 +719,85 @@ const docTemplate = `{
                 }
             }
         },
+        "/v1/internal/creator/encode/format/{formatid}/preview": {
+            "get": {
+                "description": "Renders the task and ffmpeg command that encoding a video's source file in the\nformat would send to VT, including the key the encode would be written to.\nNothing is encoded.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "creator-encodes"
+                ],
+                "summary": "Preview an encode",
+                "operationId": "get-creator-encode-format-preview",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Format ID",
+                        "name": "formatid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Video ID",
+                        "name": "videoID",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/encoder.EncodePreview"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/internal/creator/encode/import": {
+            "post": {
+                "description": "Imports a document made by export, matching formats and presets by name. Without apply\nit only reports what would be created and updated. Nothing is deleted, so importing\nthe same document again changes nothing. YAML is read when the content type is yaml.",
+                "consumes": [
+                    "application/json",
+                    "application/yaml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "creator-encodes"
+                ],
+                "summary": "Import encode presets",
+                "operationId": "import-creator-encode-presets",
+                "parameters": [
+                    {
+                        "description": "Preset document",
+                        "name": "document",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/encode.PresetDocument"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Apply the changes",
+                        "name": "apply",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/encode.ImportReport"
+                        }
+                    }
+                }
+            }
+        },
         "/v1/internal/creator/encode/preset": {
             "get": {
                 "description": "Lists all encode presets, these are groups of instructions (formats) for the encoder to create the video",
@@ -956,7 +1065,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "creates a new channel.",
+                "description": "creates a new channel. Linking it to a stream endpoint moves it between scheduled,\nlive and finished as the endpoint publishes and its scheduled times pass.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1010,835 +1119,883 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/internal/creator/playout/channels": {
+        "/v1/internal/creator/playout/channel/{channelid}/schedule": {
             "get": {
-                "description": "Lists all channels, these are a rough implementation of what is to come (linear channels)",
+                "description": "Lists the items on a channel's schedule between from and to, defaulting to the\nday before through the next two weeks.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "creator-playout-channels"
                 ],
-                "summary": "List all channels",
-                "operationId": "get-creator-playout-channels",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/playout.Channel"
-                            }
-                        }
+                "summary": "List a channel's schedule",
+                "operationId": "get-creator-playout-channel-schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel URL Name",
+                        "name": "channelid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the range, RFC 3339 or YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range, RFC 3339 or YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
                     }
-                }
-            }
-        },
-        "/v1/internal/creator/series": {
-            "get": {
-                "description": "Lists all series, doesn't include videos inside.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "creator-series"
                 ],
-                "summary": "List all series",
-                "operationId": "get-creator-series-all",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/series.Meta"
+                                "$ref": "#/definitions/playout.ScheduleItem"
                             }
                         }
                     }
                 }
             },
-            "put": {
-                "description": "UpdatePlaylist a series, video ID's required otherwise it will remove all videos.",
+            "post": {
+                "description": "Adds a video, event or text item to a channel's schedule. Items on a channel\ncan't overlap, an item that would is rejected with a conflict.",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "creator-series"
+                    "creator-playout-channels"
                 ],
-                "summary": "UpdatePlaylist series",
-                "operationId": "update-creator-series",
+                "summary": "New schedule item",
+                "operationId": "new-creator-playout-channel-schedule-item",
                 "parameters": [
                     {
-                        "description": "Series object",
-                        "name": "quote",
+                        "type": "string",
+                        "description": "Channel URL Name",
+                        "name": "channelid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Schedule item object",
+                        "name": "item",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/series.Series"
+                            "$ref": "#/definitions/playout.ScheduleItem"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "201": {
+                        "description": "Schedule item ID",
+                        "schema": {
+                            "type": "body"
+                        }
                     }
                 }
             }
         },
-        "/v1/internal/creator/series/{seriesid}": {
-            "get": {
-                "description": "Get a series including its children videos.",
-                "produces": [
+        "/v1/internal/creator/playout/channel/{channelid}/schedule/{itemid}": {
+            "put": {
+                "description": "Updates an item on a channel's schedule. It can't be moved to overlap another item.",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "creator-series"
+                    "creator-playout-channels"
                 ],
-                "summary": "Get series by ID",
-                "operationId": "get-creator-series",
+                "summary": "Update schedule item",
+                "operationId": "update-creator-playout-channel-schedule-item",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel URL Name",
+                        "name": "channelid",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "integer",
-                        "description": "Series ID",
-                        "name": "seriesid",
+                        "description": "Schedule item ID",
+                        "name": "itemid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Schedule item object",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/playout.ScheduleItem"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/series.Series"
-                        }
+                        "description": "OK"
                     }
                 }
             },
             "delete": {
-                "description": "Delete a series",
+                "description": "Removes an item from a channel's schedule.",
                 "tags": [
-                    "creator-series"
+                    "creator-playout-channels"
                 ],
-                "summary": "Delete a series",
-                "operationId": "delete-creator-series",
+                "summary": "Delete schedule item",
+                "operationId": "delete-creator-playout-channel-schedule-item",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel URL Name",
+                        "name": "channelid",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "integer",
-                        "description": "Series ID",
-                        "name": "seriesid",
+                        "description": "Schedule item ID",
+                        "name": "itemid",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK"
                     }
                 }
             }
         },
-        "/v1/internal/creator/stats": {
+        "/v1/internal/creator/playout/channels": {
             "get": {
-                "description": "Gets the statistics about the global video library.",
+                "description": "Lists all channels, these are a rough implementation of what is to come (linear channels)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "creator"
+                    "creator-playout-channels"
                 ],
-                "summary": "Get global video library information",
-                "operationId": "get-creator-glob-stats",
+                "summary": "List all channels",
+                "operationId": "get-creator-playout-channels",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/stats.VideoGlobalStats"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/playout.Channel"
+                            }
                         }
                     }
                 }
             }
         },
-        "/v1/internal/creator/video": {
+        "/v1/internal/creator/preferences": {
             "get": {
-                "description": "Lists all videos, doesn't include files inside.",
+                "description": "Gets the user's default preset, series and publish status, list view options\nand notification choices. Anything they haven't set is the default.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "creator-videos"
+                    "creator-preferences"
                 ],
-                "summary": "List all videos",
-                "operationId": "get-creator-videos-all",
+                "summary": "Get creator preferences",
+                "operationId": "get-creator-preferences",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/video.Meta"
-                            }
+                            "$ref": "#/definitions/preferences.Preferences"
                         }
                     }
                 }
-            }
-        },
-        "/v1/internal/creator/video/meta": {
+            },
             "put": {
-                "description": "Updates a video metadata",
+                "description": "Replaces the user's creator preferences, fields that are left out go back to\nthe default. Unknown fields, values outside of the allowed options and presets\nor series that don't exist are rejected.",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "creator-videos"
+                    "creator-preferences"
                 ],
-                "summary": "Update video meta",
-                "operationId": "update-creator-video-meta",
+                "summary": "Update creator preferences",
+                "operationId": "update-creator-preferences",
                 "parameters": [
                     {
-                        "description": "VideoItem meta object",
-                        "name": "event",
+                        "description": "Preferences object",
+                        "name": "preferences",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/video.Meta"
+                            "$ref": "#/definitions/preferences.Preferences"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Video ID",
+                        "description": "OK",
                         "schema": {
-                            "type": "body"
+                            "$ref": "#/definitions/preferences.Preferences"
                         }
                     }
                 }
             }
         },
-        "/v1/internal/creator/video/my": {
+        "/v1/internal/creator/series": {
             "get": {
-                "description": "Lists all videos, doesn't include files inside.",
+                "description": "Lists all series, doesn't include videos inside.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "creator-videos"
+                    "creator-series"
                 ],
-                "summary": "List all videos created by user ID",
-                "operationId": "get-creator-videos-user",
+                "summary": "List all series",
+                "operationId": "get-creator-series-all",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/video.Meta"
+                                "$ref": "#/definitions/series.Meta"
                             }
                         }
                     }
                 }
-            }
-        },
-        "/v1/internal/creator/video/search": {
-            "post": {
-                "description": "Search videos.",
-                "produces": [
+            },
+            "put": {
+                "description": "UpdatePlaylist a series, video ID's required otherwise it will remove all videos.",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "creator-videos"
+                    "creator-series"
                 ],
-                "summary": "Search videos",
-                "operationId": "search-creator-videos",
+                "summary": "UpdatePlaylist series",
+                "operationId": "update-creator-series",
                 "parameters": [
                     {
-                        "description": "Search Input object",
-                        "name": "searchInput",
+                        "description": "Series object",
+                        "name": "quote",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/creator.searchInput"
+                            "$ref": "#/definitions/series.Series"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/video.Meta"
-                            }
-                        }
+                        "description": "OK"
                     }
                 }
             }
         },
-        "/v1/internal/creator/videos": {
-            "post": {
-                "description": "creates a new video, requires the file ID/name to find it in CDN.",
-                "consumes": [
+        "/v1/internal/creator/series/{seriesid}": {
+            "get": {
+                "description": "Get a series including its children videos.",
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "creator-videos"
+                    "creator-series"
                 ],
-                "summary": "New video",
-                "operationId": "new-creator-video",
+                "summary": "Get series by ID",
+                "operationId": "get-creator-series",
                 "parameters": [
                     {
-                        "description": "NewVideo object",
-                        "name": "event",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/video.New"
-                        }
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "seriesid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Video ID",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "body"
+                            "$ref": "#/definitions/series.Series"
                         }
                     }
                 }
+            },
+            "delete": {
+                "description": "Delete a series",
+                "tags": [
+                    "creator-series"
+                ],
+                "summary": "Delete a series",
+                "operationId": "delete-creator-series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "seriesid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
             }
         },
-        "/v1/internal/creator/videos/{videoid}": {
+        "/v1/internal/creator/series/{seriesid}/stats": {
             "get": {
-                "description": "Get a playlist including its children files.",
+                "description": "Gets the stats of every video in a series and the series below it rolled up,\nalong with each video's views. Defaults to the last 30 days by day.\nRequires VideoStats.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "creator-videos"
+                    "creator-series"
                 ],
-                "summary": "Get video by ID",
-                "operationId": "get-creator-video",
+                "summary": "Get series stats",
+                "operationId": "get-creator-series-stats",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Video ID",
-                        "name": "videoid",
+                        "description": "Series ID",
+                        "name": "seriesid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the range, RFC 3339 or YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range, RFC 3339 or YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "hour, day, week or month",
+                        "name": "interval",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/video.Item"
+                            "$ref": "#/definitions/stats.SeriesStats"
                         }
                     }
                 }
             }
         },
-        "/v1/internal/custom-setting": {
-            "post": {
+        "/v1/internal/creator/stats": {
+            "get": {
+                "description": "Gets the statistics about the global video library.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "custom-settings"
-                ],
-                "summary": "Create a custom setting",
-                "operationId": "add-custom-setting",
-                "parameters": [
-                    {
-                        "description": "Custom Setting object",
-                        "name": "customSetting",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/customsettings.CustomSetting"
-                        }
-                    }
+                    "creator"
                 ],
+                "summary": "Get global video library information",
+                "operationId": "get-creator-glob-stats",
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/customsettings.CustomSetting"
+                            "$ref": "#/definitions/stats.VideoGlobalStats"
                         }
                     }
                 }
             }
         },
-        "/v1/internal/custom-setting/{officerid}": {
-            "put": {
+        "/v1/internal/creator/stats/storage": {
+            "get": {
+                "description": "Breaks down the storage used by the video library by series subtree, creating user,\nencode format and broadcast year, with what dropping each format would save.\nSizes are in bytes. Setting reconcile checks every file against the object store,\nfilling in the actual sizes and listing files whose recorded size is wrong.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "custom-settings"
+                    "creator-storage"
                 ],
-                "summary": "Edits a custom setting",
-                "operationId": "edit-custom-setting",
+                "summary": "Storage usage breakdown",
+                "operationId": "get-creator-storage-usage",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "setting id",
-                        "name": "settingid",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Custom Setting object",
-                        "name": "customSetting",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/customsettings.CustomSettingEditDTO"
-                        }
+                        "type": "boolean",
+                        "description": "Reconcile against the object store",
+                        "name": "reconcile",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/customsettings.CustomSetting"
+                            "$ref": "#/definitions/storage.Usage"
                         }
                     }
                 }
             }
         },
-        "/v1/internal/custom-setting/{settingid}": {
+        "/v1/internal/creator/stats/storage.csv": {
             "get": {
-                "description": "Contains a single setting value in string base64 format",
+                "description": "The storage usage breakdown as a CSV, one row per series, user, format and year.",
                 "produces": [
-                    "application/json"
+                    "text/csv"
                 ],
                 "tags": [
-                    "custom-settings"
+                    "creator-storage"
                 ],
-                "summary": "Provides a single setting",
-                "operationId": "get-custom-setting",
+                "summary": "Export storage usage breakdown",
+                "operationId": "get-creator-storage-usage-csv",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Setting id",
-                        "name": "settingid",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "description": "Reconcile against the object store",
+                        "name": "reconcile",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "CSV",
                         "schema": {
-                            "$ref": "#/definitions/customsettings.CustomSetting"
+                            "type": "string"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/internal/creator/storage/gc": {
+            "get": {
+                "description": "Lists the ingest and serve buckets and compares them against video files,\nthumbnails and avatars. Reports orphaned objects and video file rows whose\nobject is missing, marking those older than the grace period as collectable.\nNothing is deleted.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "custom-settings"
+                    "creator-storage"
                 ],
-                "summary": "Deletes custom setting",
-                "operationId": "delete-custom-setting",
+                "summary": "Storage garbage collection report",
+                "operationId": "get-creator-storage-gc",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "setting id",
-                        "name": "settingid",
-                        "in": "path",
-                        "required": true
+                        "description": "Grace period in days, defaults to 7",
+                        "name": "graceDays",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/storage.GCReport"
+                        }
                     }
                 }
-            }
-        },
-        "/v1/internal/custom-settings": {
-            "get": {
-                "description": "Contains settings and value in string base64 format",
+            },
+            "post": {
+                "description": "Deletes the orphaned objects and dangling video file rows that are older than\nthe grace period. Failed deletions are listed in the report's errors and are\nretried on the next run. Check the dry run report first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "custom-settings"
+                    "creator-storage"
+                ],
+                "summary": "Collect storage garbage",
+                "operationId": "collect-creator-storage-gc",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Grace period in days, defaults to 7",
+                        "name": "graceDays",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Provides all settings",
-                "operationId": "get-custom-settings",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/customsettings.CustomSetting"
-                            }
+                            "$ref": "#/definitions/storage.GCReport"
                         }
                     }
                 }
             }
         },
-        "/v1/internal/encoder/transcode_finished/{taskid}": {
-            "post": {
-                "description": "Marks a transcode item as finished",
+        "/v1/internal/creator/video/meta": {
+            "put": {
+                "description": "Updates a video metadata",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "encoder"
+                    "creator-videos"
                 ],
-                "summary": "Transcode Finished",
-                "operationId": "new-encoder-transcode-finished",
+                "summary": "Update video meta",
+                "operationId": "update-creator-video-meta",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Task ID",
-                        "name": "taskid",
-                        "in": "path",
-                        "required": true
+                        "description": "VideoItem meta object",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/video.Meta"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "Video ID",
+                        "schema": {
+                            "type": "body"
+                        }
                     }
                 }
             }
         },
-        "/v1/internal/encoder/upload_request": {
-            "post": {
-                "description": "Authenticates tusd's webhook requests",
-                "consumes": [
+        "/v1/internal/creator/video/my": {
+            "get": {
+                "description": "Lists all videos, doesn't include files inside.",
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "encoder"
+                    "creator-videos"
                 ],
-                "summary": "New upload request",
-                "operationId": "new-encoder-upload-request",
+                "summary": "List all videos created by user ID",
+                "operationId": "get-creator-videos-user",
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/video.Meta"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/v1/internal/misc/list/{listid}": {
-            "get": {
-                "description": "Get a mailing list. Provides list subscribers also",
+        "/v1/internal/creator/video/search": {
+            "post": {
+                "description": "Search all videos, including non-public ones, ranked by relevance to the query.\nResults can be filtered to a series (and its child series), a broadcast year and a duration range.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "misc-list"
+                    "creator-videos"
                 ],
-                "summary": "Get Mailing list",
-                "operationId": "get-mailing-list-id",
+                "summary": "Search videos",
+                "operationId": "search-creator-videos",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "List ID",
-                        "name": "listid",
-                        "in": "path",
-                        "required": true
+                        "description": "Search parameters object",
+                        "name": "searchInput",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/search.Params"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/misc.List"
+                            "$ref": "#/definitions/search.Results"
                         }
                     }
                 }
             }
         },
-        "/v1/internal/misc/list/{listid}/subscribe": {
-            "post": {
-                "description": "Subscribe to a mailing list by a JWT",
-                "consumes": [
+        "/v1/internal/creator/video/{id}/stats": {
+            "get": {
+                "description": "Gets a video's views and unique viewers over time, its mode breakdown,\naverage percent watched and an audience retention histogram.\nDefaults to the last 30 days by day. Requires VideoStats.",
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "misc-list"
+                    "creator-videos"
                 ],
-                "summary": "Subscribe to mailing list by token",
-                "operationId": "new-mailing-list-subscriber-token",
+                "summary": "Get video stats",
+                "operationId": "get-creator-video-stats",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "List ID",
-                        "name": "listid",
+                        "description": "Video ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the range, RFC 3339 or YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range, RFC 3339 or YYYY-MM-DD",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "hour, day, week or month",
+                        "name": "interval",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/stats.VideoStats"
+                        }
                     }
                 }
             }
         },
-        "/v1/internal/misc/list/{listid}/subscribe/{userid}": {
-            "post": {
-                "description": "Subscribe to a mailing list by a user ID",
-                "consumes": [
+        "/v1/internal/creator/video/{videoid}/file/{fileid}/url": {
+            "get": {
+                "description": "Gets a URL to fetch a video file from. Files that aren't public get a signed URL\nthat expires after 15 minutes. Private files are only available to the video's\ncreator and watch admins. Setting download logs a download of the video.",
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "misc-list"
+                    "creator-videos"
                 ],
-                "summary": "Subscribe to mailing list by user ID",
-                "operationId": "new-mailing-list-subscriber-id",
+                "summary": "Get video file URL",
+                "operationId": "get-creator-video-file-url",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "List ID",
-                        "name": "listid",
+                        "description": "Video ID",
+                        "name": "videoid",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "userid",
+                        "description": "File ID",
+                        "name": "fileid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Download the file",
+                        "name": "download",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/video.FileURL"
+                        }
                     }
                 }
             }
         },
-        "/v1/internal/misc/list/{listid}/subscribers": {
+        "/v1/internal/creator/videos": {
             "get": {
-                "description": "Get a mailing list's subscribers",
+                "description": "Lists all videos by cursor pagination, newest first, doesn't include files inside.\nFollow the next and prev links to page through the list.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "misc-list"
+                    "creator-videos"
                 ],
-                "summary": "Get subscribers",
-                "operationId": "get-mailing-list-subscribers-id",
+                "summary": "List all videos",
+                "operationId": "get-creator-videos-all",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Opaque page cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "List ID",
-                        "name": "listid",
-                        "in": "path",
-                        "required": true
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/misc.List"
+                            "$ref": "#/definitions/utils.CursorPage-video_Meta"
                         }
                     }
                 }
-            }
-        },
-        "/v1/internal/misc/list/{listid}/unsubscribe": {
-            "delete": {
-                "description": "Unsubscribe to a mailing list by a JWT",
+            },
+            "post": {
+                "description": "creates a new video, requires the file ID/name to find it in CDN.\nThe preset, series and publish type default to the user's creator preferences.\nIf the video is created but its preset can't be applied, encodeError says why.",
                 "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "misc-list"
+                    "creator-videos"
                 ],
-                "summary": "Unsubscribe to mailing list by token",
-                "operationId": "delete-mailing-list-subscriber-token",
+                "summary": "New video",
+                "operationId": "new-creator-video",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "List ID",
-                        "name": "listid",
-                        "in": "path",
-                        "required": true
+                        "description": "NewVideo object",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/video.New"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/creator.NewVideoOutput"
+                        }
                     }
                 }
             }
         },
-        "/v1/internal/misc/list/{listid}/unsubscribe/{userid}": {
-            "delete": {
-                "description": "Unsubscribe to a mailing list by a user ID",
-                "consumes": [
+        "/v1/internal/creator/videos/{videoid}": {
+            "get": {
+                "description": "Get a playlist including its children files.",
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "misc-list"
+                    "creator-videos"
                 ],
-                "summary": "Unsubscribe to mailing list by user ID",
-                "operationId": "delete-mailing-list-subscriber-id",
+                "summary": "Get video by ID",
+                "operationId": "get-creator-video",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "List ID",
-                        "name": "listid",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "userid",
+                        "description": "Video ID",
+                        "name": "videoid",
                         "in": "path",
                         "required": true
                     }
                 ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        },
-        "/v1/internal/misc/lists": {
-            "get": {
-                "description": "Lists all mailing lists.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "misc-list"
-                ],
-                "summary": "Get Mailing lists",
-                "operationId": "get-mailing-lists",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/misc.List"
-                            }
+                            "$ref": "#/definitions/video.Item"
                         }
                     }
                 }
             }
         },
-        "/v1/internal/misc/lists/my": {
-            "get": {
-                "description": "Lists all mailing lists, provides extra context for what the user has subscribed to",
+        "/v1/internal/custom-setting": {
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "misc-list"
-                ],
-                "summary": "Get Mailing lists by token",
-                "operationId": "get-mailing-lists-token",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/misc.List"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/v1/internal/misc/quotes": {
-            "put": {
-                "description": "updates a quote. Still need to provide the whole Quote object,\nweb-api will overwrite created by User ID to keep with existing record.",
-                "consumes": [
-                    "application/json"
-                ],
-                "tags": [
-                    "misc-quotes"
+                    "custom-settings"
                 ],
-                "summary": "Update quote",
-                "operationId": "update-quote",
+                "summary": "Create a custom setting",
+                "operationId": "add-custom-setting",
                 "parameters": [
                     {
-                        "description": "Quote object",
-                        "name": "quote",
+                        "description": "Custom Setting object",
+                        "name": "customSetting",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/misc.Quote"
+                            "$ref": "#/definitions/customsettings.CustomSetting"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/customsettings.CustomSetting"
+                        }
                     }
                 }
-            },
-            "post": {
-                "description": "creates a new quote.\nweb-api will overwrite created by User ID with the token's user ID.",
-                "consumes": [
+            }
+        },
+        "/v1/internal/custom-setting/{officerid}": {
+            "put": {
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "misc-quotes"
+                    "custom-settings"
                 ],
-                "summary": "New quote",
-                "operationId": "new-quote",
+                "summary": "Edits a custom setting",
+                "operationId": "edit-custom-setting",
                 "parameters": [
                     {
-                        "description": "Quote object",
-                        "name": "quote",
+                        "type": "integer",
+                        "description": "setting id",
+                        "name": "settingid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Custom Setting object",
+                        "name": "customSetting",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/misc.Quote"
+                            "$ref": "#/definitions/customsettings.CustomSettingEditDTO"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Quote ID",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "integer"
+                            "$ref": "#/definitions/customsettings.CustomSetting"
                         }
                     }
                 }
             }
         },
-        "/v1/internal/misc/quotes/{amount}/{page}": {
+        "/v1/internal/custom-setting/{settingid}": {
             "get": {
-                "description": "Lists quotes by pagination.",
+                "description": "Contains a single setting value in string base64 format",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "misc-quotes"
+                    "custom-settings"
                 ],
-                "summary": "List quotes",
-                "operationId": "get-quotes",
+                "summary": "Provides a single setting",
+                "operationId": "get-custom-setting",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Amount",
-                        "name": "amount",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page",
-                        "name": "page",
+                        "type": "string",
+                        "description": "Setting id",
+                        "name": "settingid",
                         "in": "path",
                         "required": true
                     }
@@ -1847,621 +2004,611 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/misc.QuotePage"
-                            }
+                            "$ref": "#/definitions/customsettings.CustomSetting"
                         }
                     }
                 }
-            }
-        },
-        "/v1/internal/misc/quotes/{quoteid}": {
+            },
             "delete": {
-                "description": "deletes a quote by ID.",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "misc-quotes"
+                    "custom-settings"
                 ],
-                "summary": "Delete quote",
-                "operationId": "delete-quote",
+                "summary": "Deletes custom setting",
+                "operationId": "delete-custom-setting",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Quote ID",
-                        "name": "quoteid",
+                        "description": "setting id",
+                        "name": "settingid",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "204": {
+                        "description": "No Content"
                     }
                 }
             }
         },
-        "/v1/internal/misc/webcams": {
+        "/v1/internal/custom-settings": {
             "get": {
-                "description": "List webcams available to user by using the permission ID",
+                "description": "Contains settings and value in string base64 format",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "misc-webcams"
+                    "custom-settings"
                 ],
-                "summary": "List webcams",
-                "operationId": "list-webcams",
+                "summary": "Provides all settings",
+                "operationId": "get-custom-settings",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/misc.Webcam"
+                                "$ref": "#/definitions/customsettings.CustomSetting"
                             }
                         }
                     }
                 }
             }
         },
-        "/v1/internal/misc/webcams/{cameraID}": {
-            "get": {
-                "description": "Reverse proxies the selected webcam returns the jpeg feed as a result.",
+        "/v1/internal/encoder/transcode_finished/{taskid}": {
+            "post": {
+                "description": "Marks a transcode item as finished",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "misc-webcams"
+                    "encoder"
                 ],
-                "summary": "Get webcam",
-                "operationId": "get-webcam",
+                "summary": "Transcode Finished",
+                "operationId": "new-encoder-transcode-finished",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Camera ID",
-                        "name": "cameraID",
+                        "description": "Task ID",
+                        "name": "taskid",
                         "in": "path",
                         "required": true
                     }
                 ],
-                "responses": {}
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
             }
         },
-        "/v1/internal/people/officership": {
+        "/v1/internal/encoder/upload_request": {
             "post": {
-                "produces": [
+                "description": "Authenticates tusd's webhook requests",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-officership"
-                ],
-                "summary": "Create an officership",
-                "operationId": "add-people-officership",
-                "parameters": [
-                    {
-                        "description": "Officership object",
-                        "name": "officership",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/people.OfficershipAddEditDTO"
-                        }
-                    }
+                    "encoder"
                 ],
+                "summary": "New upload request",
+                "operationId": "new-encoder-upload-request",
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/people.Officership"
-                        }
+                    "200": {
+                        "description": "OK"
                     }
                 }
             }
         },
-        "/v1/internal/people/officership/count": {
+        "/v1/internal/misc/list/{listid}": {
             "get": {
-                "description": "Contains a number of stats",
+                "description": "Get a mailing list. Provides list subscribers also",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-officership"
+                    "misc-list"
+                ],
+                "summary": "Get Mailing list",
+                "operationId": "get-mailing-list-id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "List ID",
+                        "name": "listid",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Provides officership count",
-                "operationId": "get-people-officership-count",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/people.CountOfficerships"
+                            "$ref": "#/definitions/misc.List"
                         }
                     }
                 }
             }
         },
-        "/v1/internal/people/officership/officer": {
+        "/v1/internal/misc/list/{listid}/subscribe": {
             "post": {
-                "produces": [
+                "description": "Subscribe to a mailing list by a JWT",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-officership-officer"
+                    "misc-list"
                 ],
-                "summary": "Create an officer",
-                "operationId": "add-people-officership-officer",
+                "summary": "Subscribe to mailing list by token",
+                "operationId": "new-mailing-list-subscriber-token",
                 "parameters": [
                     {
-                        "description": "Officer object",
-                        "name": "officership",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/people.OfficershipMemberAddEditDTO"
-                        }
+                        "type": "integer",
+                        "description": "List ID",
+                        "name": "listid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/people.OfficershipMember"
-                        }
+                        "description": "Created"
                     }
                 }
             }
         },
-        "/v1/internal/people/officership/officer/{officerid}": {
-            "get": {
-                "produces": [
+        "/v1/internal/misc/list/{listid}/subscribe/{userid}": {
+            "post": {
+                "description": "Subscribe to a mailing list by a user ID",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-officership-officer"
+                    "misc-list"
                 ],
-                "summary": "Provides officer",
-                "operationId": "get-people-officership-officer",
+                "summary": "Subscribe to mailing list by user ID",
+                "operationId": "new-mailing-list-subscriber-id",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "officer id",
-                        "name": "officerid",
+                        "description": "List ID",
+                        "name": "listid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "userid",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/people.OfficershipMember"
-                        }
+                    "201": {
+                        "description": "Created"
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/v1/internal/misc/list/{listid}/subscribers": {
+            "get": {
+                "description": "Get a mailing list's subscribers",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-officership-officer"
+                    "misc-list"
                 ],
-                "summary": "Edits an officer",
-                "operationId": "edit-people-officership-officer",
+                "summary": "Get subscribers",
+                "operationId": "get-mailing-list-subscribers-id",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "officer id",
-                        "name": "officerid",
+                        "description": "List ID",
+                        "name": "listid",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Officer object",
-                        "name": "officership",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/people.OfficershipMemberAddEditDTO"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/people.OfficershipMember"
+                            "$ref": "#/definitions/misc.List"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/v1/internal/misc/list/{listid}/unsubscribe": {
             "delete": {
-                "produces": [
+                "description": "Unsubscribe to a mailing list by a JWT",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-officership-officer"
+                    "misc-list"
                 ],
-                "summary": "Deletes officer",
-                "operationId": "delete-people-officership-officer",
+                "summary": "Unsubscribe to mailing list by token",
+                "operationId": "delete-mailing-list-subscriber-token",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "officer id",
-                        "name": "officerid",
+                        "description": "List ID",
+                        "name": "listid",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK"
                     }
                 }
             }
         },
-        "/v1/internal/people/officership/officers": {
-            "get": {
-                "produces": [
+        "/v1/internal/misc/list/{listid}/unsubscribe/{userid}": {
+            "delete": {
+                "description": "Unsubscribe to a mailing list by a user ID",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-officership-officer"
+                    "misc-list"
                 ],
-                "summary": "List officers with options",
-                "operationId": "get-people-officers",
+                "summary": "Unsubscribe to mailing list by user ID",
+                "operationId": "delete-mailing-list-subscriber-id",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Optional if officership is current (default), retired or any",
-                        "name": "officershipStatus",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Optional if officer is current (default), retired or any",
-                        "name": "officerStatus",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Optional officership id for getting all officers where id equal",
-                        "name": "officershipId",
-                        "in": "query"
+                        "description": "List ID",
+                        "name": "listid",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "Optional user id for getting where user is equal to id",
-                        "name": "userId",
-                        "in": "query"
+                        "description": "User ID",
+                        "name": "userid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/people.OfficershipMember"
-                            }
-                        }
+                        "description": "OK"
                     }
                 }
             }
         },
-        "/v1/internal/people/officership/team": {
-            "post": {
+        "/v1/internal/misc/lists": {
+            "get": {
+                "description": "Lists all mailing lists.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-officership-team"
-                ],
-                "summary": "Create an officership team",
-                "operationId": "add-people-officership-team",
-                "parameters": [
-                    {
-                        "description": "Officership Team object",
-                        "name": "officership",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/people.OfficershipTeamAddEditDTO"
-                        }
-                    }
+                    "misc-list"
                 ],
+                "summary": "Get Mailing lists",
+                "operationId": "get-mailing-lists",
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/people.OfficershipTeam"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/misc.List"
+                            }
                         }
                     }
                 }
             }
         },
-        "/v1/internal/people/officership/team/{officershipteamid}": {
+        "/v1/internal/misc/lists/my": {
             "get": {
-                "description": "Contains members and a range of descriptions",
+                "description": "Lists all mailing lists, provides extra context for what the user has subscribed to",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-officership-team"
-                ],
-                "summary": "Provides officership team",
-                "operationId": "get-people-officership-team",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "officership team id",
-                        "name": "officershipteamid",
-                        "in": "path",
-                        "required": true
-                    }
+                    "misc-list"
                 ],
+                "summary": "Get Mailing lists by token",
+                "operationId": "get-mailing-lists-token",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/people.OfficershipTeam"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/misc.List"
+                            }
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/v1/internal/misc/quotes": {
+            "get": {
+                "description": "Lists quotes by cursor pagination, newest first.\nFollow the next and prev links to page through the list.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-officership-team"
+                    "misc-quotes"
                 ],
-                "summary": "Edits an officership team",
-                "operationId": "edit-people-officership-team",
+                "summary": "List quotes",
+                "operationId": "get-quotes",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "officership team id",
-                        "name": "officershipteamid",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Opaque page cursor",
+                        "name": "cursor",
+                        "in": "query"
                     },
                     {
-                        "description": "Officership Team object",
-                        "name": "officership",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/people.OfficershipTeamAddEditDTO"
-                        }
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/people.OfficershipTeam"
+                            "$ref": "#/definitions/utils.CursorPage-misc_Quote"
                         }
                     }
                 }
             },
-            "delete": {
-                "produces": [
+            "put": {
+                "description": "updates a quote. Still need to provide the whole Quote object,\nweb-api will overwrite created by User ID to keep with existing record.",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-officership-team"
+                    "misc-quotes"
                 ],
-                "summary": "Deletes officership team",
-                "operationId": "delete-people-officership-team",
+                "summary": "Update quote",
+                "operationId": "update-quote",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "officership team id",
-                        "name": "officershipteamid",
-                        "in": "path",
-                        "required": true
+                        "description": "Quote object",
+                        "name": "quote",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/misc.Quote"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK"
                     }
                 }
-            }
-        },
-        "/v1/internal/people/officership/team/{officershipteamid}/officership": {
+            },
             "post": {
-                "produces": [
+                "description": "creates a new quote.\nweb-api will overwrite created by User ID with the token's user ID.",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-officership-team"
+                    "misc-quotes"
                 ],
-                "summary": "Create an officership link to a team",
-                "operationId": "add-people-officership-team-officership",
+                "summary": "New quote",
+                "operationId": "new-quote",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "officership team id",
-                        "name": "officershipteamid",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Officership team member object",
-                        "name": "officership",
+                        "description": "Quote object",
+                        "name": "quote",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/people.OfficershipTeamMemberAddDTO"
+                            "$ref": "#/definitions/misc.Quote"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Created",
+                        "description": "Quote ID",
                         "schema": {
-                            "$ref": "#/definitions/people.OfficershipTeamMember"
+                            "type": "integer"
                         }
                     }
                 }
             }
         },
-        "/v1/internal/people/officership/team/{officershipteamid}/officership/{officershipid}": {
+        "/v1/internal/misc/quotes/{quoteid}": {
             "delete": {
-                "produces": [
-                    "application/json"
-                ],
+                "description": "deletes a quote by ID.",
                 "tags": [
-                    "people-officership-team"
+                    "misc-quotes"
                 ],
-                "summary": "Removes officership from team",
-                "operationId": "remove-people-officership-from-team",
+                "summary": "Delete quote",
+                "operationId": "delete-quote",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "officership team id",
-                        "name": "officershipteamid",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "officership id",
-                        "name": "officershipid",
+                        "description": "Quote ID",
+                        "name": "quoteid",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK"
                     }
                 }
             }
         },
-        "/v1/internal/people/officership/teams": {
+        "/v1/internal/misc/redirects": {
             "get": {
+                "description": "Lists all redirects in order of source.\nSetting unusedDays lists only the redirects that haven't been used in that\nmany days, least recently used first, so they can be pruned.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-officership-team"
+                    "misc-redirects"
+                ],
+                "summary": "List redirects",
+                "operationId": "get-redirects",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only redirects unused for this many days",
+                        "name": "unusedDays",
+                        "in": "query"
+                    }
                 ],
-                "summary": "List officership teams with options",
-                "operationId": "get-people-officership-teams",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/people.OfficershipTeam"
+                                "$ref": "#/definitions/misc.Redirect"
                             }
                         }
                     }
                 }
-            }
-        },
-        "/v1/internal/people/officership/{officershipid}": {
-            "get": {
-                "description": "Contains members and a range of descriptions",
-                "produces": [
+            },
+            "put": {
+                "description": "Updates the source, destination and match type of a redirect,\nits usage is kept.",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-officership"
+                    "misc-redirects"
                 ],
-                "summary": "Provides officership",
-                "operationId": "get-people-officership",
+                "summary": "Update redirect",
+                "operationId": "update-redirect",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "officership id",
-                        "name": "officershipid",
-                        "in": "path",
-                        "required": true
+                        "description": "Redirect object",
+                        "name": "redirect",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/misc.Redirect"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/people.Officership"
-                        }
+                        "description": "OK"
                     }
                 }
             },
-            "put": {
+            "post": {
+                "description": "Creates a redirect. Match type is exact, prefix or wildcard, defaulting to exact.\nweb-api will overwrite created by User ID with the token's user ID.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-officership"
+                    "misc-redirects"
                 ],
-                "summary": "Edits an officership",
-                "operationId": "edit-people-officership",
+                "summary": "New redirect",
+                "operationId": "new-redirect",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "officership id",
-                        "name": "officershipid",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Officership object",
-                        "name": "officership",
+                        "description": "Redirect object",
+                        "name": "redirect",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/people.OfficershipAddEditDTO"
+                            "$ref": "#/definitions/misc.Redirect"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Redirect ID",
                         "schema": {
-                            "$ref": "#/definitions/people.Officership"
+                            "type": "integer"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/internal/misc/redirects/{redirectid}": {
+            "get": {
+                "description": "Gets a redirect by ID, including its usage.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-officership"
+                    "misc-redirects"
                 ],
-                "summary": "Deletes officership",
-                "operationId": "delete-people-officership",
+                "summary": "Get redirect",
+                "operationId": "get-redirect",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "officership id",
-                        "name": "officershipid",
+                        "description": "Redirect ID",
+                        "name": "redirectid",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/misc.Redirect"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a redirect by ID.",
+                "tags": [
+                    "misc-redirects"
+                ],
+                "summary": "Delete redirect",
+                "operationId": "delete-redirect",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Redirect ID",
+                        "name": "redirectid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
                     }
                 }
             }
         },
-        "/v1/internal/people/officerships": {
+        "/v1/internal/misc/shortlinks": {
             "get": {
+                "description": "Lists the user's short links, newest first, with their total clicks.\nPermalink admins can set all to list everyone's links.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-officership"
+                    "misc-short-links"
                 ],
-                "summary": "List officerships with options",
-                "operationId": "get-people-officerships",
+                "summary": "List short links",
+                "operationId": "get-short-links",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Optional if officership is current (default), retired or any",
-                        "name": "officershipStatus",
+                        "type": "boolean",
+                        "description": "List everyone's links",
+                        "name": "all",
                         "in": "query"
                     }
                 ],
@@ -2471,31 +2618,33 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/people.Officership"
+                                "$ref": "#/definitions/misc.ShortLink"
                             }
                         }
                     }
                 }
-            }
-        },
-        "/v1/internal/people/permission": {
+            },
             "post": {
+                "description": "Creates a short link to a video, series, playlist, channel or URL.\nA code is generated unless a vanity code is given.\nweb-api will overwrite created by User ID with the token's user ID.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-permission"
+                    "misc-short-links"
                 ],
-                "summary": "Create a permission",
-                "operationId": "add-people-permission",
+                "summary": "New short link",
+                "operationId": "new-short-link",
                 "parameters": [
                     {
-                        "description": "Permission object",
-                        "name": "permission",
+                        "description": "Short link object",
+                        "name": "shortlink",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/people.PermissionAddEditDTO"
+                            "$ref": "#/definitions/misc.ShortLink"
                         }
                     }
                 ],
@@ -2503,27 +2652,28 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/people.Permission"
+                            "$ref": "#/definitions/misc.ShortLink"
                         }
                     }
                 }
             }
         },
-        "/v1/internal/people/permission/{permissionid}": {
+        "/v1/internal/misc/shortlinks/{linkid}": {
             "get": {
+                "description": "Gets a short link by ID, only its creator or a permalink admin can.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-permission"
+                    "misc-short-links"
                 ],
-                "summary": "Get a single permission based on the permission id",
-                "operationId": "get-people-permission",
+                "summary": "Get short link",
+                "operationId": "get-short-link",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Permission ID",
-                        "name": "permissionid",
+                        "description": "Link ID",
+                        "name": "linkid",
                         "in": "path",
                         "required": true
                     }
@@ -2532,197 +2682,181 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/people.Permission"
+                            "$ref": "#/definitions/misc.ShortLink"
                         }
                     }
                 }
             },
-            "put": {
-                "produces": [
-                    "application/json"
-                ],
+            "delete": {
+                "description": "Deletes a short link and its analytics, only its creator or a permalink admin can.",
                 "tags": [
-                    "people-permission"
+                    "misc-short-links"
                 ],
-                "summary": "Edits a permission",
-                "operationId": "edit-people-permission",
+                "summary": "Delete short link",
+                "operationId": "delete-short-link",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Permission ID",
-                        "name": "permissionid",
+                        "description": "Link ID",
+                        "name": "linkid",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Permission object",
-                        "name": "permission",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/people.PermissionAddEditDTO"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/people.Permission"
-                        }
+                        "description": "OK"
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/internal/misc/shortlinks/{linkid}/analytics": {
+            "get": {
+                "description": "Clicks on a short link by day and by referring site, only its creator\nor a permalink admin can see them. Defaults to the last 90 days.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-permission"
+                    "misc-short-links"
                 ],
-                "summary": "Deletes a permission and links to roles",
-                "operationId": "delete-people-permission",
+                "summary": "Short link analytics",
+                "operationId": "get-short-link-analytics",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Permission ID",
-                        "name": "permissionid",
+                        "description": "Link ID",
+                        "name": "linkid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of days",
+                        "name": "days",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/misc.ShortLinkAnalytics"
+                        }
                     }
                 }
             }
         },
-        "/v1/internal/people/permission/{permissionid}/count": {
+        "/v1/internal/misc/webcams": {
             "get": {
-                "produces": [
-                    "application/json"
-                ],
+                "description": "List webcams available to user by using the permission ID",
                 "tags": [
-                    "people-permission"
-                ],
-                "summary": "Get a single permission based on the permission id with roles count",
-                "operationId": "get-people-permission-count",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Permission ID",
-                        "name": "permissionid",
-                        "in": "path",
-                        "required": true
-                    }
+                    "misc-webcams"
                 ],
+                "summary": "List webcams",
+                "operationId": "list-webcams",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/people.PermissionWithRolesCount"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/misc.Webcam"
+                            }
                         }
                     }
                 }
             }
         },
-        "/v1/internal/people/permission/{permissionid}/members": {
+        "/v1/internal/misc/webcams/{cameraID}": {
             "get": {
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Reverse proxies the selected webcam returns the jpeg feed as a result.",
                 "tags": [
-                    "people-permission"
+                    "misc-webcams"
                 ],
-                "summary": "List all users of a given permission",
-                "operationId": "get-people-permission-members",
+                "summary": "Get webcam",
+                "operationId": "get-webcam",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Permission ID",
-                        "name": "permissionid",
+                        "description": "Camera ID",
+                        "name": "cameraID",
                         "in": "path",
                         "required": true
                     }
                 ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/people.Permission"
-                            }
-                        }
-                    }
-                }
+                "responses": {}
             }
         },
-        "/v1/internal/people/permissions": {
-            "get": {
+        "/v1/internal/people/officership": {
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-permission"
+                    "people-officership"
+                ],
+                "summary": "Create an officership",
+                "operationId": "add-people-officership",
+                "parameters": [
+                    {
+                        "description": "Officership object",
+                        "name": "officership",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/people.OfficershipAddEditDTO"
+                        }
+                    }
                 ],
-                "summary": "List permissions",
-                "operationId": "get-people-permissions",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/people.Permission"
-                            }
+                            "$ref": "#/definitions/people.Officership"
                         }
                     }
                 }
             }
         },
-        "/v1/internal/people/permissions/count": {
+        "/v1/internal/people/officership/count": {
             "get": {
+                "description": "Contains a number of stats",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-permission"
+                    "people-officership"
                 ],
-                "summary": "List permissions with roles count",
-                "operationId": "get-people-permissions-count",
+                "summary": "Provides officership count",
+                "operationId": "get-people-officership-count",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/people.PermissionWithRolesCount"
-                            }
+                            "$ref": "#/definitions/people.CountOfficerships"
                         }
                     }
                 }
             }
         },
-        "/v1/internal/people/role": {
+        "/v1/internal/people/officership/officer": {
             "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-role"
+                    "people-officership-officer"
                 ],
-                "summary": "Create a role",
-                "operationId": "add-people-role",
+                "summary": "Create an officer",
+                "operationId": "add-people-officership-officer",
                 "parameters": [
                     {
-                        "description": "Role object",
-                        "name": "role",
+                        "description": "Officer object",
+                        "name": "officership",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/people.RoleAddEditDTO"
+                            "$ref": "#/definitions/people.OfficershipMemberAddEditDTO"
                         }
                     }
                 ],
@@ -2730,27 +2864,27 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/people.Role"
+                            "$ref": "#/definitions/people.OfficershipMember"
                         }
                     }
                 }
             }
         },
-        "/v1/internal/people/role/{roleid}": {
+        "/v1/internal/people/officership/officer/{officerid}": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-role"
+                    "people-officership-officer"
                 ],
-                "summary": "Provides a given role",
-                "operationId": "get-people-role",
+                "summary": "Provides officer",
+                "operationId": "get-people-officership-officer",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Role ID",
-                        "name": "roleid",
+                        "description": "officer id",
+                        "name": "officerid",
                         "in": "path",
                         "required": true
                     }
@@ -2759,7 +2893,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/people.Role"
+                            "$ref": "#/definitions/people.OfficershipMember"
                         }
                     }
                 }
@@ -2769,25 +2903,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "people-role"
+                    "people-officership-officer"
                 ],
-                "summary": "Edit a role",
-                "operationId": "edit-people-role",
+                "summary": "Edits an officer",
+                "operationId": "edit-people-officership-officer",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Role ID",
-                        "name": "roleid",
+                        "description": "officer id",
+                        "name": "officerid",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Role object",
-                        "name": "role",
+                        "description": "Officer object",
+                        "name": "officership",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/people.RoleAddEditDTO"
+                            "$ref": "#/definitions/people.OfficershipMemberAddEditDTO"
                         }
                     }
                 ],
@@ -2795,7 +2929,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/people.Role"
+                            "$ref": "#/definitions/people.OfficershipMember"
                         }
                     }
                 }
@@ -2805,15 +2939,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "people-role"
+                    "people-officership-officer"
                 ],
-                "summary": "Deletes role",
-                "operationId": "delete-people-role",
+                "summary": "Deletes officer",
+                "operationId": "delete-people-officership-officer",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "role id",
-                        "name": "roleid",
+                        "description": "officer id",
+                        "name": "officerid",
                         "in": "path",
                         "required": true
                     }
@@ -2825,52 +2959,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/internal/people/role/{roleid}/full": {
+        "/v1/internal/people/officership/officers": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-role"
+                    "people-officership-officer"
                 ],
-                "summary": "List all users and permissions of a given role",
-                "operationId": "get-people-role-full",
+                "summary": "List officers with options",
+                "operationId": "get-people-officers",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Role ID",
-                        "name": "roleid",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/people.RoleFull"
-                        }
-                    }
-                }
-            }
-        },
-        "/v1/internal/people/role/{roleid}/members": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "people-role"
-                ],
-                "summary": "List all users of a given role",
-                "operationId": "get-people-role-members",
-                "parameters": [
+                        "description": "Optional if officership is current (default), retired or any",
+                        "name": "officershipStatus",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Role ID",
-                        "name": "roleid",
-                        "in": "path",
-                        "required": true
+                        "description": "Optional if officer is current (default), retired or any",
+                        "name": "officerStatus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Optional officership id for getting all officers where id equal",
+                        "name": "officershipId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Optional user id for getting where user is equal to id",
+                        "name": "userId",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2879,212 +3001,194 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/people.User"
+                                "$ref": "#/definitions/people.OfficershipMember"
                             }
                         }
-                    },
-                    "404": {
-                        "description": "Role Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/utils.HTTPError"
-                        }
-                    },
-                    "500": {
-                        "description": "Server Side Role Error",
-                        "schema": {
-                            "$ref": "#/definitions/utils.HTTPError"
-                        }
                     }
                 }
             }
         },
-        "/v1/internal/people/role/{roleid}/permission/{permissionid}": {
+        "/v1/internal/people/officership/team": {
             "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-role"
+                    "people-officership-team"
                 ],
-                "summary": "Adds a permission to a role",
-                "operationId": "add-people-role-permission",
+                "summary": "Create an officership team",
+                "operationId": "add-people-officership-team",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "role id",
-                        "name": "roleid",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "permission id",
-                        "name": "permissionid",
-                        "in": "path",
-                        "required": true
+                        "description": "Officership Team object",
+                        "name": "officership",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/people.OfficershipTeamAddEditDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/people.RolePermission"
+                            "$ref": "#/definitions/people.OfficershipTeam"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/internal/people/officership/team/{officershipteamid}": {
+            "get": {
+                "description": "Contains members and a range of descriptions",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-role"
+                    "people-officership-team"
                 ],
-                "summary": "Removes a permission from a role",
-                "operationId": "remove-people-role-permission",
+                "summary": "Provides officership team",
+                "operationId": "get-people-officership-team",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "role id",
-                        "name": "roleid",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "permission id",
-                        "name": "permissionid",
+                        "description": "officership team id",
+                        "name": "officershipteamid",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/people.OfficershipTeam"
+                        }
                     }
                 }
-            }
-        },
-        "/v1/internal/people/role/{roleid}/permissions": {
-            "get": {
+            },
+            "put": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-role"
+                    "people-officership-team"
                 ],
-                "summary": "List permissions of a given role",
-                "operationId": "get-people-role-permissions",
+                "summary": "Edits an officership team",
+                "operationId": "edit-people-officership-team",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Role ID",
-                        "name": "roleid",
+                        "description": "officership team id",
+                        "name": "officershipteamid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Officership Team object",
+                        "name": "officership",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/people.OfficershipTeamAddEditDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/people.Permission"
-                            }
+                            "$ref": "#/definitions/people.OfficershipTeam"
                         }
                     }
                 }
-            }
-        },
-        "/v1/internal/people/role/{roleid}/permissions/notinrole": {
-            "post": {
+            },
+            "delete": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-role"
+                    "people-officership-team"
                 ],
-                "summary": "Lists permissions not in role",
-                "operationId": "list-people-role-permission-not",
+                "summary": "Deletes officership team",
+                "operationId": "delete-people-officership-team",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "role id",
-                        "name": "roleid",
+                        "description": "officership team id",
+                        "name": "officershipteamid",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/people.Permission"
-                            }
-                        }
+                    "204": {
+                        "description": "No Content"
                     }
                 }
             }
         },
-        "/v1/internal/people/role/{roleid}/user/{userid}": {
+        "/v1/internal/people/officership/team/{officershipteamid}/officership": {
             "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-role"
+                    "people-officership-team"
                 ],
-                "summary": "Adds a user to a role",
-                "operationId": "add-people-role-user",
+                "summary": "Create an officership link to a team",
+                "operationId": "add-people-officership-team-officership",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "role id",
-                        "name": "roleid",
+                        "description": "officership team id",
+                        "name": "officershipteamid",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "user id",
-                        "name": "userid",
-                        "in": "path",
-                        "required": true
+                        "description": "Officership team member object",
+                        "name": "officership",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/people.OfficershipTeamMemberAddDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/people.RoleUser"
+                            "$ref": "#/definitions/people.OfficershipTeamMember"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/v1/internal/people/officership/team/{officershipteamid}/officership/{officershipid}": {
             "delete": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-role"
+                    "people-officership-team"
                 ],
-                "summary": "Removes a user from a role",
-                "operationId": "remove-people-role-user",
+                "summary": "Removes officership from team",
+                "operationId": "remove-people-officership-from-team",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "role id",
-                        "name": "roleid",
+                        "description": "officership team id",
+                        "name": "officershipteamid",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "integer",
-                        "description": "user id",
-                        "name": "userid",
+                        "description": "officership id",
+                        "name": "officershipid",
                         "in": "path",
                         "required": true
                     }
@@ -3096,142 +3200,196 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/internal/people/role/{roleid}/users/notinrole": {
-            "post": {
+        "/v1/internal/people/officership/teams": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-role"
-                ],
-                "summary": "Lists users not in role",
-                "operationId": "list-people-role-user-not",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "role id",
-                        "name": "roleid",
-                        "in": "path",
-                        "required": true
-                    }
+                    "people-officership-team"
                 ],
+                "summary": "List officership teams with options",
+                "operationId": "get-people-officership-teams",
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/people.User"
+                                "$ref": "#/definitions/people.OfficershipTeam"
                             }
                         }
                     }
                 }
             }
         },
-        "/v1/internal/people/roles": {
+        "/v1/internal/people/officership/{officershipid}": {
             "get": {
+                "description": "Contains members and a range of descriptions",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-role"
+                    "people-officership"
+                ],
+                "summary": "Provides officership",
+                "operationId": "get-people-officership",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "officership id",
+                        "name": "officershipid",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List roles",
-                "operationId": "get-people-roles-with-permissions",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/people.RoleWithPermissions"
-                            }
+                            "$ref": "#/definitions/people.Officership"
                         }
                     }
                 }
-            }
-        },
-        "/v1/internal/people/roles/count": {
-            "get": {
+            },
+            "put": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-role"
+                    "people-officership"
+                ],
+                "summary": "Edits an officership",
+                "operationId": "edit-people-officership",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "officership id",
+                        "name": "officershipid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Officership object",
+                        "name": "officership",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/people.OfficershipAddEditDTO"
+                        }
+                    }
                 ],
-                "summary": "List roles with count",
-                "operationId": "get-people-roles-count",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/people.RoleWithCount"
-                            }
+                            "$ref": "#/definitions/people.Officership"
                         }
                     }
                 }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "people-officership"
+                ],
+                "summary": "Deletes officership",
+                "operationId": "delete-people-officership",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "officership id",
+                        "name": "officershipid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
             }
         },
-        "/v1/internal/people/user": {
+        "/v1/internal/people/officerships": {
             "get": {
-                "description": "Get a basic user object by JWT token generated by web-auth.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-user"
+                    "people-officership"
+                ],
+                "summary": "List officerships with options",
+                "operationId": "get-people-officerships",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Optional if officership is current (default), retired or any",
+                        "name": "officershipStatus",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get a user by token",
-                "operationId": "get-user-token",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/people.User"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/people.Officership"
+                            }
                         }
                     }
                 }
             }
         },
-        "/v1/internal/people/user/full": {
-            "get": {
-                "description": "Get a complete user object by JWT token generated by web-auth.",
+        "/v1/internal/people/permission": {
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-user"
+                    "people-permission"
+                ],
+                "summary": "Create a permission",
+                "operationId": "add-people-permission",
+                "parameters": [
+                    {
+                        "description": "Permission object",
+                        "name": "permission",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/people.PermissionAddEditDTO"
+                        }
+                    }
                 ],
-                "summary": "Get a full user by token",
-                "operationId": "get-user-token-full",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/people.UserFull"
+                            "$ref": "#/definitions/people.Permission"
                         }
                     }
                 }
             }
         },
-        "/v1/internal/people/user/{email}": {
+        "/v1/internal/people/permission/{permissionid}": {
             "get": {
-                "description": "Get a basic user object by email.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-user"
+                    "people-permission"
                 ],
-                "summary": "Get a user by email",
-                "operationId": "get-user-email",
+                "summary": "Get a single permission based on the permission id",
+                "operationId": "get-people-permission",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Email",
-                        "name": "email",
+                        "description": "Permission ID",
+                        "name": "permissionid",
                         "in": "path",
                         "required": true
                     }
@@ -3240,58 +3398,87 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/people.User"
+                            "$ref": "#/definitions/people.Permission"
                         }
                     }
                 }
-            }
-        },
-        "/v1/internal/people/user/{email}/full": {
-            "get": {
-                "description": "Get a complete user object by email.",
+            },
+            "put": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-user"
+                    "people-permission"
                 ],
-                "summary": "Get a full user by email",
-                "operationId": "get-user-email-full",
+                "summary": "Edits a permission",
+                "operationId": "edit-people-permission",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Email",
-                        "name": "email",
+                        "description": "Permission ID",
+                        "name": "permissionid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Permission object",
+                        "name": "permission",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/people.PermissionAddEditDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/people.User"
+                            "$ref": "#/definitions/people.Permission"
                         }
                     }
                 }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "people-permission"
+                ],
+                "summary": "Deletes a permission and links to roles",
+                "operationId": "delete-people-permission",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Permission ID",
+                        "name": "permissionid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
             }
         },
-        "/v1/internal/people/user/{userid}": {
+        "/v1/internal/people/permission/{permissionid}/count": {
             "get": {
-                "description": "Get a basic user object by ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-user"
+                    "people-permission"
                 ],
-                "summary": "Get a user by ID",
-                "operationId": "get-user-id",
+                "summary": "Get a single permission based on the permission id with roles count",
+                "operationId": "get-people-permission-count",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "userid",
+                        "description": "Permission ID",
+                        "name": "permissionid",
                         "in": "path",
                         "required": true
                     }
@@ -3300,28 +3487,27 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/people.User"
+                            "$ref": "#/definitions/people.PermissionWithRolesCount"
                         }
                     }
                 }
             }
         },
-        "/v1/internal/people/user/{userid}/full": {
+        "/v1/internal/people/permission/{permissionid}/members": {
             "get": {
-                "description": "Get a complete user object by ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-user"
+                    "people-permission"
                 ],
-                "summary": "Get a full user by ID",
-                "operationId": "get-user-id-full",
+                "summary": "List all users of a given permission",
+                "operationId": "get-people-permission-members",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "userid",
+                        "description": "Permission ID",
+                        "name": "permissionid",
                         "in": "path",
                         "required": true
                     }
@@ -3330,206 +3516,79 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/people.UserFull"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/people.Permission"
+                            }
                         }
                     }
                 }
             }
         },
-        "/v1/internal/people/users": {
+        "/v1/internal/people/permissions": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-user"
+                    "people-permission"
                 ],
-                "summary": "List users",
-                "operationId": "list-people-users",
+                "summary": "List permissions",
+                "operationId": "get-people-permissions",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/people.User"
+                                "$ref": "#/definitions/people.Permission"
                             }
                         }
                     }
                 }
             }
         },
-        "/v1/internal/people/users/pagination": {
+        "/v1/internal/people/permissions/count": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "people-user"
-                ],
-                "summary": "List users with pagination",
-                "operationId": "list-people-users-pagination",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Page size",
-                        "name": "size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search string",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Ordering column",
-                        "name": "column",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Ordering direction",
-                        "name": "direction",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Is user enabled",
-                        "name": "enabled",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Is user deleted",
-                        "name": "deleted",
-                        "in": "query"
-                    }
+                    "people-permission"
                 ],
+                "summary": "List permissions with roles count",
+                "operationId": "get-people-permissions-count",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/people.UserFull"
+                                "$ref": "#/definitions/people.PermissionWithRolesCount"
                             }
                         }
                     }
                 }
             }
         },
-        "/v1/internal/people/users/stats": {
-            "get": {
-                "description": "Get an overview of users.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "people-user"
-                ],
-                "summary": "Get user stats",
-                "operationId": "get-user-stats",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/people.CountUsers"
-                        }
-                    }
-                }
-            }
-        },
-        "/v1/internal/stream/publish": {
-            "post": {
-                "description": "Checks existing stream endpoints and changes it to active; this is for Nginx RTMP module\ncontaining the application, name and pwd",
-                "consumes": [
-                    "application/json"
-                ],
-                "tags": [
-                    "stream-endpoints"
-                ],
-                "summary": "Publish a stream",
-                "operationId": "publish-stream",
-                "responses": {
-                    "200": {
-                        "description": "Endpoint published",
-                        "schema": {
-                            "type": "body"
-                        }
-                    }
-                }
-            }
-        },
-        "/v1/internal/stream/unpublish": {
-            "post": {
-                "description": "Checks existing stream endpoints and changes it to inactive; this is for Nginx RTMP module\ncontaining the application, name, authentication and start and end times",
-                "consumes": [
-                    "application/json"
-                ],
-                "tags": [
-                    "stream-endpoints"
-                ],
-                "summary": "Unpublish a stream",
-                "operationId": "unpublish-stream",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "body"
-                        }
-                    }
-                }
-            }
-        },
-        "/v1/internal/streams": {
-            "get": {
-                "description": "Lists all stream endpoints; this is for Nginx RTMP module\ncontaining the application, name, authentication and start and end times",
-                "consumes": [
-                    "application/json"
-                ],
-                "tags": [
-                    "stream-endpoints"
-                ],
-                "summary": "ListStreams stream endpoints",
-                "operationId": "get-stream",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/stream.Endpoint"
-                            }
-                        }
-                    }
-                }
-            },
+        "/v1/internal/people/role": {
             "post": {
-                "description": "Creates a new stream endpoint; this is for Nginx RTMP module\ncontaining the application, name, authentication and start and end times",
-                "consumes": [
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "stream-endpoints"
+                    "people-role"
                 ],
-                "summary": "NewStream stream endpoint",
-                "operationId": "new-stream",
+                "summary": "Create a role",
+                "operationId": "add-people-role",
                 "parameters": [
                     {
-                        "description": "Stream endpoint object",
-                        "name": "endpoint",
+                        "description": "Role object",
+                        "name": "role",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/stream.EndpointAddEditDTO"
+                            "$ref": "#/definitions/people.RoleAddEditDTO"
                         }
                     }
                 ],
@@ -3537,62 +3596,64 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/stream.Endpoint"
+                            "$ref": "#/definitions/people.Role"
                         }
                     }
                 }
             }
         },
-        "/v1/internal/streams/find": {
+        "/v1/internal/people/role/{roleid}": {
             "get": {
-                "description": "finds existing stream",
-                "consumes": [
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "stream-endpoints"
+                    "people-role"
                 ],
-                "summary": "Finds stream",
-                "operationId": "find-stream",
+                "summary": "Provides a given role",
+                "operationId": "get-people-role",
                 "parameters": [
                     {
-                        "description": "Find Endpoint object",
-                        "name": "endpoint",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/stream.FindEndpoint"
-                        }
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "roleid",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/stream.Endpoint"
+                            "$ref": "#/definitions/people.Role"
                         }
                     }
                 }
-            }
-        },
-        "/v1/internal/streams/{endpointid}": {
+            },
             "put": {
-                "consumes": [
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "stream-endpoints"
+                    "people-role"
                 ],
-                "summary": "Edit stream endpoint",
-                "operationId": "edit-stream",
+                "summary": "Edit a role",
+                "operationId": "edit-people-role",
                 "parameters": [
                     {
-                        "description": "Endpoint object",
-                        "name": "endpoint",
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "roleid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role object",
+                        "name": "role",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/stream.EndpointAddEditDTO"
+                            "$ref": "#/definitions/people.RoleAddEditDTO"
                         }
                     }
                 ],
@@ -3600,79 +3661,80 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/stream.Endpoint"
+                            "$ref": "#/definitions/people.Role"
                         }
                     }
                 }
             },
             "delete": {
-                "consumes": [
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "stream-endpoints"
+                    "people-role"
                 ],
-                "summary": "Delete stream endpoint",
-                "operationId": "delete-stream",
+                "summary": "Deletes role",
+                "operationId": "delete-people-role",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Endpoint ID",
-                        "name": "endpointid",
+                        "description": "role id",
+                        "name": "roleid",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK"
+                    "204": {
+                        "description": "No Content"
                     }
                 }
             }
         },
-        "/v1/list_unsubscribe/{uuid}": {
+        "/v1/internal/people/role/{roleid}/full": {
             "get": {
-                "description": "Unsubscribe to a mailing list by a subscriber UUID",
-                "consumes": [
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "misc-list"
+                    "people-role"
                 ],
-                "summary": "Unsubscribe to mailing list by subscriber UUID",
-                "operationId": "delete-mailing-list-subscriber-uuid",
+                "summary": "List all users and permissions of a given role",
+                "operationId": "get-people-role-full",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Subscriber UUID",
-                        "name": "uuid",
+                        "description": "Role ID",
+                        "name": "roleid",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/people.RoleFull"
+                        }
                     }
                 }
             }
         },
-        "/v1/public/custom-setting/{settingid}": {
+        "/v1/internal/people/role/{roleid}/members": {
             "get": {
-                "description": "Contains a single setting value in string format",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public-custom-settings"
+                    "people-role"
                 ],
-                "summary": "Provides a single public setting",
-                "operationId": "get-public-custom-setting",
+                "summary": "List all users of a given role",
+                "operationId": "get-people-role-members",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Setting id",
-                        "name": "settingid",
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "roleid",
                         "in": "path",
                         "required": true
                     }
@@ -3681,377 +3743,420 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/public.CustomSetting"
-                        }
-                    }
-                }
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/people.User"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Role Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Server Side Role Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.HTTPError"
+                        }
+                    }
+                }
             }
         },
-        "/v1/public/find/{url}": {
-            "get": {
-                "description": "Allows us to remain backwards compatible with the existing URLs",
+        "/v1/internal/people/role/{roleid}/permission/{permissionid}": {
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public-breadcrumb"
+                    "people-role"
                 ],
-                "summary": "Converts a VOD url to either a series or video",
-                "operationId": "get-public-breadcrumb-find",
+                "summary": "Adds a permission to a role",
+                "operationId": "add-people-role-permission",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "URL Path",
-                        "name": "url",
+                        "type": "integer",
+                        "description": "role id",
+                        "name": "roleid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "permission id",
+                        "name": "permissionid",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/public.BreadcrumbItem"
+                            "$ref": "#/definitions/people.RolePermission"
                         }
                     }
                 }
-            }
-        },
-        "/v1/public/playlist/popular/all": {
-            "get": {
-                "description": "Provides a fake playlist, containing a list of popular videos",
+            },
+            "delete": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public-playlist"
+                    "people-role"
+                ],
+                "summary": "Removes a permission from a role",
+                "operationId": "remove-people-role-permission",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "role id",
+                        "name": "roleid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "permission id",
+                        "name": "permissionid",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Provides a playlist of popular videos of all time",
-                "operationId": "get-public-playlist-popular-by-all-time",
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/public.Playlist"
-                        }
+                    "204": {
+                        "description": "No Content"
                     }
                 }
             }
         },
-        "/v1/public/playlist/popular/month": {
+        "/v1/internal/people/role/{roleid}/permissions": {
             "get": {
-                "description": "Provides a fake playlist, containing a list of popular videos",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public-playlist"
+                    "people-role"
+                ],
+                "summary": "List permissions of a given role",
+                "operationId": "get-people-role-permissions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Role ID",
+                        "name": "roleid",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Provides a playlist of popular videos of past month",
-                "operationId": "get-public-playlist-popular-by-past-month",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/public.Playlist"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/people.Permission"
+                            }
                         }
                     }
                 }
             }
         },
-        "/v1/public/playlist/popular/year": {
-            "get": {
-                "description": "Provides a fake playlist, containing a list of popular videos",
+        "/v1/internal/people/role/{roleid}/permissions/notinrole": {
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public-playlist"
+                    "people-role"
+                ],
+                "summary": "Lists permissions not in role",
+                "operationId": "list-people-role-permission-not",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "role id",
+                        "name": "roleid",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Provides a playlist of popular videos of past year",
-                "operationId": "get-public-playlist-popular-by-past-year",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/public.Playlist"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/people.Permission"
+                            }
                         }
                     }
                 }
             }
         },
-        "/v1/public/playlist/random": {
-            "get": {
-                "description": "Provides a fake playlist, containing a list of random videos",
+        "/v1/internal/people/role/{roleid}/user/{userid}": {
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public-playlist"
+                    "people-role"
+                ],
+                "summary": "Adds a user to a role",
+                "operationId": "add-people-role-user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "role id",
+                        "name": "roleid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "userid",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Provides a playlist of random videos",
-                "operationId": "get-public-playlist-random",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/public.Playlist"
+                            "$ref": "#/definitions/people.RoleUser"
                         }
                     }
                 }
-            }
-        },
-        "/v1/public/playlist/{seriesid}": {
-            "get": {
-                "description": "Returns a playlist object, includes videos (not video files) and metadata.",
+            },
+            "delete": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public-playlist"
+                    "people-role"
                 ],
-                "summary": "Provides a playlist",
-                "operationId": "get-public-playlist",
+                "summary": "Removes a user from a role",
+                "operationId": "remove-people-role-user",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Playlist ID",
-                        "name": "playlistid",
+                        "description": "role id",
+                        "name": "roleid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "userid",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/public.Playlist"
-                        }
+                    "204": {
+                        "description": "No Content"
                     }
                 }
             }
         },
-        "/v1/public/playout/channel/{channelShortName}": {
-            "get": {
+        "/v1/internal/people/role/{roleid}/users/notinrole": {
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public-playout-channels"
+                    "people-role"
                 ],
-                "summary": "Provides a public or unlisted channel",
-                "operationId": "get-public-stream-channel",
+                "summary": "Lists users not in role",
+                "operationId": "list-people-role-user-not",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Channel short name",
-                        "name": "channelShortName",
+                        "description": "role id",
+                        "name": "roleid",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/public.Channel"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/people.User"
+                            }
                         }
                     }
                 }
             }
         },
-        "/v1/public/playout/channels": {
+        "/v1/internal/people/roles": {
             "get": {
-                "description": "Lists the publicly visible channels",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public-playout-channels"
+                    "people-role"
                 ],
-                "summary": "Provides the visible channels",
-                "operationId": "get-public-stream-channels",
+                "summary": "List roles",
+                "operationId": "get-people-roles-with-permissions",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/public.Channel"
+                                "$ref": "#/definitions/people.RoleWithPermissions"
                             }
                         }
                     }
                 }
             }
         },
-        "/v1/public/search": {
-            "post": {
-                "description": "Returns a virtual series that contains relevant videos and series",
+        "/v1/internal/people/roles/count": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public-series"
-                ],
-                "summary": "Search the VOD library",
-                "operationId": "search-vod",
-                "parameters": [
-                    {
-                        "description": "Search Input object",
-                        "name": "searchInput",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/public.SearchInput"
-                        }
-                    }
+                    "people-role"
                 ],
+                "summary": "List roles with count",
+                "operationId": "get-people-roles-count",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/public.Series"
+                                "$ref": "#/definitions/people.RoleWithCount"
                             }
                         }
                     }
                 }
             }
         },
-        "/v1/public/series/yearly/{year}": {
+        "/v1/internal/people/user": {
             "get": {
-                "description": "Returns a series array, virtual series that contains child series / videos\nthat were made in that year.",
+                "description": "Get a basic user object by JWT token generated by web-auth.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public-series"
-                ],
-                "summary": "Series of a year",
-                "operationId": "get-public-series-year",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Year",
-                        "name": "year",
-                        "in": "path",
-                        "required": true
-                    }
+                    "people-user"
                 ],
+                "summary": "Get a user by token",
+                "operationId": "get-user-token",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/public.Series"
-                            }
+                            "$ref": "#/definitions/people.User"
                         }
                     }
                 }
-            }
-        },
-        "/v1/public/series/{seriesid}": {
-            "get": {
-                "description": "Returns a series object, including the children videos and series.",
+            },
+            "post": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public-series"
+                    "people-user"
                 ],
-                "summary": "Provides a series",
-                "operationId": "get-public-series",
+                "summary": "Create a user",
+                "operationId": "add-people-user",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Series ID",
-                        "name": "seriesid",
-                        "in": "path",
-                        "required": true
+                        "description": "User object",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/people.User"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/public.Series"
+                            "$ref": "#/definitions/people.User"
                         }
                     }
                 }
             }
         },
-        "/v1/public/series/{seriesid}/breadcrumb": {
+        "/v1/internal/people/user/full": {
             "get": {
-                "description": "Returns a path of series to a series",
+                "description": "Get a complete user object by JWT token generated by web-auth.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public-breadcrumb"
-                ],
-                "summary": "Provides a breadcrumb for a series",
-                "operationId": "get-public-breadcrumb-series",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Series ID",
-                        "name": "seriesid",
-                        "in": "path",
-                        "required": true
-                    }
+                    "people-user"
                 ],
+                "summary": "Get a full user by token",
+                "operationId": "get-user-token-full",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/public.Breadcrumb"
+                            "$ref": "#/definitions/people.UserFull"
                         }
                     }
                 }
             }
         },
-        "/v1/public/teams": {
+        "/v1/internal/people/user/{email}": {
             "get": {
-                "description": "Lists the teams, their members, and info",
+                "description": "Get a basic user object by email.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public-teams"
+                    "people-user"
+                ],
+                "summary": "Get a user by email",
+                "operationId": "get-user-email",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Email",
+                        "name": "email",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Provides the current teams",
-                "operationId": "get-public-teams",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/public.Team"
-                            }
+                            "$ref": "#/definitions/people.User"
                         }
                     }
                 }
             }
         },
-        "/v1/public/teams/email/{emailAlias}": {
+        "/v1/internal/people/user/{email}/full": {
             "get": {
-                "description": "Contains members and a range of descriptions",
+                "description": "Get a complete user object by email.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public-teams"
+                    "people-user"
                 ],
-                "summary": "Provides the team of that year from email alias",
-                "operationId": "get-public-team-by-email",
+                "summary": "Get a full user by email",
+                "operationId": "get-user-email-full",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "emailAlias",
-                        "name": "emailAlias",
+                        "type": "integer",
+                        "description": "Email",
+                        "name": "email",
                         "in": "path",
                         "required": true
                     }
@@ -4060,42 +4165,28 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/public.Team"
+                            "$ref": "#/definitions/people.User"
                         }
                     }
                 }
             }
         },
-        "/v1/public/teams/email/{emailAlias}/{startYear}/{endYear}": {
+        "/v1/internal/people/user/{userid}": {
             "get": {
-                "description": "Get the team and their members of that year",
+                "description": "Get a basic user object by ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public-teams"
+                    "people-user"
                 ],
-                "summary": "Provides the team of a selected year",
-                "operationId": "get-public-team-start-end-year-by-email",
+                "summary": "Get a user by ID",
+                "operationId": "get-user-id",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "emailAlias",
-                        "name": "emailAlias",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "startYear",
-                        "name": "startYear",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "integer",
-                        "description": "endYear",
-                        "name": "endYear",
+                        "description": "User ID",
+                        "name": "userid",
                         "in": "path",
                         "required": true
                     }
@@ -4104,35 +4195,28 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/public.Team"
+                            "$ref": "#/definitions/people.User"
                         }
                     }
                 }
             }
         },
-        "/v1/public/teams/email/{emailAlias}/{year}": {
+        "/v1/internal/people/user/{userid}/full": {
             "get": {
-                "description": "Get the team and their members of that year",
+                "description": "Get a complete user object by ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public-teams"
+                    "people-user"
                 ],
-                "summary": "Provides the team of a selected year",
-                "operationId": "get-public-team-year-by-email",
+                "summary": "Get a full user by ID",
+                "operationId": "get-user-id-full",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "emailAlias",
-                        "name": "emailAlias",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "integer",
-                        "description": "year",
-                        "name": "year",
+                        "description": "User ID",
+                        "name": "userid",
                         "in": "path",
                         "required": true
                     }
@@ -4141,922 +4225,4465 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/public.Team"
+                            "$ref": "#/definitions/people.UserFull"
                         }
                     }
                 }
             }
         },
-        "/v1/public/teams/officers": {
+        "/v1/internal/people/users": {
             "get": {
-                "description": "Lists the current officers, including their info",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public-teams"
+                    "people-user"
                 ],
-                "summary": "Provides the current officers",
-                "operationId": "get-public-teams-officers-all",
+                "summary": "List users",
+                "operationId": "list-people-users",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/public.TeamMember"
+                                "$ref": "#/definitions/people.User"
                             }
                         }
                     }
                 }
             }
         },
-        "/v1/public/teams/teamid/{teamid}": {
+        "/v1/internal/people/users/pagination": {
             "get": {
-                "description": "Contains members and a range of descriptions",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public-teams"
+                    "people-user"
                 ],
-                "summary": "Provides the team of that year from id",
-                "operationId": "get-public-team-by-id",
+                "summary": "List users with pagination",
+                "operationId": "list-people-users-pagination",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "teamid",
-                        "name": "teamid",
-                        "in": "path",
-                        "required": true
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search string",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ordering column",
+                        "name": "column",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ordering direction",
+                        "name": "direction",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Is user enabled",
+                        "name": "enabled",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Is user deleted",
+                        "name": "deleted",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/public.Team"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/people.UserFull"
+                            }
                         }
                     }
                 }
             }
         },
-        "/v1/public/teams/teamid/{teamid}/{startYear}/{endYear}": {
+        "/v1/internal/people/users/stats": {
             "get": {
-                "description": "Get the team and their members of that year",
+                "description": "Get an overview of users.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public-teams"
+                    "people-user"
                 ],
-                "summary": "Provides the team of a selected year",
-                "operationId": "get-public-team-start-end-year-by-id",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "teamid",
-                        "name": "teamid",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "startYear",
-                        "name": "startYear",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "endYear",
-                        "name": "endYear",
-                        "in": "path",
-                        "required": true
+                "summary": "Get user stats",
+                "operationId": "get-user-stats",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/people.CountUsers"
+                        }
                     }
+                }
+            }
+        },
+        "/v1/internal/restream/incoming": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-restream"
                 ],
+                "summary": "List restream incoming streams",
+                "operationId": "get-restream-incomings",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/public.Team"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/stream.Incoming"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-restream"
+                ],
+                "summary": "New restream incoming stream",
+                "operationId": "new-restream-incoming",
+                "parameters": [
+                    {
+                        "description": "Incoming object",
+                        "name": "incoming",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/stream.Incoming"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/stream.Incoming"
                         }
                     }
                 }
             }
         },
-        "/v1/public/teams/teamid/{teamid}/{year}": {
-            "get": {
-                "description": "Get the team and their members of that year",
+        "/v1/internal/restream/incoming/{incomingid}": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public-teams"
+                    "stream-restream"
                 ],
-                "summary": "Provides the team of a selected year",
-                "operationId": "get-public-team-year-by-id",
+                "summary": "Edit restream incoming stream",
+                "operationId": "edit-restream-incoming",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "teamid",
-                        "name": "teamid",
+                        "description": "Incoming ID",
+                        "name": "incomingid",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "year",
-                        "name": "year",
-                        "in": "path",
-                        "required": true
+                        "description": "Incoming object",
+                        "name": "incoming",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/stream.Incoming"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/public.Team"
+                            "$ref": "#/definitions/stream.Incoming"
                         }
                     }
                 }
+            },
+            "delete": {
+                "tags": [
+                    "stream-restream"
+                ],
+                "summary": "Delete restream incoming stream",
+                "operationId": "delete-restream-incoming",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Incoming ID",
+                        "name": "incomingid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
             }
         },
-        "/v1/public/video/{videoid}": {
+        "/v1/internal/restream/incoming/{incomingid}/config": {
             "get": {
-                "description": "Returns a video item. Including the video files.",
+                "description": "Renders what a media server needs to push an incoming stream to every provider it's mapped to.\nffmpeg is a shell script running one ffmpeg with an output per mapping, srs is a transcode\nsection for the SRS config. It includes the providers' keys, and the ffmpeg source carries\na service playback token when the incoming stream is an endpoint, so fetch it again before that expires.",
                 "produces": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "tags": [
-                    "public-video"
+                    "stream-restream"
                 ],
-                "summary": "Provides a video item",
-                "operationId": "get-public-video",
+                "summary": "Get restream config",
+                "operationId": "get-restream-config",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Video ID",
-                        "name": "videoid",
+                        "description": "Incoming stream ID",
+                        "name": "incomingid",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ffmpeg or srs, defaults to ffmpeg",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/public.VideoItem"
+                            "type": "string"
                         }
                     }
                 }
             }
         },
-        "/v1/public/video/{videoid}/breadcrumb": {
+        "/v1/internal/restream/mappings": {
             "get": {
-                "description": "Returns a path of series to a video",
+                "description": "Lists the transcode mappings, as the \"All transcodes\" view with their IDs.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public-breadcrumb"
+                    "stream-restream"
                 ],
-                "summary": "Provides a breadcrumb for a video",
-                "operationId": "get-public-breadcrumb-video",
+                "summary": "List restream mappings",
+                "operationId": "get-restream-mappings",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Video ID",
-                        "name": "videoid",
-                        "in": "path",
-                        "required": true
+                        "description": "Only list the mappings of an incoming stream",
+                        "name": "incomingId",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/public.Breadcrumb"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/stream.TranscodeMapping"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-restream"
+                ],
+                "summary": "New restream mapping",
+                "operationId": "new-restream-mapping",
+                "parameters": [
+                    {
+                        "description": "Mapping object",
+                        "name": "mapping",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/stream.TranscodeMappingAddEdit"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/stream.TranscodeMapping"
                         }
                     }
                 }
             }
         },
-        "/v1/public/videos/{offset}/{page}": {
-            "get": {
-                "description": "List of video meta's in order of broadcast date.",
+        "/v1/internal/restream/mappings/{mappingid}": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "public-video"
+                    "stream-restream"
                 ],
-                "summary": "Provides a list of videos",
-                "operationId": "get-public-videos",
+                "summary": "Edit restream mapping",
+                "operationId": "edit-restream-mapping",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Offset",
-                        "name": "offset",
+                        "description": "Mapping ID",
+                        "name": "mappingid",
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "description": "Mapping object",
+                        "name": "mapping",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/stream.TranscodeMappingAddEdit"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/stream.TranscodeMapping"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "stream-restream"
+                ],
+                "summary": "Delete restream mapping",
+                "operationId": "delete-restream-mapping",
+                "parameters": [
                     {
                         "type": "integer",
-                        "description": "Page",
-                        "name": "page",
+                        "description": "Mapping ID",
+                        "name": "mappingid",
                         "in": "path",
                         "required": true
                     }
                 ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/v1/internal/restream/providers": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-restream"
+                ],
+                "summary": "List restream providers",
+                "operationId": "get-restream-providers",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/public.VideoMeta"
+                                "$ref": "#/definitions/stream.Provider"
                             }
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "clapper.Attendee": {
-            "type": "object",
-            "properties": {
-                "attendStatus": {
-                    "type": "string"
-                },
-                "firstName": {
-                    "type": "string"
-                },
-                "lastName": {
-                    "type": "string"
-                },
-                "nickname": {
-                    "type": "string"
-                },
-                "userID": {
-                    "type": "integer"
+            },
+            "post": {
+                "description": "Keys are masked, when editing leave the key empty or masked to keep it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-restream"
+                ],
+                "summary": "New restream provider",
+                "operationId": "new-restream-provider",
+                "parameters": [
+                    {
+                        "description": "Provider object",
+                        "name": "provider",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/stream.Provider"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/stream.Provider"
+                        }
+                    }
                 }
             }
         },
-        "clapper.CrewPosition": {
-            "type": "object",
-            "properties": {
-                "admin": {
-                    "type": "boolean"
-                },
-                "credited": {
-                    "type": "boolean"
-                },
-                "crewID": {
-                    "type": "integer"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "locked": {
-                    "type": "boolean"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "ordering": {
-                    "type": "integer"
-                },
-                "permissionID": {
-                    "type": "integer"
+        "/v1/internal/restream/providers/{providerid}": {
+            "put": {
+                "description": "Keys are masked, when editing leave the key empty or masked to keep it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-restream"
+                ],
+                "summary": "Edit restream provider",
+                "operationId": "edit-restream-provider",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Provider ID",
+                        "name": "providerid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Provider object",
+                        "name": "provider",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/stream.Provider"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/stream.Provider"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "stream-restream"
+                ],
+                "summary": "Delete restream provider",
+                "operationId": "delete-restream-provider",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Provider ID",
+                        "name": "providerid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/v1/internal/restream/qualities": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-restream"
+                ],
+                "summary": "List restream qualities",
+                "operationId": "get-restream-qualitys",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/stream.Quality"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "The command is ffmpeg output options, checked the same as encode formats.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-restream"
+                ],
+                "summary": "New restream quality",
+                "operationId": "new-restream-quality",
+                "parameters": [
+                    {
+                        "description": "Quality object",
+                        "name": "quality",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/stream.Quality"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/stream.Quality"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/internal/restream/qualities/{qualityid}": {
+            "put": {
+                "description": "The command is ffmpeg output options, checked the same as encode formats.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-restream"
+                ],
+                "summary": "Edit restream quality",
+                "operationId": "edit-restream-quality",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Quality ID",
+                        "name": "qualityid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Quality object",
+                        "name": "quality",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/stream.Quality"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/stream.Quality"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "stream-restream"
+                ],
+                "summary": "Delete restream quality",
+                "operationId": "delete-restream-quality",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Quality ID",
+                        "name": "qualityid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/v1/internal/stream/play": {
+            "post": {
+                "description": "Checks a viewer can play a stream; this is for SRS on_play and Nginx RTMP on_play.\nStreams feeding only internal channels need a playback token for one of those channels,\nor a service token for the stream, passed as the token query parameter. Anything else,\nincluding streams that feed a public or unlisted channel or no channel at all, can be played by anyone.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-endpoints"
+                ],
+                "summary": "Play a stream",
+                "operationId": "play-stream",
+                "responses": {
+                    "200": {
+                        "description": "Playback allowed",
+                        "schema": {
+                            "type": "body"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/internal/stream/playback/{channelShortName}": {
+            "post": {
+                "description": "Issues a short-lived token to play the stream feeding a channel, for channels that\naren't public. It's bound to the channel and only checked when playback starts.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-endpoints"
+                ],
+                "summary": "New playback token",
+                "operationId": "new-stream-playback-token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel short name",
+                        "name": "channelShortName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/stream.PlaybackToken"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/internal/stream/publish": {
+            "post": {
+                "description": "Checks existing stream endpoints and changes it to active; this is for Nginx RTMP module\ncontaining the application, name and pwd. Endpoints outside their start and end valid\ntimes are refused. Scheduled channels fed by the endpoint go live.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-endpoints"
+                ],
+                "summary": "Publish a stream",
+                "operationId": "publish-stream",
+                "responses": {
+                    "200": {
+                        "description": "Endpoint published",
+                        "schema": {
+                            "type": "body"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/internal/stream/recording": {
+            "post": {
+                "description": "Makes a finished recording into a private video in the recordings series, with the\nrecording as its source file, and applies the recordings preset so it's encoded.\nThis is for SRS on_dvr and Nginx RTMP record_done. The recording's path has to be\nbelow where the recordings bucket is mounted. Nothing happens when recordings aren't set up.\nThe media servers have to pass the recording secret in the hook URL.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-endpoints"
+                ],
+                "summary": "Register a stream recording",
+                "operationId": "recording-done",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recording hook secret",
+                        "name": "secret",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recording registered",
+                        "schema": {
+                            "type": "body"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/internal/stream/unpublish": {
+            "post": {
+                "description": "Checks existing stream endpoints and changes it to inactive; this is for Nginx RTMP module\ncontaining the application, name, authentication and start and end times.\nLive channels fed by the endpoint are finished, or scheduled again before their end.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-endpoints"
+                ],
+                "summary": "Unpublish a stream",
+                "operationId": "unpublish-stream",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "body"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/internal/streams": {
+            "get": {
+                "description": "Lists all stream endpoints; this is for Nginx RTMP module\ncontaining the application, name, authentication and start and end times.\nEndpoints that stop being valid within expiringWithin are marked as expiring soon,\nsetting expiring lists only those, soonest first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-endpoints"
+                ],
+                "summary": "ListStreams stream endpoints",
+                "operationId": "get-stream",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Duration counted as expiring soon, defaults to 24h",
+                        "name": "expiringWithin",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only list endpoints expiring soon",
+                        "name": "expiring",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/stream.Endpoint"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new stream endpoint; this is for Nginx RTMP module\ncontaining the application, name, authentication and start and end times",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-endpoints"
+                ],
+                "summary": "NewStream stream endpoint",
+                "operationId": "new-stream",
+                "parameters": [
+                    {
+                        "description": "Stream endpoint object",
+                        "name": "endpoint",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/stream.EndpointAddEditDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/stream.Endpoint"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/internal/streams/find": {
+            "get": {
+                "description": "finds existing stream",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-endpoints"
+                ],
+                "summary": "Finds stream",
+                "operationId": "find-stream",
+                "parameters": [
+                    {
+                        "description": "Find Endpoint object",
+                        "name": "endpoint",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/stream.FindEndpoint"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/stream.Endpoint"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/internal/streams/guest": {
+            "post": {
+                "description": "Creates an endpoint with a generated key that is removed once it stops being valid,\nreturning the key with instructions to stream to it. The key is only returned here.\nWhen an email is given the instructions are also sent there.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-endpoints"
+                ],
+                "summary": "New guest stream endpoint",
+                "operationId": "new-stream-guest",
+                "parameters": [
+                    {
+                        "description": "Guest endpoint object",
+                        "name": "guest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/stream.GuestEndpointNew"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/stream.GuestKey"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/internal/streams/sessions": {
+            "get": {
+                "description": "Lists the sessions of every stream endpoint, including removed ones, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-endpoints"
+                ],
+                "summary": "List recent stream sessions",
+                "operationId": "get-stream-sessions-recent",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Opaque page cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.CursorPage-stream_Session"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/internal/streams/{endpointid}": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-endpoints"
+                ],
+                "summary": "Edit stream endpoint",
+                "operationId": "edit-stream",
+                "parameters": [
+                    {
+                        "description": "Endpoint object",
+                        "name": "endpoint",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/stream.EndpointAddEditDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/stream.Endpoint"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-endpoints"
+                ],
+                "summary": "Delete stream endpoint",
+                "operationId": "delete-stream",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Endpoint ID",
+                        "name": "endpointid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/v1/internal/streams/{endpointid}/metrics": {
+            "get": {
+                "description": "Lists the samples taken of a stream endpoint while it was live, oldest first.\nSamples are taken by polling the media servers and are kept for a week.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-endpoints"
+                ],
+                "summary": "List stream endpoint metrics",
+                "operationId": "get-stream-metrics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Endpoint ID",
+                        "name": "endpointid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 time to list from, defaults to an hour ago",
+                        "name": "since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/stream.Metric"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/internal/streams/{endpointid}/playback-token": {
+            "post": {
+                "description": "Issues a long-lived token to play an endpoint's stream whatever channels it feeds, for\nrelays, recorders and restreams that pull it. It's bound to the stream and only checked\nwhen playback starts.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-endpoints"
+                ],
+                "summary": "New service playback token",
+                "operationId": "new-stream-service-playback-token",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Endpoint ID",
+                        "name": "endpointid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "How long the token works for, defaults to 720h",
+                        "name": "validity",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/stream.PlaybackToken"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/internal/streams/{endpointid}/revoke": {
+            "post": {
+                "description": "Blocks and expires an endpoint, taking anything it feeds off air.\nEndpoints set to auto remove, like guest endpoints, are deleted.",
+                "tags": [
+                    "stream-endpoints"
+                ],
+                "summary": "Revoke stream endpoint",
+                "operationId": "revoke-stream",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Endpoint ID",
+                        "name": "endpointid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    }
+                }
+            }
+        },
+        "/v1/internal/streams/{endpointid}/rotate-key": {
+            "post": {
+                "description": "Replaces the endpoint's key with a random one. The new key is only returned in this\nresponse, it's stored hashed and masked everywhere else.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-endpoints"
+                ],
+                "summary": "Rotate stream endpoint key",
+                "operationId": "rotate-stream-key",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Endpoint ID",
+                        "name": "endpointid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/stream.RotatedKey"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/internal/streams/{endpointid}/sessions": {
+            "get": {
+                "description": "Lists when a stream endpoint published, from where, to which server and for how long, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stream-endpoints"
+                ],
+                "summary": "List stream endpoint sessions",
+                "operationId": "get-stream-sessions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Endpoint ID",
+                        "name": "endpointid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque page cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.CursorPage-stream_Session"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/list_unsubscribe/{uuid}": {
+            "get": {
+                "description": "Unsubscribe to a mailing list by a subscriber UUID",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "misc-list"
+                ],
+                "summary": "Unsubscribe to mailing list by subscriber UUID",
+                "operationId": "delete-mailing-list-subscriber-uuid",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subscriber UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/v1/public/custom-setting/{settingid}": {
+            "get": {
+                "description": "Contains a single setting value in string format",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-custom-settings"
+                ],
+                "summary": "Provides a single public setting",
+                "operationId": "get-public-custom-setting",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Setting id",
+                        "name": "settingid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/public.CustomSetting"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/find/{url}": {
+            "get": {
+                "description": "Allows us to remain backwards compatible with the existing URLs.\nPaths matching a redirect return 301 with the target in Location and the body.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-breadcrumb"
+                ],
+                "summary": "Converts a VOD url to either a series or video",
+                "operationId": "get-public-breadcrumb-find",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "URL Path",
+                        "name": "url",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/public.BreadcrumbItem"
+                        }
+                    },
+                    "301": {
+                        "description": "Moved Permanently",
+                        "schema": {
+                            "$ref": "#/definitions/public.BreadcrumbItem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/meta/playlist/{playlistid}": {
+            "get": {
+                "description": "Returns the OpenGraph and Twitter card tags of a public playlist.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-embed"
+                ],
+                "summary": "Playlist page metadata",
+                "operationId": "get-public-meta-playlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Playlist ID",
+                        "name": "playlistid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/public.PageMeta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/meta/series/{seriesid}": {
+            "get": {
+                "description": "Returns the OpenGraph and Twitter card tags of a public series.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-embed"
+                ],
+                "summary": "Series page metadata",
+                "operationId": "get-public-meta-series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "seriesid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/public.PageMeta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/meta/video/{videoid}": {
+            "get": {
+                "description": "Returns the OpenGraph and Twitter card tags of a public video.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-embed"
+                ],
+                "summary": "Video page metadata",
+                "operationId": "get-public-meta-video",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Video ID",
+                        "name": "videoid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/public.PageMeta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/oembed": {
+            "get": {
+                "description": "Returns the oEmbed of a public video or series page, so links unfurl\nwith a player. Only the JSON format is supported.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-embed"
+                ],
+                "summary": "oEmbed provider",
+                "operationId": "get-public-oembed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Public site URL",
+                        "name": "url",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum player width",
+                        "name": "maxwidth",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum player height",
+                        "name": "maxheight",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format, must be json",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/public.OEmbed"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/playlist/popular/all": {
+            "get": {
+                "description": "Provides a fake playlist, containing a list of popular videos",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-playlist"
+                ],
+                "summary": "Provides a playlist of popular videos of all time",
+                "operationId": "get-public-playlist-popular-by-all-time",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/public.Playlist"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/playlist/popular/month": {
+            "get": {
+                "description": "Provides a fake playlist, containing a list of popular videos",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-playlist"
+                ],
+                "summary": "Provides a playlist of popular videos of past month",
+                "operationId": "get-public-playlist-popular-by-past-month",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/public.Playlist"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/playlist/popular/year": {
+            "get": {
+                "description": "Provides a fake playlist, containing a list of popular videos",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-playlist"
+                ],
+                "summary": "Provides a playlist of popular videos of past year",
+                "operationId": "get-public-playlist-popular-by-past-year",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/public.Playlist"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/playlist/random": {
+            "get": {
+                "description": "Provides a fake playlist, containing a list of random videos",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-playlist"
+                ],
+                "summary": "Provides a playlist of random videos",
+                "operationId": "get-public-playlist-random",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/public.Playlist"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/playlist/{playlistid}/feed.rss": {
+            "get": {
+                "description": "RSS feed of the public videos in a playlist, in playlist order.\nSetting format turns it into a podcast with enclosures of that encode format.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "public-playlist"
+                ],
+                "summary": "Playlist RSS feed",
+                "operationId": "get-public-playlist-feed-rss",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Playlist ID",
+                        "name": "playlistid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Encode format ID for podcast enclosures",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/playlist/{playlistid}/videos": {
+            "get": {
+                "description": "List of public video meta's in the playlist, in playlist order.\nFollow the next and prev links to page through the list.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-playlist"
+                ],
+                "summary": "Provides a list of a playlist's videos",
+                "operationId": "get-public-playlist-videos",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Playlist ID",
+                        "name": "playlistid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque page cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.CursorPage-public_VideoMeta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/playlist/{seriesid}": {
+            "get": {
+                "description": "Returns a playlist object, includes videos (not video files) and metadata.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-playlist"
+                ],
+                "summary": "Provides a playlist",
+                "operationId": "get-public-playlist",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Playlist ID",
+                        "name": "playlistid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/public.Playlist"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/playout/channel/{channelShortName}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-playout-channels"
+                ],
+                "summary": "Provides a public or unlisted channel",
+                "operationId": "get-public-stream-channel",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Channel short name",
+                        "name": "channelShortName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/public.Channel"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/playout/channel/{channelShortName}/guide.xml": {
+            "get": {
+                "description": "XMLTV guide of a public or unlisted channel, from the day before through the next week.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "public-playout-channels"
+                ],
+                "summary": "Channel XMLTV guide",
+                "operationId": "get-public-stream-channel-guide",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel short name",
+                        "name": "channelShortName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/playout/channel/{channelShortName}/now": {
+            "get": {
+                "description": "What is on a public or unlisted channel's schedule now and what is on next,\neither is null when nothing is scheduled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-playout-channels"
+                ],
+                "summary": "Provides what is on a channel now and next",
+                "operationId": "get-public-stream-channel-now-next",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Channel short name",
+                        "name": "channelShortName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/public.NowNext"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/playout/channels": {
+            "get": {
+                "description": "Lists the publicly visible channels",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-playout-channels"
+                ],
+                "summary": "Provides the visible channels",
+                "operationId": "get-public-stream-channels",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/public.Channel"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/playout/guide.xml": {
+            "get": {
+                "description": "XMLTV guide of every public channel, from the day before through the next week.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "public-playout-channels"
+                ],
+                "summary": "XMLTV guide",
+                "operationId": "get-public-stream-guide",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/s/{code}": {
+            "get": {
+                "description": "Redirects to the target of a short link, recording the click.",
+                "tags": [
+                    "misc-short-links"
+                ],
+                "summary": "Follow short link",
+                "operationId": "get-public-short-link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Short link code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "410": {
+                        "description": "Gone"
+                    }
+                }
+            }
+        },
+        "/v1/public/search": {
+            "post": {
+                "description": "Returns a page of public videos ranked by relevance to the query, with highlighted snippets.\nResults can be filtered to a series (and its child series), a broadcast year and a duration range.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-series"
+                ],
+                "summary": "Search the VOD library",
+                "operationId": "search-vod",
+                "parameters": [
+                    {
+                        "description": "Search parameters object",
+                        "name": "searchInput",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/search.Params"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/search.Results"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/series/yearly/{year}": {
+            "get": {
+                "description": "Returns a series array, virtual series that contains child series / videos\nthat were made in that year.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-series"
+                ],
+                "summary": "Series of a year",
+                "operationId": "get-public-series-year",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Year",
+                        "name": "year",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/public.Series"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/series/{id}/videos": {
+            "get": {
+                "description": "List of video meta's belonging to the series, in series order.\nFollow the next and prev links to page through the list.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-series"
+                ],
+                "summary": "Provides a list of a series' videos",
+                "operationId": "get-public-series-videos",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque page cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.CursorPage-public_VideoMeta"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/series/{seriesid}": {
+            "get": {
+                "description": "Returns a series object, including the children videos and series.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-series"
+                ],
+                "summary": "Provides a series",
+                "operationId": "get-public-series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "seriesid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/public.Series"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/series/{seriesid}/breadcrumb": {
+            "get": {
+                "description": "Returns a path of series to a series",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-breadcrumb"
+                ],
+                "summary": "Provides a breadcrumb for a series",
+                "operationId": "get-public-breadcrumb-series",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "seriesid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/public.Breadcrumb"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/series/{seriesid}/feed.atom": {
+            "get": {
+                "description": "Atom feed of the most recent public videos in a series and its child series.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "public-series"
+                ],
+                "summary": "Series Atom feed",
+                "operationId": "get-public-series-feed-atom",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "seriesid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/series/{seriesid}/feed.rss": {
+            "get": {
+                "description": "RSS feed of the most recent public videos in a series and its child series.\nSetting format turns it into a podcast with enclosures of that encode format.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "public-series"
+                ],
+                "summary": "Series RSS feed",
+                "operationId": "get-public-series-feed-rss",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "seriesid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Encode format ID for podcast enclosures",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/sitemap.xml": {
+            "get": {
+                "description": "Sitemap index of the public video library, linking to the series\nsitemap and each chunk of the video sitemap.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "public-sitemap"
+                ],
+                "summary": "Sitemap index",
+                "operationId": "get-public-sitemap",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/sitemap/series.xml": {
+            "get": {
+                "description": "Sitemap of every public series.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "public-sitemap"
+                ],
+                "summary": "Series sitemap",
+                "operationId": "get-public-sitemap-series",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/sitemap/videos/{chunk}": {
+            "get": {
+                "description": "A chunk of the video sitemap, with video extensions.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "public-sitemap"
+                ],
+                "summary": "Video sitemap",
+                "operationId": "get-public-sitemap-videos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Chunk, i.e. 0.xml",
+                        "name": "chunk",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/teams": {
+            "get": {
+                "description": "Lists the teams, their members, and info",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-teams"
+                ],
+                "summary": "Provides the current teams",
+                "operationId": "get-public-teams",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/public.Team"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/teams/email/{emailAlias}": {
+            "get": {
+                "description": "Contains members and a range of descriptions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-teams"
+                ],
+                "summary": "Provides the team of that year from email alias",
+                "operationId": "get-public-team-by-email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "emailAlias",
+                        "name": "emailAlias",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/public.Team"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/teams/email/{emailAlias}/{startYear}/{endYear}": {
+            "get": {
+                "description": "Get the team and their members of that year",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-teams"
+                ],
+                "summary": "Provides the team of a selected year",
+                "operationId": "get-public-team-start-end-year-by-email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "emailAlias",
+                        "name": "emailAlias",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "startYear",
+                        "name": "startYear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "endYear",
+                        "name": "endYear",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/public.Team"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/teams/email/{emailAlias}/{year}": {
+            "get": {
+                "description": "Get the team and their members of that year",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-teams"
+                ],
+                "summary": "Provides the team of a selected year",
+                "operationId": "get-public-team-year-by-email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "emailAlias",
+                        "name": "emailAlias",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "year",
+                        "name": "year",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/public.Team"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/teams/officers": {
+            "get": {
+                "description": "Lists the current officers, including their info",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-teams"
+                ],
+                "summary": "Provides the current officers",
+                "operationId": "get-public-teams-officers-all",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/public.TeamMember"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/teams/teamid/{teamid}": {
+            "get": {
+                "description": "Contains members and a range of descriptions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-teams"
+                ],
+                "summary": "Provides the team of that year from id",
+                "operationId": "get-public-team-by-id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "teamid",
+                        "name": "teamid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/public.Team"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/teams/teamid/{teamid}/{startYear}/{endYear}": {
+            "get": {
+                "description": "Get the team and their members of that year",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-teams"
+                ],
+                "summary": "Provides the team of a selected year",
+                "operationId": "get-public-team-start-end-year-by-id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "teamid",
+                        "name": "teamid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "startYear",
+                        "name": "startYear",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "endYear",
+                        "name": "endYear",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/public.Team"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/teams/teamid/{teamid}/{year}": {
+            "get": {
+                "description": "Get the team and their members of that year",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-teams"
+                ],
+                "summary": "Provides the team of a selected year",
+                "operationId": "get-public-team-year-by-id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "teamid",
+                        "name": "teamid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "year",
+                        "name": "year",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/public.Team"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/video/{videoid}": {
+            "get": {
+                "description": "Returns a video item. Including the video files.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-video"
+                ],
+                "summary": "Provides a video item",
+                "operationId": "get-public-video",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Video ID",
+                        "name": "videoid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/public.VideoItem"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/video/{videoid}/breadcrumb": {
+            "get": {
+                "description": "Returns a path of series to a video",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-breadcrumb"
+                ],
+                "summary": "Provides a breadcrumb for a video",
+                "operationId": "get-public-breadcrumb-video",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Video ID",
+                        "name": "videoid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/public.Breadcrumb"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/video/{videoid}/related": {
+            "get": {
+                "description": "Lists public videos related to a video, scored on being in the same series,\nsharing tags, having a similar name and being watched together.\nThe weighting is set by the \"related_videos\" custom setting.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-video"
+                ],
+                "summary": "Provides related videos",
+                "operationId": "get-public-video-related",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Video ID",
+                        "name": "videoid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/public.RelatedVideo"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/videos": {
+            "get": {
+                "description": "List of video meta's in order of broadcast date, newest first.\nFollow the next and prev links to page through the list.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "public-video"
+                ],
+                "summary": "Provides a list of videos",
+                "operationId": "get-public-videos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Opaque page cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.CursorPage-public_VideoMeta"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "clapper.Attendee": {
+            "type": "object",
+            "properties": {
+                "attendStatus": {
+                    "type": "string"
+                },
+                "firstName": {
+                    "type": "string"
+                },
+                "lastName": {
+                    "type": "string"
+                },
+                "nickname": {
+                    "type": "string"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "clapper.CrewPosition": {
+            "type": "object",
+            "properties": {
+                "admin": {
+                    "type": "boolean"
+                },
+                "credited": {
+                    "type": "boolean"
+                },
+                "crewID": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "locked": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ordering": {
+                    "type": "integer"
+                },
+                "permissionID": {
+                    "type": "integer"
+                },
+                "positionID": {
+                    "type": "integer"
+                },
+                "user": {
+                    "$ref": "#/definitions/clapper.User"
+                }
+            }
+        },
+        "clapper.Event": {
+            "type": "object",
+            "properties": {
+                "attendees": {
+                    "description": "Used for social, meet and other. This would be an XOR with Signups",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/clapper.Attendee"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "endDate": {
+                    "type": "string"
+                },
+                "eventID": {
+                    "type": "integer"
+                },
+                "eventType": {
+                    "type": "string"
+                },
+                "isCancelled": {
+                    "type": "boolean"
+                },
+                "isPrivate": {
+                    "type": "boolean"
+                },
+                "isTentative": {
+                    "type": "boolean"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "signups": {
+                    "description": "Used for shows",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/clapper.Signup"
+                    }
+                },
+                "startDate": {
+                    "type": "string"
+                }
+            }
+        },
+        "clapper.NewCrew": {
+            "type": "object",
+            "properties": {
+                "credited": {
+                    "type": "boolean"
+                },
+                "locked": {
+                    "type": "boolean"
+                },
+                "ordering": {
+                    "type": "integer"
+                },
+                "positionID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "clapper.NewEvent": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "endDate": {
+                    "type": "string"
+                },
+                "eventType": {
+                    "type": "string"
+                },
+                "isCancelled": {
+                    "type": "boolean"
+                },
+                "isPrivate": {
+                    "type": "boolean"
+                },
+                "isTentative": {
+                    "type": "boolean"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "startDate": {
+                    "type": "string"
+                }
+            }
+        },
+        "clapper.NewSignup": {
+            "type": "object",
+            "properties": {
+                "arrivalTime": {
+                    "type": "string"
+                },
+                "crew": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/clapper.NewCrew"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "endTime": {
+                    "type": "string"
+                },
+                "eventID": {
+                    "type": "integer"
+                },
+                "startTime": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "unlockDate": {
+                    "type": "string"
+                }
+            }
+        },
+        "clapper.Position": {
+            "type": "object",
+            "properties": {
+                "admin": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "permissionID": {
+                    "type": "integer"
+                },
+                "positionID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "clapper.Signup": {
+            "type": "object",
+            "properties": {
+                "arrivalTime": {
+                    "type": "string"
+                },
+                "crew": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/clapper.CrewPosition"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "endTime": {
+                    "type": "string"
+                },
+                "signupID": {
+                    "type": "integer"
+                },
+                "startTime": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "unlockDate": {
+                    "type": "string"
+                }
+            }
+        },
+        "clapper.User": {
+            "type": "object",
+            "properties": {
+                "firstName": {
+                    "type": "string"
+                },
+                "lastName": {
+                    "type": "string"
+                },
+                "nickname": {
+                    "type": "string"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "creator.NewVideoOutput": {
+            "type": "object",
+            "properties": {
+                "encodeError": {
+                    "description": "EncodeError is set when the video was created but its preset couldn't be applied",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "customsettings.CustomSetting": {
+            "type": "object",
+            "properties": {
+                "public": {
+                    "type": "boolean"
+                },
+                "settingID": {
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Value will be returned in Base64 from PostgreSQL"
+                }
+            }
+        },
+        "customsettings.CustomSettingEditDTO": {
+            "type": "object",
+            "properties": {
+                "public": {
+                    "type": "boolean"
+                },
+                "value": {
+                    "description": "Value will be returned in Base64 from PostgreSQL",
+                    "type": "string"
+                }
+            }
+        },
+        "encode.FieldChange": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "encode.Format": {
+            "type": "object",
+            "properties": {
+                "arguments": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "fileSuffix": {
+                    "type": "string"
+                },
+                "formatID": {
+                    "type": "integer"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "mimeType": {
+                    "type": "string"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "watermarked": {
+                    "type": "boolean"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "encode.FormatDocument": {
+            "type": "object",
+            "properties": {
+                "arguments": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "fileSuffix": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "mimeType": {
+                    "type": "string"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "watermarked": {
+                    "type": "boolean"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "encode.ImportChange": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Action is create, update or unchanged",
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/encode.FieldChange"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "encode.ImportReport": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "boolean"
+                },
+                "formats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/encode.ImportChange"
+                    }
+                },
+                "presets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/encode.ImportChange"
+                    }
+                }
+            }
+        },
+        "encode.Preset": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "formats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/encode.Format"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "presetID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "encode.PresetDocument": {
+            "type": "object",
+            "properties": {
+                "formats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/encode.FormatDocument"
+                    }
+                },
+                "presets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/encode.PresetDocumentEntry"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "encode.PresetDocumentEntry": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "formats": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "encoder.EncodePreview": {
+            "type": "object",
+            "properties": {
+                "command": {
+                    "description": "Command is the ffmpeg command the task amounts to",
+                    "type": "string"
+                },
+                "dstKey": {
+                    "description": "DstKey is the key the encode will be written to in the serve bucket",
+                    "type": "string"
+                },
+                "fileID": {
+                    "type": "integer"
+                },
+                "sourceExists": {
+                    "description": "SourceExists is false when the source object can't be found,\nCreateEncode would fail",
+                    "type": "boolean"
+                },
+                "task": {
+                    "$ref": "#/definitions/encoder.EncodeTask"
+                }
+            }
+        },
+        "encoder.EncodeTask": {
+            "type": "object",
+            "properties": {
+                "dstArgs": {
+                    "type": "string"
+                },
+                "dstURL": {
+                    "type": "string"
+                },
+                "srcURL": {
+                    "type": "string"
+                }
+            }
+        },
+        "misc.List": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "isSubscribed": {
+                    "type": "boolean"
+                },
+                "listID": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "permissionID": {
+                    "type": "integer"
+                },
+                "subscribers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/misc.Subscriber"
+                    }
+                }
+            }
+        },
+        "misc.Quote": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "quote": {
+                    "type": "string"
+                }
+            }
+        },
+        "misc.Redirect": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "integer"
+                },
+                "destinationURL": {
+                    "description": "DestinationURL is a site path, or a full URL when External.\nWildcard rules can use $1, $2... for whatever each * matched.",
+                    "type": "string"
+                },
+                "external": {
+                    "type": "boolean"
+                },
+                "hits": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "matchType": {
+                    "type": "string"
+                },
+                "sourceURL": {
+                    "description": "SourceURL is the path being redirected, without leading or trailing slashes.\nWildcard rules use * to match anything.",
+                    "type": "string"
+                }
+            }
+        },
+        "misc.ResolvedRedirect": {
+            "type": "object",
+            "properties": {
+                "external": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "target": {
+                    "description": "Target is the destination with any prefix remainder or wildcards filled in",
+                    "type": "string"
+                }
+            }
+        },
+        "misc.ShortLink": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "description": "Clicks is the total number of times the link has been followed",
+                    "type": "integer"
+                },
+                "code": {
+                    "description": "Code is a vanity code, or generated when left empty",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "integer"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "shortURL": {
+                    "type": "string"
+                },
+                "targetID": {
+                    "description": "TargetID is the ID of the video, series, playlist or channel",
+                    "type": "integer"
+                },
+                "targetType": {
+                    "description": "TargetType is video, series, playlist, channel or url",
+                    "type": "string"
+                },
+                "targetURL": {
+                    "description": "TargetURL is the URL when the target type is url",
+                    "type": "string"
+                }
+            }
+        },
+        "misc.ShortLinkAnalytics": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/misc.ShortLinkDay"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "referrers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/misc.ShortLinkReferrer"
+                    }
+                },
+                "since": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "misc.ShortLinkDay": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "type": "integer"
+                },
+                "day": {
+                    "type": "string"
+                }
+            }
+        },
+        "misc.ShortLinkReferrer": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "type": "integer"
+                },
+                "referrer": {
+                    "type": "string"
+                }
+            }
+        },
+        "misc.Subscriber": {
+            "type": "object",
+            "properties": {
+                "subscribeID": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/people.User"
+                }
+            }
+        },
+        "misc.Webcam": {
+            "type": "object",
+            "properties": {
+                "file": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mimeType": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "people.CountOfficerships": {
+            "type": "object",
+            "properties": {
+                "currentOfficers": {
+                    "type": "integer"
+                },
+                "currentOfficerships": {
+                    "type": "integer"
+                },
+                "totalOfficers": {
+                    "type": "integer"
+                },
+                "totalOfficerships": {
+                    "type": "integer"
+                }
+            }
+        },
+        "people.CountUsers": {
+            "type": "object",
+            "properties": {
+                "activeUsers": {
+                    "type": "integer"
+                },
+                "activeUsersPast24Hours": {
+                    "type": "integer"
+                },
+                "activeUsersPastYear": {
+                    "type": "integer"
+                },
+                "totalUsers": {
+                    "type": "integer"
+                }
+            }
+        },
+        "people.Officership": {
+            "type": "object",
+            "properties": {
+                "currentOfficers": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "emailAlias": {
+                    "type": "string"
+                },
+                "historyWikiURL": {
+                    "type": "string"
+                },
+                "ifUnfilled": {
+                    "type": "boolean"
+                },
+                "isCurrent": {
+                    "type": "boolean"
+                },
+                "isTeamDeputy": {
+                    "type": "boolean"
+                },
+                "isTeamLeader": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "officershipID": {
+                    "type": "integer"
+                },
+                "previousOfficers": {
+                    "type": "integer"
+                },
+                "roleID": {
+                    "type": "integer"
+                },
+                "teamID": {
+                    "type": "integer"
+                },
+                "teamName": {
+                    "type": "string"
+                }
+            }
+        },
+        "people.OfficershipAddEditDTO": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "emailAlias": {
+                    "type": "string"
+                },
+                "historyWikiURL": {
+                    "type": "string"
+                },
+                "isCurrent": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "people.OfficershipMember": {
+            "type": "object",
+            "properties": {
+                "endDate": {
+                    "type": "string"
+                },
+                "officerID": {
+                    "type": "integer"
+                },
+                "officershipMemberID": {
+                    "type": "integer"
+                },
+                "officershipName": {
+                    "type": "string"
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "teamID": {
+                    "type": "integer"
+                },
+                "teamName": {
+                    "type": "string"
+                },
+                "userID": {
+                    "type": "integer"
+                },
+                "userName": {
+                    "type": "string"
+                }
+            }
+        },
+        "people.OfficershipMemberAddEditDTO": {
+            "type": "object",
+            "properties": {
+                "endDate": {
+                    "type": "string"
                 },
-                "positionID": {
+                "officerID": {
+                    "type": "integer"
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "people.OfficershipTeam": {
+            "type": "object",
+            "properties": {
+                "currentOfficers": {
+                    "type": "integer"
+                },
+                "currentOfficerships": {
+                    "type": "integer"
+                },
+                "emailAlias": {
+                    "type": "string"
+                },
+                "fullDescription": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "shortDescription": {
+                    "type": "string"
+                },
+                "teamID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "people.OfficershipTeamAddEditDTO": {
+            "type": "object",
+            "properties": {
+                "emailAlias": {
+                    "type": "string"
+                },
+                "fullDescription": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "shortDescription": {
+                    "type": "string"
+                }
+            }
+        },
+        "people.OfficershipTeamMember": {
+            "type": "object",
+            "properties": {
+                "currentOfficers": {
+                    "type": "integer"
+                },
+                "isCurrent": {
+                    "type": "boolean"
+                },
+                "isDeputy": {
+                    "type": "boolean"
+                },
+                "isLeader": {
+                    "type": "boolean"
+                },
+                "officerID": {
+                    "type": "integer"
+                },
+                "officerName": {
+                    "type": "string"
+                },
+                "officershipTeamMemberID": {
+                    "type": "integer"
+                },
+                "previousOfficers": {
+                    "type": "integer"
+                }
+            }
+        },
+        "people.OfficershipTeamMemberAddDTO": {
+            "type": "object",
+            "properties": {
+                "isDeputy": {
+                    "type": "boolean"
+                },
+                "isLeader": {
+                    "type": "boolean"
+                },
+                "officerID": {
+                    "type": "integer"
+                },
+                "officershipTeamMemberID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "people.Permission": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "people.PermissionAddEditDTO": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "people.PermissionWithRolesCount": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "roles": {
+                    "type": "integer"
+                }
+            }
+        },
+        "people.Role": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "people.RoleAddEditDTO": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "people.RoleFull": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/people.Permission"
+                    }
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/people.User"
+                    }
+                }
+            }
+        },
+        "people.RolePermission": {
+            "type": "object",
+            "properties": {
+                "permissionID": {
+                    "type": "integer"
+                },
+                "roleID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "people.RoleUser": {
+            "type": "object",
+            "properties": {
+                "roleID": {
+                    "type": "integer"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "people.RoleWithCount": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "type": "integer"
+                },
+                "users": {
+                    "type": "integer"
+                }
+            }
+        },
+        "people.RoleWithPermissions": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "user": {
-                    "$ref": "#/definitions/clapper.User"
+                "name": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/people.Permission"
+                    }
                 }
             }
         },
-        "clapper.Event": {
+        "people.User": {
             "type": "object",
             "properties": {
-                "attendees": {
-                    "description": "Used for social, meet and other. This would be an XOR with Signups",
+                "avatar": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "firstName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastName": {
+                    "type": "string"
+                },
+                "nickname": {
+                    "type": "string"
+                },
+                "permissions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/clapper.Attendee"
+                        "$ref": "#/definitions/people.Permission"
                     }
                 },
-                "description": {
+                "useGravatar": {
+                    "type": "boolean"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "people.UserFull": {
+            "type": "object",
+            "properties": {
+                "avatar": {
                     "type": "string"
                 },
-                "endDate": {
+                "createdAt": {
                     "type": "string"
                 },
-                "eventID": {
+                "createdBy": {
                     "type": "integer"
                 },
-                "eventType": {
+                "deletedAt": {
                     "type": "string"
                 },
-                "isCancelled": {
-                    "type": "boolean"
+                "deletedBy": {
+                    "type": "integer"
                 },
-                "isPrivate": {
-                    "type": "boolean"
+                "email": {
+                    "type": "string"
                 },
-                "isTentative": {
+                "enabled": {
                     "type": "boolean"
                 },
-                "location": {
+                "firstName": {
                     "type": "string"
                 },
-                "name": {
+                "id": {
+                    "type": "integer"
+                },
+                "lastLogin": {
                     "type": "string"
                 },
-                "signups": {
-                    "description": "Used for shows",
+                "lastName": {
+                    "type": "string"
+                },
+                "nickname": {
+                    "type": "string"
+                },
+                "permissions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/clapper.Signup"
+                        "$ref": "#/definitions/people.Permission"
                     }
                 },
-                "startDate": {
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/people.Role"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "type": "integer"
+                },
+                "useGravatar": {
+                    "type": "boolean"
+                },
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "clapper.NewCrew": {
+        "playlist.New": {
             "type": "object",
             "properties": {
-                "credited": {
-                    "type": "boolean"
+                "createdBy": {
+                    "type": "integer"
                 },
-                "locked": {
-                    "type": "boolean"
+                "description": {
+                    "type": "string"
                 },
-                "ordering": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 },
-                "positionID": {
-                    "type": "integer"
+                "status": {
+                    "type": "string"
+                },
+                "thumbnail": {
+                    "type": "string"
+                },
+                "videoIDs": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "clapper.NewEvent": {
+        "playlist.Playlist": {
             "type": "object",
             "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "integer"
+                },
                 "description": {
                     "type": "string"
                 },
-                "endDate": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "eventType": {
+                "status": {
                     "type": "string"
                 },
-                "isCancelled": {
-                    "type": "boolean"
+                "thumbnail": {
+                    "type": "string"
                 },
-                "isPrivate": {
-                    "type": "boolean"
+                "updatedAt": {
+                    "type": "string"
                 },
-                "isTentative": {
-                    "type": "boolean"
+                "updatedBy": {
+                    "type": "integer"
+                },
+                "videos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/video.Meta"
+                    }
+                }
+            }
+        },
+        "playout.Channel": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "\"Very good tennis\"",
+                    "type": "string"
+                },
+                "endpointID": {
+                    "description": "EndpointID is the stream endpoint feeding the channel, when set the\nchannel's status follows it going live and its scheduled times",
+                    "type": "integer"
                 },
                 "location": {
+                    "description": "\"Central Hall\"",
                     "type": "string"
                 },
                 "name": {
+                    "description": "\"YUSU Tennis 2020\"",
                     "type": "string"
                 },
-                "startDate": {
+                "outputType": {
+                    "type": "string"
+                },
+                "outputURL": {
+                    "type": "string"
+                },
+                "scheduledEnd": {
+                    "type": "string"
+                },
+                "scheduledStart": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "\"live\" or \"scheduled\" or \"cancelled\" or \"finished\"",
+                    "type": "string"
+                },
+                "thumbnail": {
+                    "type": "string"
+                },
+                "urlName": {
+                    "description": "\"tennis\"",
+                    "type": "string"
+                },
+                "visibility": {
                     "type": "string"
                 }
             }
         },
-        "clapper.NewSignup": {
+        "playout.ScheduleItem": {
             "type": "object",
             "properties": {
-                "arrivalTime": {
+                "createdAt": {
                     "type": "string"
                 },
-                "crew": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/clapper.NewCrew"
-                    }
+                "createdBy": {
+                    "type": "integer"
                 },
                 "description": {
                     "type": "string"
                 },
-                "endTime": {
+                "eventID": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Name is the title, or the name of the video or event when it isn't set",
                     "type": "string"
                 },
-                "eventID": {
+                "scheduleItemID": {
                     "type": "integer"
                 },
-                "startTime": {
+                "scheduledEnd": {
+                    "type": "string"
+                },
+                "scheduledStart": {
                     "type": "string"
                 },
                 "title": {
+                    "description": "Title overrides the video or event name, it is required for text items",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "\"video\" or \"event\" or \"text\"",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "updatedBy": {
+                    "type": "integer"
+                },
+                "videoID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "preferences.ListView": {
+            "type": "object",
+            "properties": {
+                "layout": {
+                    "description": "Layout is table or grid",
+                    "type": "string"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "sortBy": {
+                    "description": "SortBy is broadcastDate, createdAt, name or views",
                     "type": "string"
                 },
-                "unlockDate": {
+                "sortDescending": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "preferences.Notifications": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "Email sends notifications by email as well",
+                    "type": "boolean"
+                },
+                "encodeFailed": {
+                    "type": "boolean"
+                },
+                "encodeFinished": {
+                    "type": "boolean"
+                },
+                "videoPublished": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "preferences.Preferences": {
+            "type": "object",
+            "properties": {
+                "defaultPresetID": {
+                    "description": "DefaultPresetID is the encode preset new videos use when none is given",
+                    "type": "integer"
+                },
+                "defaultPublishStatus": {
+                    "description": "DefaultPublishStatus is private, internal or public",
                     "type": "string"
+                },
+                "defaultSeriesID": {
+                    "description": "DefaultSeriesID is the series new videos go in when none is given",
+                    "type": "integer"
+                },
+                "listView": {
+                    "$ref": "#/definitions/preferences.ListView"
+                },
+                "notifications": {
+                    "$ref": "#/definitions/preferences.Notifications"
                 }
             }
         },
-        "clapper.Position": {
+        "public.Breadcrumb": {
             "type": "object",
             "properties": {
-                "admin": {
-                    "type": "boolean"
-                },
-                "description": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
                 "name": {
                     "type": "string"
                 },
-                "permissionID": {
-                    "type": "integer"
+                "url": {
+                    "type": "string"
                 },
-                "positionID": {
-                    "type": "integer"
+                "useInURL": {
+                    "type": "boolean"
                 }
             }
         },
-        "clapper.Signup": {
+        "public.BreadcrumbItem": {
             "type": "object",
             "properties": {
-                "arrivalTime": {
-                    "type": "string"
+                "redirect": {
+                    "$ref": "#/definitions/misc.ResolvedRedirect"
                 },
-                "crew": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/clapper.CrewPosition"
-                    }
+                "series": {
+                    "$ref": "#/definitions/public.Series"
                 },
+                "video": {
+                    "$ref": "#/definitions/public.VideoItem"
+                }
+            }
+        },
+        "public.Channel": {
+            "type": "object",
+            "properties": {
                 "description": {
+                    "description": "\"Very good tennis\"",
                     "type": "string"
                 },
-                "endTime": {
+                "location": {
+                    "description": "\"Central Hall\"",
                     "type": "string"
                 },
-                "signupID": {
-                    "type": "integer"
+                "name": {
+                    "description": "\"YUSU Tennis 2020\"",
+                    "type": "string"
                 },
-                "startTime": {
+                "outputType": {
                     "type": "string"
                 },
-                "title": {
+                "outputURL": {
                     "type": "string"
                 },
-                "unlockDate": {
+                "scheduledEnd": {
                     "type": "string"
-                }
-            }
-        },
-        "clapper.User": {
-            "type": "object",
-            "properties": {
-                "firstName": {
+                },
+                "scheduledStart": {
                     "type": "string"
                 },
-                "lastName": {
+                "status": {
+                    "description": "\"live\" or \"scheduled\" or \"cancelled\" or \"finished\"",
                     "type": "string"
                 },
-                "nickname": {
+                "thumbnail": {
                     "type": "string"
                 },
-                "userID": {
-                    "type": "integer"
+                "urlName": {
+                    "description": "\"tennis\"",
+                    "type": "string"
                 }
             }
         },
-        "creator.searchInput": {
+        "public.CustomSetting": {
             "type": "object",
             "properties": {
-                "query": {
+                "settingID": {
                     "type": "string"
+                },
+                "value": {
+                    "description": "Value will be returned in Base64 from PostgreSQL"
                 }
             }
         },
-        "customsettings.CustomSetting": {
+        "public.MetaTag": {
             "type": "object",
             "properties": {
-                "public": {
-                    "type": "boolean"
-                },
-                "settingID": {
+                "content": {
                     "type": "string"
                 },
-                "value": {
-                    "description": "Value will be returned in Base64 from PostgreSQL"
+                "property": {
+                    "type": "string"
                 }
             }
         },
-        "customsettings.CustomSettingEditDTO": {
+        "public.NowNext": {
             "type": "object",
             "properties": {
-                "public": {
-                    "type": "boolean"
+                "next": {
+                    "$ref": "#/definitions/public.Programme"
                 },
-                "value": {
-                    "description": "Value will be returned in Base64 from PostgreSQL",
+                "now": {
+                    "$ref": "#/definitions/public.Programme"
+                },
+                "urlName": {
                     "type": "string"
                 }
             }
         },
-        "encode.Format": {
+        "public.OEmbed": {
             "type": "object",
             "properties": {
-                "arguments": {
-                    "type": "string"
-                },
-                "description": {
+                "author_name": {
                     "type": "string"
                 },
-                "fileSuffix": {
+                "author_url": {
                     "type": "string"
                 },
-                "formatID": {
+                "cache_age": {
                     "type": "integer"
                 },
                 "height": {
                     "type": "integer"
                 },
-                "mimeType": {
+                "html": {
                     "type": "string"
                 },
-                "mode": {
+                "provider_name": {
                     "type": "string"
                 },
-                "name": {
+                "provider_url": {
                     "type": "string"
                 },
-                "watermarked": {
-                    "type": "boolean"
+                "thumbnail_height": {
+                    "type": "integer"
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
+                "thumbnail_width": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
                 },
                 "width": {
                     "type": "integer"
                 }
             }
         },
-        "encode.Preset": {
+        "public.PageMeta": {
             "type": "object",
             "properties": {
                 "description": {
                     "type": "string"
                 },
-                "formats": {
+                "image": {
+                    "type": "string"
+                },
+                "tags": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/encode.Format"
+                        "$ref": "#/definitions/public.MetaTag"
                     }
                 },
-                "name": {
+                "title": {
                     "type": "string"
                 },
-                "presetID": {
-                    "type": "integer"
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "misc.List": {
+        "public.Playlist": {
             "type": "object",
             "properties": {
-                "alias": {
-                    "type": "string"
-                },
                 "description": {
                     "type": "string"
                 },
-                "isSubscribed": {
-                    "type": "boolean"
-                },
-                "listID": {
+                "id": {
                     "type": "integer"
                 },
                 "name": {
                     "type": "string"
                 },
-                "permissionID": {
-                    "type": "integer"
+                "thumbnail": {
+                    "type": "string"
                 },
-                "subscribers": {
+                "videos": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/misc.Subscriber"
+                        "$ref": "#/definitions/public.VideoItem"
                     }
                 }
             }
         },
-        "misc.Quote": {
+        "public.Programme": {
             "type": "object",
             "properties": {
-                "createdBy": {
-                    "type": "integer"
-                },
                 "description": {
                     "type": "string"
                 },
-                "id": {
+                "eventID": {
                     "type": "integer"
                 },
-                "quote": {
+                "scheduledEnd": {
+                    "type": "string"
+                },
+                "scheduledStart": {
+                    "type": "string"
+                },
+                "thumbnail": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "\"video\" or \"event\" or \"text\"",
                     "type": "string"
+                },
+                "videoID": {
+                    "type": "integer"
                 }
             }
         },
-        "misc.QuotePage": {
+        "public.RelatedVideo": {
             "type": "object",
             "properties": {
-                "lastPageIndex": {
+                "broadcastDate": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "duration": {
                     "type": "integer"
                 },
-                "quotes": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/misc.Quote"
-                    }
-                }
-            }
-        },
-        "misc.Subscriber": {
-            "type": "object",
-            "properties": {
-                "subscribeID": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/people.User"
+                "score": {
+                    "description": "Score is the weighted relevance to the original video, higher is better",
+                    "type": "number"
+                },
+                "seriesID": {
+                    "type": "integer"
+                },
+                "thumbnail": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "views": {
+                    "type": "integer"
                 }
             }
         },
-        "misc.Webcam": {
+        "public.Series": {
             "type": "object",
             "properties": {
-                "file": {
+                "childSeries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/public.SeriesMeta"
+                    }
+                },
+                "description": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "mimeType": {
+                "name": {
                     "type": "string"
                 },
-                "name": {
+                "thumbnail": {
+                    "type": "string"
+                },
+                "url": {
                     "type": "string"
+                },
+                "videos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/public.VideoMeta"
+                    }
                 }
             }
         },
-        "people.CountOfficerships": {
+        "public.SeriesMeta": {
             "type": "object",
             "properties": {
-                "currentOfficers": {
-                    "type": "integer"
+                "description": {
+                    "type": "string"
                 },
-                "currentOfficerships": {
+                "id": {
                     "type": "integer"
                 },
-                "totalOfficers": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 },
-                "totalOfficerships": {
-                    "type": "integer"
+                "thumbnail": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
-        "people.CountUsers": {
+        "public.Team": {
             "type": "object",
             "properties": {
-                "activeUsers": {
+                "emailAlias": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "activeUsersPast24Hours": {
-                    "type": "integer"
+                "longDescription": {
+                    "type": "string"
                 },
-                "activeUsersPastYear": {
-                    "type": "integer"
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/public.TeamMember"
+                    }
                 },
-                "totalUsers": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
+                },
+                "shortDescription": {
+                    "type": "string"
                 }
             }
         },
-        "people.Officership": {
+        "public.TeamMember": {
             "type": "object",
             "properties": {
-                "currentOfficers": {
-                    "type": "integer"
-                },
-                "description": {
+                "avatar": {
                     "type": "string"
                 },
                 "emailAlias": {
                     "type": "string"
                 },
-                "historyWikiURL": {
+                "endDate": {
                     "type": "string"
                 },
-                "ifUnfilled": {
-                    "type": "boolean"
-                },
-                "isCurrent": {
-                    "type": "boolean"
+                "historywikiURL": {
+                    "type": "string"
                 },
-                "isTeamDeputy": {
+                "isDeputy": {
                     "type": "boolean"
                 },
-                "isTeamLeader": {
+                "isLeader": {
                     "type": "boolean"
                 },
-                "name": {
+                "officerDescription": {
                     "type": "string"
                 },
-                "officershipID": {
-                    "type": "integer"
+                "officerName": {
+                    "type": "string"
                 },
-                "previousOfficers": {
-                    "type": "integer"
+                "pronouns": {
+                    "type": "string"
                 },
-                "roleID": {
-                    "type": "integer"
+                "startDate": {
+                    "type": "string"
                 },
-                "teamID": {
-                    "type": "integer"
+                "teamEmail": {
+                    "type": "string"
                 },
-                "teamName": {
+                "userName": {
                     "type": "string"
                 }
             }
         },
-        "people.OfficershipAddEditDTO": {
+        "public.VideoFile": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
+                "height": {
+                    "type": "integer"
                 },
-                "emailAlias": {
+                "mimeType": {
                     "type": "string"
                 },
-                "historyWikiURL": {
+                "mode": {
                     "type": "string"
                 },
-                "isCurrent": {
-                    "type": "boolean"
-                },
-                "name": {
+                "uri": {
                     "type": "string"
+                },
+                "width": {
+                    "type": "integer"
                 }
             }
         },
-        "people.OfficershipMember": {
+        "public.VideoItem": {
             "type": "object",
             "properties": {
-                "endDate": {
+                "broadcastDate": {
                     "type": "string"
                 },
-                "officerID": {
-                    "type": "integer"
+                "description": {
+                    "type": "string"
                 },
-                "officershipMemberID": {
+                "duration": {
                     "type": "integer"
                 },
-                "officershipName": {
-                    "type": "string"
-                },
-                "startDate": {
-                    "type": "string"
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/public.VideoFile"
+                    }
                 },
-                "teamID": {
+                "id": {
                     "type": "integer"
                 },
-                "teamName": {
+                "name": {
                     "type": "string"
                 },
-                "userID": {
+                "seriesID": {
                     "type": "integer"
                 },
-                "userName": {
+                "thumbnail": {
                     "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "views": {
+                    "type": "integer"
                 }
             }
         },
-        "people.OfficershipMemberAddEditDTO": {
+        "public.VideoMeta": {
             "type": "object",
             "properties": {
-                "endDate": {
+                "broadcastDate": {
                     "type": "string"
                 },
-                "officerID": {
+                "description": {
+                    "type": "string"
+                },
+                "duration": {
                     "type": "integer"
                 },
-                "startDate": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "userID": {
+                "seriesID": {
+                    "type": "integer"
+                },
+                "thumbnail": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "views": {
                     "type": "integer"
                 }
             }
         },
-        "people.OfficershipTeam": {
+        "search.Params": {
             "type": "object",
             "properties": {
-                "currentOfficers": {
+                "maxDuration": {
+                    "description": "MaxDuration limits the results to videos at most this many seconds long",
                     "type": "integer"
                 },
-                "currentOfficerships": {
+                "minDuration": {
+                    "description": "MinDuration limits the results to videos at least this many seconds long",
                     "type": "integer"
                 },
-                "emailAlias": {
-                    "type": "string"
+                "page": {
+                    "description": "Page is the page number, starting at 1",
+                    "type": "integer"
                 },
-                "fullDescription": {
+                "query": {
+                    "description": "Query is the web search style query, supports \"quoted phrases\", OR and -exclusion",
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "seriesID": {
+                    "description": "SeriesID limits the results to the series and any of its child series",
+                    "type": "integer"
                 },
-                "shortDescription": {
-                    "type": "string"
+                "size": {
+                    "description": "Size is the number of results per page, defaults to 20 and is capped at 100",
+                    "type": "integer"
                 },
-                "teamID": {
+                "year": {
+                    "description": "Year limits the results to videos broadcast in that year",
                     "type": "integer"
                 }
             }
         },
-        "people.OfficershipTeamAddEditDTO": {
+        "search.Result": {
             "type": "object",
             "properties": {
-                "emailAlias": {
+                "broadcastDate": {
                     "type": "string"
                 },
-                "fullDescription": {
+                "description": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "duration": {
+                    "type": "integer"
                 },
-                "shortDescription": {
+                "headline": {
+                    "description": "Headline is a snippet of the description with the matches wrapped in \u003cb\u003e\u003c/b\u003e",
                     "type": "string"
-                }
-            }
-        },
-        "people.OfficershipTeamMember": {
-            "type": "object",
-            "properties": {
-                "currentOfficers": {
-                    "type": "integer"
                 },
-                "isCurrent": {
-                    "type": "boolean"
+                "id": {
+                    "type": "integer"
                 },
-                "isDeputy": {
-                    "type": "boolean"
+                "name": {
+                    "type": "string"
                 },
-                "isLeader": {
-                    "type": "boolean"
+                "rank": {
+                    "description": "Rank is the ts_rank of the video against the query, higher is better",
+                    "type": "number"
                 },
-                "officerID": {
+                "seriesID": {
                     "type": "integer"
                 },
-                "officerName": {
+                "status": {
                     "type": "string"
                 },
-                "officershipTeamMemberID": {
-                    "type": "integer"
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "previousOfficers": {
+                "thumbnail": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "views": {
                     "type": "integer"
                 }
             }
         },
-        "people.OfficershipTeamMemberAddDTO": {
+        "search.Results": {
             "type": "object",
             "properties": {
-                "isDeputy": {
-                    "type": "boolean"
-                },
-                "isLeader": {
-                    "type": "boolean"
+                "fullCount": {
+                    "type": "integer"
                 },
-                "officerID": {
+                "page": {
                     "type": "integer"
                 },
-                "officershipTeamMemberID": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/search.Result"
+                    }
+                },
+                "size": {
                     "type": "integer"
                 }
             }
         },
-        "people.Permission": {
+        "series.Meta": {
             "type": "object",
             "properties": {
+                "depth": {
+                    "type": "integer"
+                },
                 "description": {
                     "type": "string"
                 },
@@ -5065,23 +8692,27 @@ const docTemplate = `{
                 },
                 "name": {
                     "type": "string"
-                }
-            }
-        },
-        "people.PermissionAddEditDTO": {
-            "type": "object",
-            "properties": {
-                "description": {
+                },
+                "thumbnail": {
                     "type": "string"
                 },
-                "name": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "people.PermissionWithRolesCount": {
+        "series.Series": {
             "type": "object",
             "properties": {
+                "childSeries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/series.Meta"
+                    }
+                },
+                "depth": {
+                    "type": "integer"
+                },
                 "description": {
                     "type": "string"
                 },
@@ -5091,823 +8722,981 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
-                "roles": {
-                    "type": "integer"
+                "thumbnail": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "videos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/video.Meta"
+                    }
                 }
             }
         },
-        "people.Role": {
+        "stats.ModeStats": {
             "type": "object",
             "properties": {
-                "description": {
+                "mode": {
                     "type": "string"
                 },
-                "id": {
+                "views": {
                     "type": "integer"
-                },
-                "name": {
-                    "type": "string"
                 }
             }
         },
-        "people.RoleAddEditDTO": {
+        "stats.RetentionBucket": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
+                "percent": {
+                    "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "retained": {
+                    "type": "number"
+                },
+                "views": {
+                    "type": "integer"
                 }
             }
         },
-        "people.RoleFull": {
+        "stats.SeriesStats": {
             "type": "object",
             "properties": {
-                "description": {
+                "averagePercent": {
+                    "type": "number"
+                },
+                "from": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "interval": {
+                    "type": "string"
+                },
+                "modes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/stats.ModeStats"
+                    }
                 },
                 "name": {
                     "type": "string"
                 },
-                "permissions": {
+                "retention": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/people.Permission"
+                        "$ref": "#/definitions/stats.RetentionBucket"
                     }
                 },
-                "users": {
+                "seriesID": {
+                    "type": "integer"
+                },
+                "timeline": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/people.User"
+                        "$ref": "#/definitions/stats.TimeBucket"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "uniqueViewers": {
+                    "type": "integer"
+                },
+                "videos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/stats.VideoSummary"
                     }
+                },
+                "views": {
+                    "type": "integer"
                 }
             }
         },
-        "people.RolePermission": {
+        "stats.TimeBucket": {
             "type": "object",
             "properties": {
-                "permissionID": {
+                "start": {
+                    "type": "string"
+                },
+                "uniqueViewers": {
                     "type": "integer"
                 },
-                "roleID": {
+                "views": {
                     "type": "integer"
                 }
             }
         },
-        "people.RoleUser": {
+        "stats.VideoGlobalStats": {
             "type": "object",
             "properties": {
-                "roleID": {
+                "totalPendingVideos": {
                     "type": "integer"
                 },
-                "userID": {
+                "totalStorageUsed": {
+                    "type": "integer"
+                },
+                "totalVideoHits": {
+                    "type": "integer"
+                },
+                "totalVideos": {
                     "type": "integer"
                 }
             }
         },
-        "people.RoleWithCount": {
+        "stats.VideoStats": {
             "type": "object",
             "properties": {
-                "description": {
+                "averagePercent": {
+                    "type": "number"
+                },
+                "from": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "interval": {
+                    "type": "string"
+                },
+                "modes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/stats.ModeStats"
+                    }
                 },
                 "name": {
                     "type": "string"
                 },
-                "permissions": {
+                "retention": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/stats.RetentionBucket"
+                    }
+                },
+                "timeline": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/stats.TimeBucket"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "uniqueViewers": {
                     "type": "integer"
                 },
-                "users": {
+                "videoID": {
+                    "type": "integer"
+                },
+                "views": {
                     "type": "integer"
                 }
             }
         },
-        "people.RoleWithPermissions": {
+        "stats.VideoSummary": {
             "type": "object",
             "properties": {
-                "description": {
+                "averagePercent": {
+                    "type": "number"
+                },
+                "name": {
                     "type": "string"
                 },
-                "id": {
+                "uniqueViewers": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "videoID": {
+                    "type": "integer"
                 },
-                "permissions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/people.Permission"
-                    }
+                "views": {
+                    "type": "integer"
                 }
             }
         },
-        "people.User": {
+        "storage.DanglingFile": {
             "type": "object",
             "properties": {
-                "avatar": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "firstName": {
-                    "type": "string"
+                "collectable": {
+                    "type": "boolean"
                 },
-                "id": {
+                "fileID": {
                     "type": "integer"
                 },
-                "lastName": {
-                    "type": "string"
+                "isSource": {
+                    "type": "boolean"
                 },
-                "nickname": {
+                "modifiedAt": {
                     "type": "string"
                 },
-                "permissions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/people.Permission"
-                    }
-                },
-                "useGravatar": {
-                    "type": "boolean"
+                "status": {
+                    "type": "string"
                 },
-                "username": {
+                "uri": {
                     "type": "string"
+                },
+                "videoID": {
+                    "type": "integer"
                 }
             }
         },
-        "people.UserFull": {
+        "storage.DanglingReference": {
             "type": "object",
             "properties": {
-                "avatar": {
+                "id": {
                     "type": "string"
                 },
-                "createdAt": {
+                "table": {
                     "type": "string"
                 },
-                "createdBy": {
-                    "type": "integer"
-                },
-                "deletedAt": {
+                "url": {
                     "type": "string"
-                },
-                "deletedBy": {
+                }
+            }
+        },
+        "storage.FileDiscrepancy": {
+            "type": "object",
+            "properties": {
+                "actualSize": {
                     "type": "integer"
                 },
-                "email": {
-                    "type": "string"
+                "fileID": {
+                    "type": "integer"
                 },
-                "enabled": {
+                "missing": {
                     "type": "boolean"
                 },
-                "firstName": {
+                "size": {
+                    "type": "integer"
+                },
+                "uri": {
                     "type": "string"
                 },
-                "id": {
+                "videoID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "storage.FormatUsage": {
+            "type": "object",
+            "properties": {
+                "actualSize": {
+                    "description": "ActualSize is the size of the objects, only set when reconciled",
                     "type": "integer"
                 },
-                "lastLogin": {
-                    "type": "string"
+                "dropSavings": {
+                    "description": "DropSavings is what would be freed by dropping the format, source\nfiles aren't included since they would have to be kept",
+                    "type": "integer"
                 },
-                "lastName": {
-                    "type": "string"
+                "dropSavingsActual": {
+                    "type": "integer"
                 },
-                "nickname": {
-                    "type": "string"
+                "files": {
+                    "type": "integer"
                 },
-                "permissions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/people.Permission"
-                    }
+                "formatID": {
+                    "type": "integer"
                 },
-                "roles": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/people.Role"
-                    }
+                "missing": {
+                    "description": "Missing is the number of files without an object, only set when reconciled",
+                    "type": "integer"
                 },
-                "updatedAt": {
+                "name": {
                     "type": "string"
                 },
-                "updatedBy": {
+                "size": {
+                    "description": "Size is the recorded size of the files",
                     "type": "integer"
                 },
-                "useGravatar": {
-                    "type": "boolean"
-                },
-                "username": {
-                    "type": "string"
+                "sourceFiles": {
+                    "type": "integer"
                 }
             }
         },
-        "playlist.New": {
+        "storage.GCBucket": {
             "type": "object",
             "properties": {
-                "createdBy": {
-                    "type": "integer"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "name": {
+                "bucket": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "string"
+                "objects": {
+                    "type": "integer"
                 },
-                "thumbnail": {
-                    "type": "string"
+                "orphans": {
+                    "type": "integer"
                 },
-                "videoIDs": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "size": {
+                    "type": "integer"
                 }
             }
         },
-        "playlist.Playlist": {
+        "storage.GCReport": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.GCBucket"
+                    }
+                },
+                "cutoff": {
+                    "description": "Cutoff is the end of the grace period, only things older than it are collectable",
                     "type": "string"
                 },
-                "createdBy": {
-                    "type": "integer"
+                "danglingFiles": {
+                    "description": "DanglingFiles are video file rows whose object is missing",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.DanglingFile"
+                    }
                 },
-                "description": {
-                    "type": "string"
+                "danglingReferences": {
+                    "description": "DanglingReferences are thumbnails and avatars pointing at a missing object",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.DanglingReference"
+                    }
                 },
-                "id": {
+                "deletedFiles": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
+                "deletedObjects": {
+                    "type": "integer"
                 },
-                "status": {
-                    "type": "string"
+                "dryRun": {
+                    "type": "boolean"
                 },
-                "thumbnail": {
-                    "type": "string"
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "updatedAt": {
-                    "type": "string"
+                "orphanObjects": {
+                    "description": "OrphanObjects are objects that nothing in the database refers to",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.OrphanObject"
+                    }
                 },
-                "updatedBy": {
+                "reclaimedSize": {
+                    "description": "ReclaimedSize is the size in bytes of the deleted objects, or what would be\nreclaimed on a dry run",
                     "type": "integer"
                 },
-                "videos": {
+                "unlistedBuckets": {
+                    "description": "UnlistedBuckets are buckets video files are in that weren't listed. Their\nobjects could be anywhere, so nothing is deleted while there are any.",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/video.Meta"
+                        "type": "string"
                     }
                 }
             }
         },
-        "playout.Channel": {
+        "storage.OrphanObject": {
             "type": "object",
             "properties": {
-                "description": {
-                    "description": "\"Very good tennis\"",
-                    "type": "string"
-                },
-                "location": {
-                    "description": "\"Central Hall\"",
-                    "type": "string"
-                },
-                "name": {
-                    "description": "\"YUSU Tennis 2020\"",
-                    "type": "string"
-                },
-                "outputType": {
-                    "type": "string"
-                },
-                "outputURL": {
-                    "type": "string"
-                },
-                "scheduledEnd": {
+                "bucket": {
                     "type": "string"
                 },
-                "scheduledStart": {
-                    "type": "string"
-                },
-                "status": {
-                    "description": "\"live\" or \"scheduled\" or \"cancelled\" or \"finished\"",
-                    "type": "string"
+                "collectable": {
+                    "type": "boolean"
                 },
-                "thumbnail": {
+                "key": {
                     "type": "string"
                 },
-                "urlName": {
-                    "description": "\"tennis\"",
+                "lastModified": {
                     "type": "string"
                 },
-                "visibility": {
-                    "type": "string"
+                "size": {
+                    "type": "integer"
                 }
             }
         },
-        "public.Breadcrumb": {
+        "storage.SeriesUsage": {
             "type": "object",
             "properties": {
-                "id": {
+                "actualSize": {
+                    "description": "ActualSize is the size of the objects, only set when reconciled",
+                    "type": "integer"
+                },
+                "depth": {
+                    "type": "integer"
+                },
+                "files": {
+                    "type": "integer"
+                },
+                "missing": {
+                    "description": "Missing is the number of files without an object, only set when reconciled",
                     "type": "integer"
                 },
                 "name": {
                     "type": "string"
                 },
-                "url": {
-                    "type": "string"
+                "seriesID": {
+                    "type": "integer"
                 },
-                "useInURL": {
-                    "type": "boolean"
+                "size": {
+                    "description": "Size is the recorded size of the files",
+                    "type": "integer"
                 }
             }
         },
-        "public.BreadcrumbItem": {
+        "storage.Usage": {
             "type": "object",
             "properties": {
+                "discrepancies": {
+                    "description": "Discrepancies are files whose recorded size doesn't match the object store",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.FileDiscrepancy"
+                    }
+                },
+                "formats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.FormatUsage"
+                    }
+                },
+                "reconciled": {
+                    "type": "boolean"
+                },
                 "series": {
-                    "$ref": "#/definitions/public.Series"
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.SeriesUsage"
+                    }
                 },
-                "video": {
-                    "$ref": "#/definitions/public.VideoItem"
+                "total": {
+                    "$ref": "#/definitions/storage.UsageFigure"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.UserUsage"
+                    }
+                },
+                "years": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.YearUsage"
+                    }
                 }
             }
         },
-        "public.Channel": {
+        "storage.UsageFigure": {
             "type": "object",
             "properties": {
-                "description": {
-                    "description": "\"Very good tennis\"",
-                    "type": "string"
-                },
-                "location": {
-                    "description": "\"Central Hall\"",
-                    "type": "string"
+                "actualSize": {
+                    "description": "ActualSize is the size of the objects, only set when reconciled",
+                    "type": "integer"
                 },
-                "name": {
-                    "description": "\"YUSU Tennis 2020\"",
-                    "type": "string"
+                "files": {
+                    "type": "integer"
                 },
-                "outputType": {
-                    "type": "string"
+                "missing": {
+                    "description": "Missing is the number of files without an object, only set when reconciled",
+                    "type": "integer"
                 },
-                "outputURL": {
-                    "type": "string"
+                "size": {
+                    "description": "Size is the recorded size of the files",
+                    "type": "integer"
+                }
+            }
+        },
+        "storage.UserUsage": {
+            "type": "object",
+            "properties": {
+                "actualSize": {
+                    "description": "ActualSize is the size of the objects, only set when reconciled",
+                    "type": "integer"
                 },
-                "scheduledEnd": {
-                    "type": "string"
+                "files": {
+                    "type": "integer"
                 },
-                "scheduledStart": {
-                    "type": "string"
+                "missing": {
+                    "description": "Missing is the number of files without an object, only set when reconciled",
+                    "type": "integer"
                 },
-                "status": {
-                    "description": "\"live\" or \"scheduled\" or \"cancelled\" or \"finished\"",
+                "name": {
                     "type": "string"
                 },
-                "thumbnail": {
-                    "type": "string"
+                "size": {
+                    "description": "Size is the recorded size of the files",
+                    "type": "integer"
                 },
-                "urlName": {
-                    "description": "\"tennis\"",
-                    "type": "string"
+                "userID": {
+                    "type": "integer"
                 }
             }
         },
-        "public.CustomSetting": {
+        "storage.YearUsage": {
             "type": "object",
             "properties": {
-                "settingID": {
-                    "type": "string"
+                "actualSize": {
+                    "description": "ActualSize is the size of the objects, only set when reconciled",
+                    "type": "integer"
                 },
-                "value": {
-                    "description": "Value will be returned in Base64 from PostgreSQL"
+                "files": {
+                    "type": "integer"
+                },
+                "missing": {
+                    "description": "Missing is the number of files without an object, only set when reconciled",
+                    "type": "integer"
+                },
+                "size": {
+                    "description": "Size is the recorded size of the files",
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
                 }
             }
         },
-        "public.Playlist": {
+        "stream.Endpoint": {
             "type": "object",
             "properties": {
-                "description": {
+                "active": {
+                    "description": "Active indicates if this endpoint is currently being used",
+                    "type": "boolean"
+                },
+                "application": {
+                    "description": "Application defines which RTMP application this is valid for",
                     "type": "string"
                 },
-                "id": {
+                "autoRemove": {
+                    "description": "AutoRemove indicates that this endpoint can be automatically removed when the end valid time comes, optional",
+                    "type": "boolean"
+                },
+                "blocked": {
+                    "description": "Blocked prevents the endpoint from going live",
+                    "type": "boolean"
+                },
+                "endValid": {
+                    "description": "EndValid defines the optional end time that this endpoint stops being valid",
+                    "type": "string"
+                },
+                "endpointId": {
                     "type": "integer"
                 },
+                "expiresSoon": {
+                    "description": "ExpiresSoon indicates the endpoint stops being valid soon",
+                    "type": "boolean"
+                },
                 "name": {
+                    "description": "Name is the unique name given in an application",
                     "type": "string"
                 },
-                "thumbnail": {
+                "notes": {
+                    "description": "Notes is an optional internal note for the endpoint",
                     "type": "string"
                 },
-                "videos": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/public.VideoItem"
-                    }
-                }
-            }
-        },
-        "public.SearchInput": {
-            "type": "object",
-            "properties": {
-                "query": {
+                "pwd": {
+                    "description": "Pwd is masked when a key is set, the key itself can't be read back",
+                    "type": "string"
+                },
+                "startValid": {
+                    "description": "StartValid defines the optional start time that this endpoint becomes valid",
                     "type": "string"
+                },
+                "valid": {
+                    "description": "Valid indicates the endpoint is inside its validity window, so it can publish",
+                    "type": "boolean"
                 }
             }
         },
-        "public.Series": {
+        "stream.EndpointAddEditDTO": {
             "type": "object",
             "properties": {
-                "childSeries": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/public.SeriesMeta"
-                    }
-                },
-                "description": {
+                "application": {
+                    "description": "Application defines which RTMP application this is valid for",
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "autoRemove": {
+                    "description": "AutoRemove indicates that this endpoint can be automatically removed when the end valid time comes, optional",
+                    "type": "boolean"
+                },
+                "blocked": {
+                    "description": "Blocked prevents the endpoint from going live, optional defaults to false",
+                    "type": "boolean"
+                },
+                "endValid": {
+                    "description": "EndValid defines the optional end time that this endpoint stops being valid, RFC3339",
+                    "type": "string"
                 },
                 "name": {
+                    "description": "Name is the unique name given in an application",
                     "type": "string"
                 },
-                "thumbnail": {
+                "notes": {
+                    "description": "Notes is an optional internal note for the endpoint",
                     "type": "string"
                 },
-                "url": {
+                "pwd": {
+                    "description": "Pwd defines an extra layer of security for authentication, it is stored hashed.\nWhen editing leave it unset, or send back the masked key, to keep the current key.",
                     "type": "string"
                 },
-                "videos": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/public.VideoMeta"
-                    }
+                "startValid": {
+                    "description": "StartValid defines the optional start time that this endpoint becomes valid, RFC3339",
+                    "type": "string"
                 }
             }
         },
-        "public.SeriesMeta": {
+        "stream.FindEndpoint": {
             "type": "object",
             "properties": {
-                "description": {
+                "application": {
+                    "description": "Application defines which RTMP application this is valid for",
                     "type": "string"
                 },
-                "id": {
+                "endpointId": {
+                    "description": "EndpointID is the unique database id of the stream",
                     "type": "integer"
                 },
                 "name": {
+                    "description": "Name is the unique name given in an application",
                     "type": "string"
                 },
-                "thumbnail": {
-                    "type": "string"
-                },
-                "url": {
+                "pwd": {
+                    "description": "Pwd defines an extra layer of security for authentication",
                     "type": "string"
                 }
             }
         },
-        "public.Team": {
+        "stream.GuestEndpointNew": {
             "type": "object",
             "properties": {
-                "emailAlias": {
+                "application": {
+                    "description": "Application defines which RTMP application the guest streams to",
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "email": {
+                    "description": "Email is where the instructions are sent, optional",
+                    "type": "string"
                 },
-                "longDescription": {
+                "endValid": {
+                    "description": "EndValid defines when the key stops working and the endpoint is removed, RFC3339",
                     "type": "string"
                 },
-                "members": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/public.TeamMember"
-                    }
+                "guest": {
+                    "description": "Guest is who the key is for, e.g. another station or a society",
+                    "type": "string"
                 },
                 "name": {
+                    "description": "Name is the unique name given in an application, generated when left unset",
                     "type": "string"
                 },
-                "shortDescription": {
+                "startValid": {
+                    "description": "StartValid defines when the key starts working, defaults to now, RFC3339",
                     "type": "string"
                 }
             }
         },
-        "public.TeamMember": {
+        "stream.GuestKey": {
             "type": "object",
             "properties": {
-                "avatar": {
+                "application": {
                     "type": "string"
                 },
-                "emailAlias": {
-                    "type": "string"
+                "emailed": {
+                    "description": "Emailed indicates the instructions were sent to the requested email",
+                    "type": "boolean"
                 },
-                "endDate": {
+                "endValid": {
                     "type": "string"
                 },
-                "historywikiURL": {
-                    "type": "string"
+                "endpointId": {
+                    "type": "integer"
                 },
-                "isDeputy": {
-                    "type": "boolean"
+                "guest": {
+                    "type": "string"
                 },
-                "isLeader": {
-                    "type": "boolean"
+                "instructions": {
+                    "description": "Instructions is a plain text sheet that can be passed on to the guest",
+                    "type": "string"
                 },
-                "officerDescription": {
+                "name": {
                     "type": "string"
                 },
-                "officerName": {
+                "pwd": {
                     "type": "string"
                 },
-                "pronouns": {
+                "rtmpServer": {
+                    "description": "RTMPServer and RTMPStreamKey are the values streaming software asks for",
                     "type": "string"
                 },
-                "startDate": {
+                "rtmpStreamKey": {
                     "type": "string"
                 },
-                "teamEmail": {
+                "srtUrl": {
                     "type": "string"
                 },
-                "userName": {
+                "startValid": {
                     "type": "string"
                 }
             }
         },
-        "public.VideoFile": {
+        "stream.Incoming": {
             "type": "object",
             "properties": {
-                "height": {
+                "incomingId": {
                     "type": "integer"
                 },
-                "mimeType": {
-                    "type": "string"
-                },
-                "mode": {
-                    "type": "string"
-                },
-                "uri": {
+                "name": {
                     "type": "string"
-                },
-                "width": {
-                    "type": "integer"
                 }
             }
         },
-        "public.VideoItem": {
+        "stream.Metric": {
             "type": "object",
             "properties": {
-                "broadcastDate": {
+                "audioCodec": {
                     "type": "string"
                 },
-                "description": {
-                    "type": "string"
+                "bitrateKbps": {
+                    "type": "integer"
                 },
-                "duration": {
+                "clients": {
                     "type": "integer"
                 },
-                "files": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/public.VideoFile"
-                    }
+                "frameRate": {
+                    "type": "number"
                 },
-                "id": {
+                "height": {
                     "type": "integer"
                 },
-                "name": {
-                    "type": "string"
-                },
-                "seriesID": {
+                "metricId": {
                     "type": "integer"
                 },
-                "thumbnail": {
+                "recordedAt": {
                     "type": "string"
                 },
-                "url": {
+                "server": {
                     "type": "string"
                 },
-                "views": {
+                "sessionId": {
+                    "type": "integer"
+                },
+                "videoCodec": {
+                    "type": "string"
+                },
+                "width": {
                     "type": "integer"
                 }
             }
         },
-        "public.VideoMeta": {
+        "stream.PlaybackToken": {
             "type": "object",
             "properties": {
-                "broadcastDate": {
+                "application": {
                     "type": "string"
                 },
-                "description": {
+                "channel": {
                     "type": "string"
                 },
-                "duration": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "integer"
+                "expiresAt": {
+                    "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "seriesID": {
-                    "type": "integer"
-                },
-                "thumbnail": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "stream.Provider": {
+            "type": "object",
+            "properties": {
+                "host": {
+                    "description": "Host is the RTMP URL to push to, without the key",
                     "type": "string"
                 },
-                "url": {
+                "key": {
+                    "description": "Key is the provider's stream key, it's masked when listed",
                     "type": "string"
                 },
-                "views": {
+                "providerId": {
                     "type": "integer"
+                },
+                "user": {
+                    "description": "User is the account the stream goes out on",
+                    "type": "string"
                 }
             }
         },
-        "series.Meta": {
+        "stream.Quality": {
             "type": "object",
             "properties": {
-                "depth": {
-                    "type": "integer"
-                },
-                "description": {
+                "command": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
                 "name": {
                     "type": "string"
                 },
-                "thumbnail": {
-                    "type": "string"
+                "qualityId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "stream.RotatedKey": {
+            "type": "object",
+            "properties": {
+                "endpointId": {
+                    "type": "integer"
                 },
-                "url": {
+                "pwd": {
                     "type": "string"
                 }
             }
         },
-        "series.Series": {
+        "stream.Session": {
             "type": "object",
             "properties": {
-                "childSeries": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/series.Meta"
-                    }
+                "application": {
+                    "type": "string"
                 },
-                "depth": {
+                "clientIp": {
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "Duration is in seconds, up to now for sessions that haven't ended",
                     "type": "integer"
                 },
-                "description": {
+                "endReason": {
                     "type": "string"
                 },
-                "id": {
+                "endedAt": {
+                    "type": "string"
+                },
+                "endpointId": {
                     "type": "integer"
                 },
                 "name": {
                     "type": "string"
                 },
-                "thumbnail": {
+                "server": {
+                    "description": "Server is the media server the stream was published to, srs or nginx-rtmp",
                     "type": "string"
                 },
-                "url": {
-                    "type": "string"
+                "sessionId": {
+                    "type": "integer"
                 },
-                "videos": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/video.Meta"
-                    }
+                "startedAt": {
+                    "type": "string"
                 }
             }
         },
-        "stats.VideoGlobalStats": {
+        "stream.TranscodeMapping": {
             "type": "object",
             "properties": {
-                "totalPendingVideos": {
+                "incomingId": {
                     "type": "integer"
                 },
-                "totalStorageUsed": {
+                "mappingId": {
                     "type": "integer"
                 },
-                "totalVideoHits": {
+                "providerId": {
                     "type": "integer"
                 },
-                "totalVideos": {
+                "quality": {
+                    "type": "string"
+                },
+                "qualityId": {
                     "type": "integer"
+                },
+                "stream": {
+                    "description": "Stream, Transcoder and Quality name the mapping, as the \"All transcodes\" view",
+                    "type": "string"
+                },
+                "transcoder": {
+                    "type": "string"
                 }
             }
         },
-        "stream.Endpoint": {
+        "stream.TranscodeMappingAddEdit": {
             "type": "object",
             "properties": {
-                "active": {
-                    "description": "Active indicates if this endpoint is currently being used",
-                    "type": "boolean"
-                },
-                "application": {
-                    "description": "Application defines which RTMP application this is valid for",
-                    "type": "string"
-                },
-                "autoRemove": {
-                    "description": "AutoRemove indicates that this endpoint can be automatically removed when the end valid time comes, optional",
-                    "type": "boolean"
-                },
-                "blocked": {
-                    "description": "Blocked prevents the endpoint from going live",
-                    "type": "boolean"
-                },
-                "endValid": {
-                    "description": "EndValid defines the optional end time that this endpoint stops being valid",
-                    "type": "string"
-                },
-                "endpointId": {
+                "incomingId": {
                     "type": "integer"
                 },
-                "name": {
-                    "description": "Name is the unique name given in an application",
-                    "type": "string"
+                "providerId": {
+                    "type": "integer"
                 },
-                "notes": {
-                    "description": "Notes is an optional internal note for the endpoint",
-                    "type": "string"
+                "qualityId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "utils.CursorPage-misc_Quote": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/misc.Quote"
+                    }
                 },
-                "pwd": {
-                    "description": "Pwd defines an extra layer of security for authentication",
+                "next": {
+                    "description": "Next is the link to the following page, empty on the last page",
                     "type": "string"
                 },
-                "startValid": {
-                    "description": "StartValid defines the optional start time that this endpoint becomes valid",
+                "prev": {
+                    "description": "Prev is the link to the preceding page, empty on the first page",
                     "type": "string"
                 }
             }
         },
-        "stream.EndpointAddEditDTO": {
+        "utils.CursorPage-public_VideoMeta": {
             "type": "object",
             "properties": {
-                "application": {
-                    "description": "Application defines which RTMP application this is valid for",
-                    "type": "string"
-                },
-                "autoRemove": {
-                    "description": "AutoRemove indicates that this endpoint can be automatically removed when the end valid time comes, optional",
-                    "type": "boolean"
-                },
-                "blocked": {
-                    "description": "Blocked prevents the endpoint from going live, optional defaults to false",
-                    "type": "boolean"
-                },
-                "endValid": {
-                    "description": "EndValid defines the optional end time that this endpoint stops being valid, RFC3339",
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/public.VideoMeta"
+                    }
                 },
-                "name": {
-                    "description": "Name is the unique name given in an application",
+                "next": {
+                    "description": "Next is the link to the following page, empty on the last page",
                     "type": "string"
                 },
-                "notes": {
-                    "description": "Notes is an optional internal note for the endpoint",
+                "prev": {
+                    "description": "Prev is the link to the preceding page, empty on the first page",
                     "type": "string"
+                }
+            }
+        },
+        "utils.CursorPage-stream_Session": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/stream.Session"
+                    }
                 },
-                "pwd": {
-                    "description": "Pwd defines an extra layer of security for authentication",
+                "next": {
+                    "description": "Next is the link to the following page, empty on the last page",
                     "type": "string"
                 },
-                "startValid": {
-                    "description": "StartValid defines the optional start time that this endpoint becomes valid, RFC3339",
+                "prev": {
+                    "description": "Prev is the link to the preceding page, empty on the first page",
                     "type": "string"
                 }
             }
         },
-        "stream.FindEndpoint": {
+        "utils.CursorPage-video_Meta": {
             "type": "object",
             "properties": {
-                "application": {
-                    "description": "Application defines which RTMP application this is valid for",
-                    "type": "string"
-                },
-                "endpointId": {
-                    "description": "EndpointID is the unique database id of the stream",
-                    "type": "integer"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/video.Meta"
+                    }
                 },
-                "name": {
-                    "description": "Name is the unique name given in an application",
+                "next": {
+                    "description": "Next is the link to the following page, empty on the last page",
                     "type": "string"
                 },
-                "pwd": {
-                    "description": "Pwd defines an extra layer of security for authentication",
+                "prev": {
+                    "description": "Prev is the link to the preceding page, empty on the first page",
                     "type": "string"
                 }
             }
@@ -5929,6 +9718,9 @@ const docTemplate = `{
                 "encodeFormat": {
                     "type": "string"
                 },
+                "fileID": {
+                    "type": "integer"
+                },
                 "mimeType": {
                     "type": "string"
                 },
@@ -5943,6 +9735,18 @@ const docTemplate = `{
                 }
             }
         },
+        "video.FileURL": {
+            "type": "object",
+            "properties": {
+                "expiresAt": {
+                    "description": "ExpiresAt is when a signed URL stops working, public files aren't signed",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
         "video.Item": {
             "type": "object",
             "properties": {
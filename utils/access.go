@@ -25,6 +25,9 @@ type (
 		SuperUserAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc
 		ModifyUserAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc
 		ManageStreamAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc
+		PermalinkAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc
+		VideoStatsAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc
+		PlaybackAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc
 	}
 
 	Accesser struct {
@@ -279,3 +282,63 @@ func (a *Accesser) ManageStreamAuthMiddleware(next echo.HandlerFunc) echo.Handle
 		return echo.NewHTTPError(http.StatusUnauthorized, err)
 	}
 }
+
+// PermalinkAuthMiddleware checks an HTTP request for a valid token either in the header or cookie and if the user can manage redirects
+func (a *Accesser) PermalinkAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		claims, status, err := a.GetToken(c.Request())
+		if err != nil {
+			return &echo.HTTPError{
+				Code:     status,
+				Message:  err.Error(),
+				Internal: err,
+			}
+		}
+		for _, p := range claims.Permissions {
+			if p == users.SuperUser || p == users.CMSPermalinkAdmin {
+				return next(c)
+			}
+		}
+		return echo.NewHTTPError(http.StatusUnauthorized, err)
+	}
+}
+
+// VideoStatsAuthMiddleware checks an HTTP request for a valid token either in the header or cookie and if the user can view video stats
+func (a *Accesser) VideoStatsAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		claims, status, err := a.GetToken(c.Request())
+		if err != nil {
+			return &echo.HTTPError{
+				Code:     status,
+				Message:  err.Error(),
+				Internal: err,
+			}
+		}
+		for _, p := range claims.Permissions {
+			if p == users.SuperUser || p == users.VideoStats {
+				return next(c)
+			}
+		}
+		return echo.NewHTTPError(http.StatusUnauthorized, err)
+	}
+}
+
+// PlaybackAuthMiddleware checks an HTTP request for a valid token either in the header or cookie and if the user can watch internal streams
+func (a *Accesser) PlaybackAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		claims, status, err := a.GetToken(c.Request())
+		if err != nil {
+			return &echo.HTTPError{
+				Code:     status,
+				Message:  err.Error(),
+				Internal: err,
+			}
+		}
+		for _, p := range claims.Permissions {
+			if p == users.SuperUser || p == users.Cobra || p == users.Streamer || p == users.Director {
+				return next(c)
+			}
+		}
+		return echo.NewHTTPError(http.StatusUnauthorized, err)
+	}
+}
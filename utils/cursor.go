@@ -0,0 +1,195 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	// Cursor is an opaque keyset pagination position. It holds the sort key and
+	// unique ID of the row on the edge of a page, so inserting rows while
+	// someone is paging won't shift or duplicate results.
+	Cursor struct {
+		// Key is the sort key of the row, it is passed straight to the database,
+		// so it will be coerced to the type of the sort column
+		Key string `json:"k"`
+		// ID is the unique ID of the row, used as a tiebreaker
+		ID int `json:"i"`
+		// Backwards indicates the cursor is for the previous page
+		Backwards bool `json:"b,omitempty"`
+	}
+
+	// CursorPage is the envelope for a cursor paginated list
+	CursorPage[T any] struct {
+		Items []T `json:"items"`
+		// Next is the link to the following page, empty on the last page
+		Next string `json:"next,omitempty"`
+		// Prev is the link to the preceding page, empty on the first page
+		Prev string `json:"prev,omitempty"`
+	}
+)
+
+const (
+	DefaultCursorPageSize = 20
+	MaxCursorPageSize     = 100
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// TimeKey formats a time to be used as a cursor key
+func TimeKey(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+// IntKey formats an integer to be used as a cursor key
+func IntKey(i int) string {
+	return strconv.Itoa(i)
+}
+
+// Encode returns the opaque string form of the cursor
+func (c Cursor) Encode() string {
+	b, err := json.Marshal(c)
+	if err != nil {
+		panic(fmt.Errorf("failed to marshal cursor: %w", err))
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor parses a cursor from its opaque string form
+func DecodeCursor(s string) (Cursor, error) {
+	var c Cursor
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	err = json.Unmarshal(b, &c)
+	if err != nil || c.Key == "" {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return c, nil
+}
+
+// CursorParams reads the "cursor" and "size" query parameters of a request,
+// the cursor is nil when requesting the first page
+func CursorParams(c echo.Context) (*Cursor, int, error) {
+	size := DefaultCursorPageSize
+
+	if raw := c.QueryParam("size"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			return nil, 0, errors.New("invalid size, must be a positive number")
+		}
+
+		size = min(parsed, MaxCursorPageSize)
+	}
+
+	raw := c.QueryParam("cursor")
+	if raw == "" {
+		return nil, size, nil
+	}
+
+	cursor, err := DecodeCursor(raw)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &cursor, size, nil
+}
+
+// Keyset returns the WHERE condition and ORDER BY clauses to fetch the page
+// after the cursor on (keyExpr, idColumn). desc is the display order of the
+// list, when the cursor is going backwards the query order is flipped, and
+// NewCursorPage will put it back.
+//
+// Queries should be limited to size + 1 rows, so we can tell if there is
+// another page.
+func Keyset(cursor *Cursor, keyExpr, idColumn string, desc bool) (sq.Sqlizer, []string) {
+	backwards := cursor != nil && cursor.Backwards
+
+	// Moving forward through a descending list is the same as moving backwards
+	// through an ascending list
+	descQuery := desc != backwards
+
+	direction, op := "ASC", ">"
+	if descQuery {
+		direction, op = "DESC", "<"
+	}
+
+	order := []string{keyExpr + " " + direction, idColumn + " " + direction}
+
+	if cursor == nil {
+		return sq.And{}, order
+	}
+
+	return sq.Expr(fmt.Sprintf("(%s, %s) %s (?, ?)", keyExpr, idColumn, op), cursor.Key, cursor.ID), order
+}
+
+// NewCursorPage trims the look-ahead row fetched by a Keyset query and sets the
+// next and previous cursors. key returns the sort key and ID of a row.
+func NewCursorPage[T any](rows []T, size int, cursor *Cursor, key func(T) (string, int)) CursorPage[T] {
+	backwards := cursor != nil && cursor.Backwards
+
+	hasMore := len(rows) > size
+	if hasMore {
+		rows = rows[:size]
+	}
+
+	if backwards {
+		slices.Reverse(rows)
+	}
+
+	page := CursorPage[T]{Items: NonNil(rows)}
+
+	if len(rows) == 0 {
+		return page
+	}
+
+	// A backwards page always has a following page, the one we came from
+	if hasMore || backwards {
+		k, id := key(rows[len(rows)-1])
+		page.Next = Cursor{Key: k, ID: id}.Encode()
+	}
+
+	// A forwards page has a preceding page if we didn't start at the beginning
+	if backwards && hasMore || !backwards && cursor != nil {
+		k, id := key(rows[0])
+		page.Prev = Cursor{Key: k, ID: id, Backwards: true}.Encode()
+	}
+
+	return page
+}
+
+// WithLinks turns the next and previous cursors into links based on the
+// current request URL, keeping any other query parameters
+func (p CursorPage[T]) WithLinks(u *url.URL) CursorPage[T] {
+	link := func(cursor string) string {
+		if cursor == "" {
+			return ""
+		}
+
+		l := *u
+		q := l.Query()
+		q.Set("cursor", cursor)
+		l.RawQuery = q.Encode()
+
+		return l.RequestURI()
+	}
+
+	p.Next = link(p.Next)
+	p.Prev = link(p.Prev)
+
+	return p
+}
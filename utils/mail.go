@@ -35,6 +35,17 @@ type Mail struct {
 	tplData interface{}
 }
 
+// NewMail creates an email whose body is the template executed with data
+func NewMail(subject, to, from string, tpl *template.Template, data interface{}) Mail {
+	return Mail{
+		Subject: subject,
+		To:      to,
+		From:    from,
+		tpl:     *tpl,
+		tplData: data,
+	}
+}
+
 // NewMailer creates a new SMTP client
 func NewMailer(config MailConfig) (*Mailer, error) {
 	smtpServer := mail.SMTPServer{
@@ -0,0 +1,82 @@
+-- +goose Up
+
+alter table video.items
+    add column search_document tsvector;
+
+comment on column video.items.search_document is 'Weighted full-text search document, name (A) > tags (B) > series (C) > description (D).
+Maintained by triggers on video.items and video.series, do not write directly';
+
+-- +goose StatementBegin
+create function video.build_search_document(item_name text, item_tags text[], item_series_id integer,
+                                            item_description text) returns tsvector
+    language sql
+    stable
+as
+$$
+SELECT setweight(to_tsvector('english', coalesce(item_name, '')), 'A') ||
+       setweight(to_tsvector('english', array_to_string(coalesce(item_tags, ARRAY []::text[]), ' ')), 'B') ||
+       setweight(to_tsvector('english', coalesce((SELECT string_agg(parent.name, ' ' ORDER BY parent.lft)
+                                                  FROM video.series node,
+                                                       video.series parent
+                                                  WHERE node.series_id = item_series_id
+                                                    AND node.lft BETWEEN parent.lft AND parent.rgt), '')), 'C') ||
+       setweight(to_tsvector('english', coalesce(item_description, '')), 'D');
+$$;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+create function video.items_search_document_trigger() returns trigger
+    language plpgsql
+as
+$$
+BEGIN
+    NEW.search_document := video.build_search_document(NEW.name, NEW.tags, NEW.series_id, NEW.description);
+    RETURN NEW;
+END;
+$$;
+-- +goose StatementEnd
+
+create trigger items_search_document
+    before insert or update of name, tags, series_id, description
+    on video.items
+    for each row
+execute function video.items_search_document_trigger();
+
+-- +goose StatementBegin
+create function video.series_search_document_trigger() returns trigger
+    language plpgsql
+as
+$$
+BEGIN
+    UPDATE video.items item
+    SET search_document = video.build_search_document(item.name, item.tags, item.series_id, item.description)
+    FROM video.series node
+    WHERE item.series_id = node.series_id
+      AND node.lft BETWEEN NEW.lft AND NEW.rgt;
+    RETURN NULL;
+END;
+$$;
+-- +goose StatementEnd
+
+create trigger series_search_document
+    after update of name, lft, rgt
+    on video.series
+    for each row
+    when (OLD.name IS DISTINCT FROM NEW.name OR OLD.lft IS DISTINCT FROM NEW.lft OR OLD.rgt IS DISTINCT FROM NEW.rgt)
+execute function video.series_search_document_trigger();
+
+UPDATE video.items
+SET search_document = video.build_search_document(name, tags, series_id, description);
+
+create index items_search_document_idx
+    on video.items using gin (search_document);
+
+-- +goose Down
+
+DROP INDEX video.items_search_document_idx;
+DROP TRIGGER series_search_document ON video.series;
+DROP FUNCTION video.series_search_document_trigger();
+DROP TRIGGER items_search_document ON video.items;
+DROP FUNCTION video.items_search_document_trigger();
+DROP FUNCTION video.build_search_document(text, text[], integer, text);
+ALTER TABLE video.items DROP COLUMN search_document;
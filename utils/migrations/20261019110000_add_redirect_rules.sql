@@ -0,0 +1,36 @@
+-- +goose Up
+
+alter table misc.redirects
+    add column match_type   text                     default 'exact'::text not null
+        constraint match_type_chk
+            check (match_type = ANY (ARRAY ['exact'::text, 'prefix'::text, 'wildcard'::text])),
+    add column hits         bigint                   default 0             not null,
+    add column last_used_at timestamp with time zone,
+    add column created_at   timestamp with time zone default now()         not null,
+    add column created_by   integer
+        references people.users
+            on update cascade on delete set null;
+
+update misc.redirects
+set external = false
+where external is null;
+
+alter table misc.redirects
+    alter column external set not null;
+
+comment on column misc.redirects.source_url is 'URL path without leading or trailing slashes';
+
+comment on column misc.redirects.match_type is 'exact matches the whole path, prefix matches the path and anything under it
+appending the remainder to the destination, wildcard matches * to anything which can be used in the destination as $1, $2...';
+
+comment on column misc.redirects.last_used_at is 'Used with hits to find stale redirects that can be pruned';
+
+-- +goose Down
+
+ALTER TABLE misc.redirects
+    ALTER COLUMN external DROP NOT NULL,
+    DROP COLUMN created_by,
+    DROP COLUMN created_at,
+    DROP COLUMN last_used_at,
+    DROP COLUMN hits,
+    DROP COLUMN match_type;
@@ -0,0 +1,46 @@
+-- +goose Up
+
+create table misc.short_links
+(
+    link_id     integer generated by default as identity
+        primary key,
+    code        text                                   not null
+        unique
+        constraint code_chk
+            check (code ~ '^[a-z0-9-]{3,32}$'),
+    target_type text                                   not null
+        constraint target_type_chk
+            check (target_type = ANY (ARRAY ['video'::text, 'series'::text, 'playlist'::text, 'channel'::text, 'url'::text])),
+    target_id   integer,
+    target_url  text,
+    expires_at  timestamp with time zone,
+    created_at  timestamp with time zone default now() not null,
+    created_by  integer
+        references people.users
+            on update cascade on delete set null,
+    constraint target_chk
+        check ((target_type = 'url' AND target_url IS NOT NULL) OR (target_type <> 'url' AND target_id IS NOT NULL))
+);
+
+comment on table misc.short_links is 'Short links for promo material, served as ystv.co.uk/s/<code>';
+
+comment on column misc.short_links.target_id is 'ID of the video, series, playlist or channel, depending on target_type';
+
+create table misc.short_link_clicks
+(
+    link_id  integer           not null
+        references misc.short_links
+            on update cascade on delete cascade,
+    day      date              not null,
+    referrer text              not null,
+    clicks   integer default 0 not null,
+    constraint short_link_clicks_pkey
+        primary key (link_id, day, referrer)
+);
+
+comment on column misc.short_link_clicks.referrer is 'Host of the referring page, empty when there was no referrer';
+
+-- +goose Down
+
+DROP TABLE misc.short_link_clicks;
+DROP TABLE misc.short_links;
@@ -0,0 +1,33 @@
+-- +goose Up
+
+delete from creator.preferences a
+    using creator.preferences b
+where a.user_id = b.user_id
+  and a.ctid < b.ctid;
+
+update creator.preferences
+set preferences = '{}'::jsonb
+where preferences is null;
+
+alter table creator.preferences
+    alter column preferences set default '{}'::jsonb,
+    alter column preferences set not null,
+    add constraint preferences_pkey
+        primary key (user_id),
+    drop constraint if exists preferences_user_id_fkey,
+    add constraint preferences_user_id_fkey
+        foreign key (user_id) references people.users
+            on update cascade on delete cascade;
+
+comment on column creator.preferences.preferences is 'Creator defaults, list view options and notification choices,
+validated by web-api before being stored';
+
+-- +goose Down
+
+ALTER TABLE creator.preferences
+    DROP CONSTRAINT preferences_user_id_fkey,
+    ADD CONSTRAINT preferences_user_id_fkey
+        FOREIGN KEY (user_id) REFERENCES people.users,
+    DROP CONSTRAINT preferences_pkey,
+    ALTER COLUMN preferences DROP NOT NULL,
+    ALTER COLUMN preferences DROP DEFAULT;
@@ -0,0 +1,51 @@
+-- +goose Up
+
+create table playout.schedule_items
+(
+    schedule_item_id integer generated by default as identity
+        primary key,
+    channel_id       integer                                not null
+        references playout.channel
+            on update cascade on delete cascade,
+    item_type        text                                   not null
+        constraint item_type_chk
+            check (item_type = ANY (ARRAY ['video'::text, 'event'::text, 'text'::text])),
+    video_id         integer
+        references video.items
+            on update cascade on delete cascade,
+    event_id         integer
+        references event.events
+            on update cascade on delete cascade,
+    title            text                     default ''::text not null
+        constraint title_chk
+            check (char_length(title) <= 100),
+    description      text                     default ''::text not null,
+    scheduled_start  timestamp with time zone               not null,
+    scheduled_end    timestamp with time zone               not null,
+    created_at       timestamp with time zone default now() not null,
+    created_by       integer
+        references people.users
+            on update cascade on delete set null,
+    updated_at       timestamp with time zone,
+    updated_by       integer
+        references people.users
+            on update cascade on delete set null,
+    constraint time_chk
+        check (scheduled_end > scheduled_start),
+    constraint target_chk
+        check ((item_type = 'video' AND video_id IS NOT NULL AND event_id IS NULL) OR
+               (item_type = 'event' AND event_id IS NOT NULL AND video_id IS NULL) OR
+               (item_type = 'text' AND video_id IS NULL AND event_id IS NULL AND title <> ''))
+);
+
+create index schedule_items_channel_start_idx
+    on playout.schedule_items (channel_id, scheduled_start);
+
+comment on table playout.schedule_items is 'Programme schedule of a channel, items of a channel are not allowed to overlap
+which is checked by web-api';
+
+comment on column playout.schedule_items.title is 'Overrides the video or event name, required for text items';
+
+-- +goose Down
+
+DROP TABLE playout.schedule_items;
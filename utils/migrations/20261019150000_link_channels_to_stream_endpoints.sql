@@ -0,0 +1,40 @@
+-- +goose Up
+
+alter table playout.channel
+    add column endpoint_id integer
+        references web_api.stream_endpoints
+            on update cascade on delete set null;
+
+comment on column playout.channel.endpoint_id is 'Stream endpoint feeding the channel, publishing and unpublishing it moves the
+channel between scheduled, live and finished';
+
+create table playout.channel_events
+(
+    event_id    integer generated by default as identity
+        primary key,
+    channel_id  integer                                not null
+        references playout.channel
+            on update cascade on delete cascade,
+    from_status text                                   not null,
+    to_status   text                                   not null,
+    reason      text                                   not null
+        constraint reason_chk
+            check (reason = ANY (ARRAY ['publish'::text, 'unpublish'::text, 'schedule'::text])),
+    endpoint_id integer
+        references web_api.stream_endpoints
+            on update cascade on delete set null,
+    created_at  timestamp with time zone default now() not null
+);
+
+create index channel_events_channel_created_idx
+    on playout.channel_events (channel_id, created_at);
+
+comment on table playout.channel_events is 'Automatic channel status transitions, each is also sent as a notification
+on playout_channel_status';
+
+-- +goose Down
+
+DROP TABLE playout.channel_events;
+
+ALTER TABLE playout.channel
+    DROP COLUMN endpoint_id;
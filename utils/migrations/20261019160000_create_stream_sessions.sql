@@ -0,0 +1,36 @@
+-- +goose Up
+
+create table web_api.stream_sessions
+(
+    session_id  integer generated by default as identity
+        primary key,
+    endpoint_id integer
+        references web_api.stream_endpoints
+            on update cascade on delete set null,
+    application text                                   not null,
+    name        text                                   not null,
+    client_ip   inet,
+    server      text                                   not null
+        constraint server_chk
+            check (server = ANY (ARRAY ['srs'::text, 'nginx-rtmp'::text])),
+    started_at  timestamp with time zone default now() not null,
+    ended_at    timestamp with time zone,
+    end_reason  text,
+    constraint time_chk
+        check ((ended_at IS NULL) OR (ended_at >= started_at))
+);
+
+create index stream_sessions_endpoint_started_idx
+    on web_api.stream_sessions (endpoint_id, started_at);
+
+create index stream_sessions_started_idx
+    on web_api.stream_sessions (started_at);
+
+comment on table web_api.stream_sessions is 'Each time a stream endpoint publishes, application and name are kept so
+the history survives the endpoint being removed';
+
+comment on column web_api.stream_sessions.end_reason is 'unpublish, expired or superseded, null while still publishing';
+
+-- +goose Down
+
+DROP TABLE web_api.stream_sessions;
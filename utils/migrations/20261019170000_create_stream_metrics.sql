@@ -0,0 +1,37 @@
+-- +goose Up
+
+create table web_api.stream_metrics
+(
+    metric_id    integer generated by default as identity
+        primary key,
+    endpoint_id  integer                                not null
+        references web_api.stream_endpoints
+            on update cascade on delete cascade,
+    session_id   integer
+        references web_api.stream_sessions
+            on update cascade on delete set null,
+    server       text                                   not null
+        constraint server_chk
+            check (server = ANY (ARRAY ['srs'::text, 'nginx-rtmp'::text])),
+    recorded_at  timestamp with time zone default now() not null,
+    bitrate_kbps integer                                not null,
+    width        integer                                not null,
+    height       integer                                not null,
+    frame_rate   double precision                       not null,
+    video_codec  text                                   not null,
+    audio_codec  text                                   not null,
+    clients      integer                                not null
+);
+
+create index stream_metrics_endpoint_recorded_idx
+    on web_api.stream_metrics (endpoint_id, recorded_at);
+
+comment on table web_api.stream_metrics is 'Samples of live streams polled from the media servers, kept for a week';
+
+comment on column web_api.stream_sessions.end_reason is 'unpublish, expired, superseded or reconciled, null while still publishing';
+
+-- +goose Down
+
+DROP TABLE web_api.stream_metrics;
+
+COMMENT ON COLUMN web_api.stream_sessions.end_reason IS 'unpublish, expired or superseded, null while still publishing';
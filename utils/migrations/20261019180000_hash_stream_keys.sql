@@ -0,0 +1,17 @@
+-- +goose Up
+
+alter table web_api.stream_endpoints
+    add column pwd_hash text;
+
+comment on column web_api.stream_endpoints.pwd_hash is 'Salted bcrypt hash of the stream key';
+
+comment on column web_api.stream_endpoints.pwd is 'Plaintext stream key from before keys were hashed, moved into pwd_hash when
+the api starts';
+
+-- +goose Down
+
+-- Keys only stored as a hash can't be recovered, those endpoints need a new key set
+COMMENT ON COLUMN web_api.stream_endpoints.pwd IS NULL;
+
+ALTER TABLE web_api.stream_endpoints
+    DROP COLUMN pwd_hash;
@@ -0,0 +1,17 @@
+-- +goose Up
+
+-- Redirects are looked up by the path without leading or trailing slashes, so
+-- sources saved before they were normalised never matched. Where two sources
+-- normalise to the same path neither is changed, they're left for an admin to
+-- merge or delete, since saving either normalises it.
+update misc.redirects redirect
+set source_url = btrim(btrim(redirect.source_url), '/')
+where redirect.source_url <> btrim(btrim(redirect.source_url), '/')
+  and not exists(select 1
+                 from misc.redirects other
+                 where other.redirect_id <> redirect.redirect_id
+                   and btrim(btrim(other.source_url), '/') = btrim(btrim(redirect.source_url), '/'));
+
+-- +goose Down
+
+-- The original slashes aren't kept, the normalised sources still work
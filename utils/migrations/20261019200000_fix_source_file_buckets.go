@@ -0,0 +1,72 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+
+	"github.com/pressly/goose/v3"
+)
+
+func init() {
+	goose.AddMigrationContext(upFixSourceFileBuckets, downFixSourceFileBuckets)
+}
+
+// uploadedSourceFile matches the source files of uploaded videos, which are
+// named {year}_{video ID}_... by NewItem, in whichever bucket
+const uploadedSourceFile = `is_source AND substr(uri, strpos(uri, '/') + 1) ~ ('^[0-9]{4}_' || video_id || '_')`
+
+// upFixSourceFileBuckets moves the source files of uploaded videos to the serve
+// bucket. They were copied there but recorded as being in "videos", which is
+// only right when that's what the serve bucket is called. It's a Go migration
+// since the serve bucket is only known from the environment, so it fails when
+// there are files to move and it isn't set, rather than being marked as applied.
+func upFixSourceFileBuckets(ctx context.Context, tx *sql.Tx) error {
+	var count int
+
+	err := tx.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM video.files
+		WHERE split_part(uri, '/', 1) = 'videos' AND `+uploadedSourceFile+`;`).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("failed to count source files: %w", err)
+	}
+
+	if count == 0 {
+		return nil
+	}
+
+	serveBucket := os.Getenv("WAPI_BUCKET_VOD_SERVE")
+	if serveBucket == "" {
+		return fmt.Errorf("WAPI_BUCKET_VOD_SERVE needs to be set to move %d source files to the serve bucket", count)
+	}
+
+	if serveBucket == "videos" {
+		return nil
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE video.files
+		SET uri = $1 || '/' || substr(uri, strpos(uri, '/') + 1)
+		WHERE split_part(uri, '/', 1) = 'videos' AND `+uploadedSourceFile+`;`, serveBucket)
+	if err != nil {
+		return fmt.Errorf("failed to move source files to \"%s\": %w", serveBucket, err)
+	}
+
+	return nil
+}
+
+// downFixSourceFileBuckets records the source files of uploaded videos as being
+// in "videos" again, from whichever bucket they're in now
+func downFixSourceFileBuckets(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE video.files
+		SET uri = 'videos/' || substr(uri, strpos(uri, '/') + 1)
+		WHERE split_part(uri, '/', 1) <> 'videos' AND `+uploadedSourceFile+`;`)
+	if err != nil {
+		return fmt.Errorf("failed to move source files back to \"videos\": %w", err)
+	}
+
+	return nil
+}
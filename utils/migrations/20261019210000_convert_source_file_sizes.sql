@@ -0,0 +1,15 @@
+-- +goose Up
+
+-- The source files of uploaded videos had their size recorded in bytes, every
+-- other file is in kilobytes
+update video.files
+set size = size / 1024
+where is_source
+  and substr(uri, strpos(uri, '/') + 1) ~ ('^[0-9]{4}_' || video_id || '_');
+
+-- +goose Down
+
+UPDATE video.files
+SET size = size * 1024
+WHERE is_source
+  AND substr(uri, strpos(uri, '/') + 1) ~ ('^[0-9]{4}_' || video_id || '_');